@@ -4,6 +4,7 @@ import (
 	"backend/internal/config"
 	"backend/internal/logger"
 	"backend/internal/router"
+	"backend/internal/service"
 	"backend/pkg/database"
 	"context"
 	"errors"
@@ -26,16 +27,65 @@ func main() {
 	logger.Init(config.AppConfig.GinMode)
 	defer logger.Sync()
 
+	// 加载 JWT 密钥，配置错误时启动失败
+	service.GetJWTKeyring()
+
 	// 连接数据库
 	if err := database.Connect(config.AppConfig.MongoURI, config.AppConfig.MongoDatabase); err != nil {
 		logger.Fatal("数据库连接失败", logger.Err(err))
 	}
 
+	// 删除旧版 refresh token 唯一索引，失败时不能继续启动，否则新登录会因唯一键冲突失败
+	indexCtx, cancelIndex := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := service.NewAuthService().DropLegacyTokenIndexes(indexCtx); err != nil {
+		logger.Fatal("删除旧版 refresh token 索引失败", logger.Err(err))
+	}
+	cancelIndex()
+
+	// 为旧文章回填 slug、补建分词索引并同步标签计数，为旧回复补充 ID，迁移明文 refresh token
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		articleService := service.NewArticleService()
+		if n, err := articleService.BackfillSlugs(ctx); err != nil {
+			logger.Error("回填文章 slug 失败", logger.Err(err))
+		} else if n > 0 {
+			logger.Info("已回填文章 slug", logger.Int("count", n))
+		}
+		if n, err := articleService.IndexMissing(ctx); err != nil {
+			logger.Error("补建文章分词索引失败", logger.Err(err))
+		} else if n > 0 {
+			logger.Info("已补建文章分词索引", logger.Int("count", n))
+		}
+		if _, err := service.NewTagService().Rebuild(ctx); err != nil {
+			logger.Error("同步标签失败", logger.Err(err))
+		}
+		if n, err := service.NewMessageService().BackfillReplyIDs(ctx); err != nil {
+			logger.Error("回填回复 ID 失败", logger.Err(err))
+		} else if n > 0 {
+			logger.Info("已回填回复 ID", logger.Int("count", n))
+		}
+		if n, err := service.NewAuthService().MigrateRefreshTokens(ctx); err != nil {
+			logger.Error("迁移 refresh token 失败", logger.Err(err))
+		} else if n > 0 {
+			logger.Info("已将明文 refresh token 迁移为哈希存储", logger.Int("count", n))
+		}
+	}()
+
+	// 启动定时发布任务
+	publisherCtx, stopPublisher := context.WithCancel(context.Background())
+	service.NewArticlePublisher().Start(publisherCtx)
+	service.GetAccessTokenDenylist().Start(publisherCtx)
+	service.GetMailOutbox().Start(publisherCtx)
+
 	// 设置Gin模式
 	gin.SetMode(config.AppConfig.GinMode)
 
 	// 创建Gin引擎
 	r := gin.Default()
+	if err := r.SetTrustedProxies(config.AppConfig.TrustedProxies); err != nil {
+		logger.Fatal("TRUSTED_PROXIES 配置错误", logger.Err(err))
+	}
 
 	// 设置路由
 	router.Setup(r)
@@ -73,6 +123,9 @@ func main() {
 		logger.Error("服务器强制关闭", logger.Err(err))
 	}
 
+	// 停止后台任务
+	stopPublisher()
+
 	// 断开数据库连接
 	database.Disconnect()
 	logger.Info("服务器已退出")
@@ -15,18 +15,115 @@ type Config struct {
 	JWTSecret          string
 	AccessTokenExpire  time.Duration
 	RefreshTokenExpire time.Duration
+	// JWT 密钥环配置（JSON 数组），JWTKeysFile 优先；均未配置时使用 JWTSecret 作为 HS256 密钥（kid=v1）
+	JWTKeysFile string
+	JWTKeys     string
+	// access token 黑名单从数据库同步的间隔（多实例部署时其他实例吊销的令牌在该时间内生效）
+	AccessTokenDenylistSync time.Duration
+	// 旧签名密钥被新密钥取代后继续用于验证的时长
+	JWTKeyOverlap time.Duration
+	// refresh token 哈希密钥，未配置时使用 JWTSecret（修改后所有已签发的 refresh token 失效）
+	RefreshTokenHashKey string
+	// 已轮换的 refresh token 在该时长内首次重用且其后的令牌尚未轮换时不视为泄露（多个标签页同时刷新），0 表示不允许
+	RefreshReuseGrace time.Duration
+	// 用户禁用状态与角色缓存时长
+	UserStatusCacheTTL time.Duration
 	ServerPort         string
 	GinMode            string
 	UploadPath         string
 	BaseURL            string
 	// CORS 配置
 	CORSAllowOrigins []string
+	// 受信任的反向代理（IP 或 CIDR），只有来自这些地址的 X-Forwarded-For 才用于识别客户端 IP
+	TrustedProxies []string
 	// 上传限制
 	MaxUploadSize int64
 	// 默认头像路径
 	DefaultAvatarPath string
+	// 定时发布文章的检查间隔
+	ArticlePublishInterval time.Duration
+	// 站点统计信息缓存时长（文章、留言、用户写操作会立即使缓存失效）
+	ArticleInfoCacheTTL time.Duration
+	// 留言审核模式：off 全部自动通过；first_post 新用户需审核；all 除信任角色外全部需审核
+	MessageModeration string
+	// 发言自动通过审核的角色
+	MessageTrustedRoles []string
+	// first_post 模式下，累计通过多少条留言后自动信任
+	MessageTrustAfter int64
+	// 作者可修改自己留言的时间窗口，0 表示不限制
+	MessageEditWindow time.Duration
+	// 垃圾内容过滤：评分达到 SpamHoldScore 进入审核队列，达到 SpamRejectScore 直接拒绝
+	SpamHoldScore   int
+	SpamRejectScore int
+	// 单条留言允许的链接数
+	SpamMaxLinks int
+	// 同一用户在该时间内发布相同内容视为重复
+	SpamDuplicateWindow time.Duration
+	// 同一用户在 SpamRateWindow 内最多发言 SpamRateLimit 次
+	SpamRateLimit  int
+	SpamRateWindow time.Duration
+	// 敏感词表缓存时长（管理员修改会立即使本进程缓存失效）
+	SensitiveWordCacheTTL time.Duration
+	// 接口限流策略（令牌桶），Limit 为 0 表示不限流
+	RateLimitLogin    RateLimitRule
+	RateLimitRegister RateLimitRule
+	RateLimitCaptcha  RateLimitRule
+	RateLimitMessage  RateLimitRule
+	RateLimitUpload   RateLimitRule
+	RateLimitPassword RateLimitRule
+	RateLimitMail     RateLimitRule
+	// 登录防爆破：失败计数窗口、要求验证码的阈值、递增等待与锁定
+	LoginFailureWindow time.Duration
+	LoginCaptchaAfter  int
+	LoginDelayBase     time.Duration
+	LoginDelayMax      time.Duration
+	LoginLockAfter     int
+	LoginIPLockAfter   int
+	LoginLockDuration  time.Duration
+	// 密码强度策略：长度（字符）、至少包含的字符类别数（小写、大写、数字、符号），以及弱密码黑名单文件（每行一个）
+	PasswordMinLength     int
+	PasswordMaxLength     int
+	PasswordMinClasses    int
+	PasswordBlocklistFile string
+	// 密码重置令牌有效期
+	PasswordResetExpire time.Duration
+	// 邮件发送：smtp 通过 SMTP 服务器发送，file 保存为 .eml 文件，log 输出到日志（开发环境）
+	MailDriver   string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// SMTP 加密方式：starttls、tls 或 none
+	SMTPSecurity string
+	MailFileDir  string
+	// 发件箱投递间隔与最大尝试次数，超过次数后标记为失败
+	MailOutboxInterval time.Duration
+	MailMaxAttempts    int
+	// 邮件中链接的前端地址，如 https://example.com
+	MailLinkBaseURL string
+	// 邮箱验证令牌有效期
+	EmailVerifyExpire time.Duration
 }
 
+// RateLimitRule 限流规则：每 Period 最多 Limit 次请求
+type RateLimitRule struct {
+	Limit  int
+	Period time.Duration
+}
+
+// Enabled 规则是否生效
+func (r RateLimitRule) Enabled() bool {
+	return r.Limit > 0 && r.Period > 0
+}
+
+// 留言审核模式
+const (
+	MessageModerationOff       = "off"
+	MessageModerationFirstPost = "first_post"
+	MessageModerationAll       = "all"
+)
+
 // GetDefaultAvatarURL 获取完整的默认头像 URL
 func (c *Config) GetDefaultAvatarURL() string {
 	return c.BaseURL + c.DefaultAvatarPath
@@ -46,14 +143,91 @@ func Load() error {
 		refreshExpire = 168 * time.Hour
 	}
 
+	reuseGrace, err := time.ParseDuration(getEnv("REFRESH_REUSE_GRACE", "0s"))
+	if err != nil || reuseGrace < 0 {
+		reuseGrace = 0
+	}
+
+	userStatusTTL, err := time.ParseDuration(getEnv("USER_STATUS_CACHE_TTL", "30s"))
+	if err != nil {
+		userStatusTTL = 30 * time.Second
+	}
+
+	publishInterval, err := time.ParseDuration(getEnv("ARTICLE_PUBLISH_INTERVAL", "1m"))
+	if err != nil || publishInterval <= 0 {
+		publishInterval = time.Minute
+	}
+
+	infoTTL, err := time.ParseDuration(getEnv("ARTICLE_INFO_CACHE_TTL", "5m"))
+	if err != nil {
+		infoTTL = 5 * time.Minute
+	}
+
+	editWindow, err := time.ParseDuration(getEnv("MESSAGE_EDIT_WINDOW", "15m"))
+	if err != nil || editWindow < 0 {
+		editWindow = 15 * time.Minute
+	}
+
+	duplicateWindow, err := time.ParseDuration(getEnv("SPAM_DUPLICATE_WINDOW", "24h"))
+	if err != nil {
+		duplicateWindow = 24 * time.Hour
+	}
+	rateWindow, err := time.ParseDuration(getEnv("SPAM_RATE_WINDOW", "1m"))
+	if err != nil || rateWindow <= 0 {
+		rateWindow = time.Minute
+	}
+	wordCacheTTL, err := time.ParseDuration(getEnv("SENSITIVE_WORD_CACHE_TTL", "1m"))
+	if err != nil {
+		wordCacheTTL = time.Minute
+	}
+
+	loginWindow, err := time.ParseDuration(getEnv("LOGIN_FAILURE_WINDOW", "15m"))
+	if err != nil || loginWindow <= 0 {
+		loginWindow = 15 * time.Minute
+	}
+	loginDelayBase, err := time.ParseDuration(getEnv("LOGIN_DELAY_BASE", "1s"))
+	if err != nil || loginDelayBase < 0 {
+		loginDelayBase = time.Second
+	}
+	loginDelayMax, err := time.ParseDuration(getEnv("LOGIN_DELAY_MAX", "30s"))
+	if err != nil || loginDelayMax < 0 {
+		loginDelayMax = 30 * time.Second
+	}
+	loginLockDuration, err := time.ParseDuration(getEnv("LOGIN_LOCK_DURATION", "15m"))
+	if err != nil || loginLockDuration <= 0 {
+		loginLockDuration = 15 * time.Minute
+	}
+
+	resetExpire, err := time.ParseDuration(getEnv("PASSWORD_RESET_EXPIRE", "30m"))
+	if err != nil || resetExpire <= 0 {
+		resetExpire = 30 * time.Minute
+	}
+
+	outboxInterval, err := time.ParseDuration(getEnv("MAIL_OUTBOX_INTERVAL", "10s"))
+	if err != nil || outboxInterval <= 0 {
+		outboxInterval = 10 * time.Second
+	}
+	verifyExpire, err := time.ParseDuration(getEnv("EMAIL_VERIFY_EXPIRE", "24h"))
+	if err != nil || verifyExpire <= 0 {
+		verifyExpire = 24 * time.Hour
+	}
+	baseURL := getEnv("BASE_URL", "http://localhost:3000")
+
 	// 解析 CORS 允许的域名列表
-	corsOrigins := getEnv("CORS_ALLOW_ORIGINS", "")
-	var allowOrigins []string
-	if corsOrigins != "" {
-		allowOrigins = strings.Split(corsOrigins, ",")
-		for i := range allowOrigins {
-			allowOrigins[i] = strings.TrimSpace(allowOrigins[i])
-		}
+	allowOrigins := splitList(getEnv("CORS_ALLOW_ORIGINS", ""))
+	// 默认不信任任何代理，直接使用连接的对端地址
+	trustedProxies := splitList(getEnv("TRUSTED_PROXIES", ""))
+
+	// 默认不审核，与升级前行为一致
+	moderation := getEnv("MESSAGE_MODERATION", MessageModerationOff)
+	switch moderation {
+	case MessageModerationOff, MessageModerationFirstPost, MessageModerationAll:
+	default:
+		moderation = MessageModerationOff
+	}
+	trustAfter, err := strconv.ParseInt(getEnv("MESSAGE_TRUST_AFTER", "1"), 10, 64)
+	if err != nil || trustAfter < 1 {
+		trustAfter = 1
 	}
 
 	// 解析上传大小限制（默认 5MB）
@@ -64,19 +238,82 @@ func Load() error {
 		}
 	}
 
+	jwtSecret := getEnv("JWT_SECRET", "default-secret-key")
+	denylistSync, err := time.ParseDuration(getEnv("ACCESS_TOKEN_DENYLIST_SYNC", "30s"))
+	if err != nil || denylistSync <= 0 {
+		denylistSync = 30 * time.Second
+	}
+	// 重叠期默认为 access token 有效期的两倍，保证旧令牌自然过期
+	keyOverlap, err := time.ParseDuration(getEnv("JWT_KEY_OVERLAP", ""))
+	if err != nil || keyOverlap < accessExpire {
+		keyOverlap = 2 * accessExpire
+	}
+
 	AppConfig = &Config{
-		MongoURI:           getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		MongoDatabase:      getEnv("MONGO_DATABASE", "blog"),
-		JWTSecret:          getEnv("JWT_SECRET", "default-secret-key"),
-		AccessTokenExpire:  accessExpire,
-		RefreshTokenExpire: refreshExpire,
-		ServerPort:         getEnv("SERVER_PORT", "3000"),
-		GinMode:            getEnv("GIN_MODE", "debug"),
-		UploadPath:         getEnv("UPLOAD_PATH", "./public/img/upload"),
-		BaseURL:            getEnv("BASE_URL", "http://localhost:3000"),
-		CORSAllowOrigins:   allowOrigins,
-		MaxUploadSize:      maxUploadSize,
-		DefaultAvatarPath:  getEnv("DEFAULT_AVATAR_PATH", "/img/default_avatar.jpeg"),
+		MongoURI:                getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDatabase:           getEnv("MONGO_DATABASE", "blog"),
+		JWTSecret:               jwtSecret,
+		RefreshTokenHashKey:     getEnv("REFRESH_TOKEN_HASH_KEY", jwtSecret),
+		JWTKeysFile:             getEnv("JWT_KEYS_FILE", ""),
+		JWTKeys:                 getEnv("JWT_KEYS", ""),
+		JWTKeyOverlap:           keyOverlap,
+		AccessTokenDenylistSync: denylistSync,
+		AccessTokenExpire:       accessExpire,
+		RefreshTokenExpire:      refreshExpire,
+		RefreshReuseGrace:       reuseGrace,
+		UserStatusCacheTTL:      userStatusTTL,
+		ServerPort:              getEnv("SERVER_PORT", "3000"),
+		GinMode:                 getEnv("GIN_MODE", "debug"),
+		UploadPath:              getEnv("UPLOAD_PATH", "./public/img/upload"),
+		BaseURL:                 baseURL,
+		CORSAllowOrigins:        allowOrigins,
+		TrustedProxies:          trustedProxies,
+		MaxUploadSize:           maxUploadSize,
+		DefaultAvatarPath:       getEnv("DEFAULT_AVATAR_PATH", "/img/default_avatar.jpeg"),
+		ArticlePublishInterval:  publishInterval,
+		ArticleInfoCacheTTL:     infoTTL,
+		MessageModeration:       moderation,
+		MessageTrustedRoles:     splitList(getEnv("MESSAGE_TRUSTED_ROLES", "admin,moderator")),
+		MessageTrustAfter:       trustAfter,
+		MessageEditWindow:       editWindow,
+		SpamHoldScore:           getEnvInt("SPAM_HOLD_SCORE", 50),
+		SpamRejectScore:         getEnvInt("SPAM_REJECT_SCORE", 100),
+		SpamMaxLinks:            getEnvInt("SPAM_MAX_LINKS", 2),
+		SpamDuplicateWindow:     duplicateWindow,
+		SpamRateLimit:           getEnvInt("SPAM_RATE_LIMIT", 5),
+		SpamRateWindow:          rateWindow,
+		SensitiveWordCacheTTL:   wordCacheTTL,
+		RateLimitLogin:          getEnvRateLimit("RATE_LIMIT_LOGIN", "10/1m"),
+		RateLimitRegister:       getEnvRateLimit("RATE_LIMIT_REGISTER", "5/1h"),
+		RateLimitCaptcha:        getEnvRateLimit("RATE_LIMIT_CAPTCHA", "20/1m"),
+		RateLimitMessage:        getEnvRateLimit("RATE_LIMIT_MESSAGE", "10/1m"),
+		RateLimitUpload:         getEnvRateLimit("RATE_LIMIT_UPLOAD", "5/1m"),
+		RateLimitPassword:       getEnvRateLimit("RATE_LIMIT_PASSWORD", "5/10m"),
+		RateLimitMail:           getEnvRateLimit("RATE_LIMIT_MAIL", "3/10m"),
+		LoginFailureWindow:      loginWindow,
+		LoginCaptchaAfter:       getEnvInt("LOGIN_CAPTCHA_AFTER", 3),
+		LoginDelayBase:          loginDelayBase,
+		LoginDelayMax:           loginDelayMax,
+		LoginLockAfter:          getEnvInt("LOGIN_LOCK_AFTER", 10),
+		LoginIPLockAfter:        getEnvInt("LOGIN_IP_LOCK_AFTER", 50),
+		LoginLockDuration:       loginLockDuration,
+		PasswordMinLength:       getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordMaxLength:       getEnvInt("PASSWORD_MAX_LENGTH", 72),
+		PasswordMinClasses:      getEnvInt("PASSWORD_MIN_CLASSES", 2),
+		PasswordBlocklistFile:   getEnv("PASSWORD_BLOCKLIST_FILE", ""),
+		PasswordResetExpire:     resetExpire,
+		MailDriver:              getEnv("MAIL_DRIVER", "log"),
+		MailFrom:                getEnv("MAIL_FROM", "Blog <noreply@localhost>"),
+		SMTPHost:                getEnv("SMTP_HOST", ""),
+		SMTPPort:                getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:            getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:            getEnv("SMTP_PASSWORD", ""),
+		SMTPSecurity:            getEnv("SMTP_SECURITY", "starttls"),
+		MailFileDir:             getEnv("MAIL_FILE_DIR", "./data/mail"),
+		MailOutboxInterval:      outboxInterval,
+		MailMaxAttempts:         getEnvInt("MAIL_MAX_ATTEMPTS", 8),
+		MailLinkBaseURL:         strings.TrimRight(getEnv("MAIL_LINK_BASE_URL", baseURL), "/"),
+		EmailVerifyExpire:       verifyExpire,
 	}
 	return nil
 }
@@ -87,3 +324,51 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt 读取整数配置，缺失或格式错误时使用默认值
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(getEnv(key, "")); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// splitList 解析逗号分隔的列表，忽略空项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvRateLimit 读取 "次数/时长" 格式的限流规则（如 10/1m），off 或 0 表示关闭，格式错误时使用默认值
+func getEnvRateLimit(key, defaultValue string) RateLimitRule {
+	if rule, ok := parseRateLimitRule(getEnv(key, defaultValue)); ok {
+		return rule
+	}
+	rule, _ := parseRateLimitRule(defaultValue)
+	return rule
+}
+
+func parseRateLimitRule(value string) (RateLimitRule, bool) {
+	value = strings.TrimSpace(value)
+	if value == "off" || value == "0" {
+		return RateLimitRule{}, true
+	}
+	limitStr, periodStr, found := strings.Cut(value, "/")
+	if !found {
+		return RateLimitRule{}, false
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	if err != nil || limit < 0 {
+		return RateLimitRule{}, false
+	}
+	period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || period <= 0 {
+		return RateLimitRule{}, false
+	}
+	return RateLimitRule{Limit: limit, Period: period}, true
+}
@@ -4,6 +4,8 @@ import (
 	"backend/internal/model"
 	"backend/pkg/database"
 	"context"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,17 +14,50 @@ import (
 )
 
 type ArticleDAO struct {
-	collection     *mongo.Collection
-	infoCollection *mongo.Collection
+	collection *mongo.Collection
 }
 
 func NewArticleDAO() *ArticleDAO {
 	return &ArticleDAO{
-		collection:     database.Collection("articles"),
-		infoCollection: database.Collection("article_infos"),
+		collection: database.Collection("articles"),
 	}
 }
 
+// publicFilter 在 filter 基础上追加公开可见条件：已发布，或已到发布时间的定时文章；
+// 没有 status 字段的旧数据视为已发布
+func publicFilter(filter bson.M) bson.M {
+	visible := bson.M{"$or": []bson.M{
+		{"status": bson.M{"$exists": false}},
+		{"status": model.ArticleStatusPublished},
+		{"status": model.ArticleStatusScheduled, "publish_at": bson.M{"$lte": time.Now()}},
+	}}
+	if len(filter) == 0 {
+		return visible
+	}
+	return bson.M{"$and": []bson.M{filter, visible}}
+}
+
+// listFilter 构造标签和分类过滤条件，MatchAllTags 为 true 时要求包含全部标签
+func listFilter(filter *model.ArticleFilter) bson.M {
+	result := bson.M{}
+	if filter == nil {
+		return result
+	}
+
+	switch {
+	case len(filter.Tags) == 1:
+		result["tags"] = filter.Tags[0]
+	case len(filter.Tags) > 1 && filter.MatchAllTags:
+		result["tags"] = bson.M{"$all": filter.Tags}
+	case len(filter.Tags) > 1:
+		result["tags"] = bson.M{"$in": filter.Tags}
+	}
+	if len(filter.CategoryIDs) > 0 {
+		result["category_id"] = bson.M{"$in": filter.CategoryIDs}
+	}
+	return result
+}
+
 func (ad *ArticleDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error) {
 	var article model.Article
 	err := ad.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&article)
@@ -32,18 +67,28 @@ func (ad *ArticleDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*mod
 	return &article, nil
 }
 
+// FindPublicByID 根据 ID 查找公开可见的文章
+func (ad *ArticleDAO) FindPublicByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error) {
+	var article model.Article
+	err := ad.collection.FindOne(ctx, publicFilter(bson.M{"_id": id})).Decode(&article)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
 func (ad *ArticleDAO) IncrementPageViews(ctx context.Context, id primitive.ObjectID) error {
 	_, err := ad.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"page_views": 1}})
 	return err
 }
 
-func (ad *ArticleDAO) FindExtend(ctx context.Context, tag string, limit int64) ([]model.ArticleBrief, error) {
+func (ad *ArticleDAO) FindExtend(ctx context.Context, filter *model.ArticleFilter, limit int64) ([]model.ArticleBrief, error) {
 	opts := options.Find().
-		SetProjection(bson.M{"_id": 1, "title": 1}).
+		SetProjection(bson.M{"_id": 1, "title": 1, "slug": 1}).
 		SetSort(bson.M{"page_views": -1}).
 		SetLimit(limit)
 
-	cursor, err := ad.collection.Find(ctx, bson.M{"tag": tag}, opts)
+	cursor, err := ad.collection.Find(ctx, publicFilter(listFilter(filter)), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -56,21 +101,103 @@ func (ad *ArticleDAO) FindExtend(ctx context.Context, tag string, limit int64) (
 	return articles, nil
 }
 
-func (ad *ArticleDAO) GetInfo(ctx context.Context) (*model.ArticleInfo, error) {
-	var info model.ArticleInfo
-	err := ad.infoCollection.FindOne(ctx, bson.M{}).Decode(&info)
+// AggregateInfo 聚合公开文章的数量、总浏览量、最近更新时间和各标签文章数
+func (ad *ArticleDAO) AggregateInfo(ctx context.Context) (*model.ArticleInfo, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: publicFilter(nil)}},
+		{{Key: "$facet", Value: bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":        nil,
+					"count":      bson.M{"$sum": 1},
+					"page_views": bson.M{"$sum": "$page_views"},
+					"latest":     bson.M{"$max": "$updated_at"},
+				}},
+			},
+			"tags": bson.A{
+				// 没有 tags 字段的旧数据使用 tag
+				bson.M{"$project": bson.M{"tags": bson.M{"$ifNull": bson.A{"$tags", bson.A{"$tag"}}}}},
+				bson.M{"$unwind": "$tags"},
+				bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			},
+		}}},
+	}
+
+	cursor, err := ad.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
-	return &info, nil
+	defer cursor.Close(ctx)
+
+	var results []articleInfoFacet
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return articleInfoFromFacet(articleInfoFacet{}), nil
+	}
+	return articleInfoFromFacet(results[0]), nil
+}
+
+// articleInfoFacet AggregateInfo 中 $facet 阶段的输出
+type articleInfoFacet struct {
+	Totals []struct {
+		Count     int64     `bson:"count"`
+		PageViews int64     `bson:"page_views"`
+		Latest    time.Time `bson:"latest"`
+	} `bson:"totals"`
+	Tags []model.TagCount `bson:"tags"`
+}
+
+// articleInfoFromFacet 将聚合结果转换为统计信息，没有公开文章时各项为零值，标签为空数组
+func articleInfoFromFacet(result articleInfoFacet) *model.ArticleInfo {
+	info := &model.ArticleInfo{Tags: []string{}, TagCounts: []model.TagCount{}}
+	if len(result.Totals) > 0 {
+		info.TotalCount = result.Totals[0].Count
+		info.TotalPageViews = result.Totals[0].PageViews
+		info.LatestUpdate = result.Totals[0].Latest
+	}
+	for _, tc := range result.Tags {
+		if tc.Name == "" {
+			continue
+		}
+		info.Tags = append(info.Tags, tc.Name)
+		info.TagCounts = append(info.TagCounts, tc)
+	}
+	return info
 }
 
+// listProjection 列表接口不返回渲染后的 HTML 和目录
+var listProjection = bson.M{"content_html": 0, "toc": 0}
+
 func (ad *ArticleDAO) FindHot(ctx context.Context, limit int64) ([]model.Article, error) {
 	opts := options.Find().
 		SetSort(bson.M{"page_views": -1}).
-		SetLimit(limit)
+		SetLimit(limit).
+		SetProjection(listProjection)
+
+	cursor, err := ad.collection.Find(ctx, publicFilter(nil), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []model.Article
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+func (ad *ArticleDAO) FindList(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error) {
+	opts := options.Find().
+		SetSort(bson.M{"page_views": -1}).
+		SetSkip(skip).
+		SetLimit(limit).
+		SetProjection(listProjection)
 
-	cursor, err := ad.collection.Find(ctx, bson.M{}, opts)
+	cursor, err := ad.collection.Find(ctx, publicFilter(listFilter(filter)), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -83,16 +210,30 @@ func (ad *ArticleDAO) FindHot(ctx context.Context, limit int64) ([]model.Article
 	return articles, nil
 }
 
-func (ad *ArticleDAO) FindList(ctx context.Context, tag string, skip, limit int64) ([]model.Article, error) {
+// FindManaged 查找任意状态的文章（管理后台使用），authorID 非空时只返回该作者的文章，
+// status 为空时不过滤状态，按更新时间倒序
+func (ad *ArticleDAO) FindManaged(ctx context.Context, authorID *primitive.ObjectID, status string, skip, limit int64) ([]model.Article, error) {
 	filter := bson.M{}
-	if tag != "" {
-		filter["tag"] = tag
+	if authorID != nil {
+		filter["author_id"] = *authorID
+	}
+	switch status {
+	case "":
+	case model.ArticleStatusPublished:
+		// 没有 status 字段的旧数据视为已发布
+		filter["$or"] = []bson.M{
+			{"status": model.ArticleStatusPublished},
+			{"status": bson.M{"$exists": false}},
+		}
+	default:
+		filter["status"] = status
 	}
 
 	opts := options.Find().
-		SetSort(bson.M{"page_views": -1}).
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
 		SetSkip(skip).
-		SetLimit(limit)
+		SetLimit(limit).
+		SetProjection(listProjection)
 
 	cursor, err := ad.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -108,19 +249,21 @@ func (ad *ArticleDAO) FindList(ctx context.Context, tag string, skip, limit int6
 }
 
 func (ad *ArticleDAO) Search(ctx context.Context, keywords string, limit int64) ([]model.ArticleBrief, error) {
+	// 关键词按字面匹配，避免用户输入被当作正则解释
+	pattern := regexp.QuoteMeta(keywords)
 	filter := bson.M{
 		"$or": []bson.M{
-			{"title": bson.M{"$regex": keywords, "$options": "i"}},
-			{"tag": bson.M{"$regex": keywords, "$options": "i"}},
+			{"title": bson.M{"$regex": pattern, "$options": "i"}},
+			{"tags": bson.M{"$regex": pattern, "$options": "i"}},
 		},
 	}
 
 	opts := options.Find().
-		SetProjection(bson.M{"_id": 1, "title": 1}).
+		SetProjection(bson.M{"_id": 1, "title": 1, "slug": 1}).
 		SetSort(bson.M{"page_views": -1}).
 		SetLimit(limit)
 
-	cursor, err := ad.collection.Find(ctx, filter, opts)
+	cursor, err := ad.collection.Find(ctx, publicFilter(filter), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -132,3 +275,388 @@ func (ad *ArticleDAO) Search(ctx context.Context, keywords string, limit int64)
 	}
 	return articles, nil
 }
+
+func (ad *ArticleDAO) Create(ctx context.Context, article *model.Article) (*model.Article, error) {
+	now := time.Now()
+	article.CreatedAt = now
+	article.UpdatedAt = now
+	if article.Comments == nil {
+		article.Comments = []primitive.ObjectID{}
+	}
+	result, err := ad.collection.InsertOne(ctx, article)
+	if err != nil {
+		return nil, err
+	}
+	article.ID = result.InsertedID.(primitive.ObjectID)
+	return article, nil
+}
+
+func (ad *ArticleDAO) Update(ctx context.Context, id primitive.ObjectID, update *model.ArticleUpdate) (*model.Article, error) {
+	set := bson.M{"updated_at": time.Now()}
+	if update.ArticleType != nil {
+		set["article_type"] = *update.ArticleType
+	}
+	if update.Title != nil {
+		set["title"] = *update.Title
+	}
+
+	change := bson.M{"$set": set}
+	if update.Slug != nil {
+		set["slug"] = *update.Slug
+	}
+	if update.OldSlug != nil {
+		// 旧 slug 保留在 old_slugs 中用于重定向；改回历史 slug 时查询优先匹配当前 slug，不影响结果
+		change["$addToSet"] = bson.M{"old_slugs": *update.OldSlug}
+	}
+	if update.Content != nil {
+		set["content"] = *update.Content
+	}
+	if update.ContentHTML != nil {
+		set["content_html"] = *update.ContentHTML
+		set["toc"] = update.TOC
+	}
+	if update.Tags != nil {
+		set["tags"] = update.Tags
+		set["tag"] = update.Tags[0]
+	}
+	if update.CoverImage != nil {
+		set["cover_image"] = *update.CoverImage
+	}
+	if update.CategoryID != nil {
+		if update.CategoryID.IsZero() {
+			change["$unset"] = bson.M{"category_id": ""}
+		} else {
+			set["category_id"] = *update.CategoryID
+		}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var article model.Article
+	err := ad.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, change, opts).Decode(&article)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// SetSlug 仅设置 slug，不修改 updated_at（用于回填旧数据）
+func (ad *ArticleDAO) SetSlug(ctx context.Context, id primitive.ObjectID, slug string) error {
+	_, err := ad.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"slug": slug}})
+	return err
+}
+
+// FindPublicBySlug 根据当前 slug 或历史 slug 查找公开可见的文章
+func (ad *ArticleDAO) FindPublicBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	filter := bson.M{"$or": []bson.M{
+		{"slug": slug},
+		{"old_slugs": slug},
+	}}
+	var article model.Article
+	err := ad.collection.FindOne(ctx, publicFilter(filter)).Decode(&article)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// SlugTaken 判断 slug 是否已被其他文章使用（包括历史 slug）
+func (ad *ArticleDAO) SlugTaken(ctx context.Context, slug string, excludeID primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id": bson.M{"$ne": excludeID},
+		"$or": []bson.M{
+			{"slug": slug},
+			{"old_slugs": slug},
+		},
+	}
+	count, err := ad.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// FindWithoutSlug 查找尚未生成 slug 的文章（用于回填旧数据）
+func (ad *ArticleDAO) FindWithoutSlug(ctx context.Context, limit int64) ([]model.ArticleBrief, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "title": 1}).
+		SetLimit(limit)
+
+	cursor, err := ad.collection.Find(ctx, bson.M{"slug": bson.M{"$in": []interface{}{nil, ""}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []model.ArticleBrief
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+func (ad *ArticleDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := ad.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (ad *ArticleDAO) UpdateStatus(ctx context.Context, id primitive.ObjectID, status string, publishAt time.Time) (*model.Article, error) {
+	set := bson.M{
+		"status":     status,
+		"updated_at": time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if publishAt.IsZero() {
+		// 退回草稿后不再保留原定的发布时间
+		update["$unset"] = bson.M{"publish_at": ""}
+	} else {
+		set["publish_at"] = publishAt
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var article model.Article
+	err := ad.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&article)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// PublishDue 将已到发布时间的定时文章标记为已发布，返回这些文章（仅含 ID 和标签）
+func (ad *ArticleDAO) PublishDue(ctx context.Context, now time.Time) ([]model.Article, error) {
+	filter := bson.M{
+		"status":     model.ArticleStatusScheduled,
+		"publish_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "tag": 1, "tags": 1})
+
+	cursor, err := ad.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var due []model.Article
+	if err = cursor.All(ctx, &due); err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(due))
+	for _, a := range due {
+		ids = append(ids, a.ID)
+	}
+
+	_, err = ad.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "status": model.ArticleStatusScheduled},
+		bson.M{"$set": bson.M{"status": model.ArticleStatusPublished, "updated_at": now}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// searchFilter 按标签、日期构造公开可见文章的搜索过滤条件
+func searchFilter(query *model.ArticleSearchQuery) bson.M {
+	filter := bson.M{}
+	if query.Tag != "" {
+		filter["tags"] = query.Tag
+	}
+	created := bson.M{}
+	if !query.From.IsZero() {
+		created["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		created["$lt"] = query.To
+	}
+	if len(created) > 0 {
+		filter["created_at"] = created
+	}
+	return publicFilter(filter)
+}
+
+// FindForIndex 查找不在 excludeIDs 中的文章（用于补建分词索引）
+func (ad *ArticleDAO) FindForIndex(ctx context.Context, excludeIDs []primitive.ObjectID, limit int64) ([]model.Article, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "title": 1, "tag": 1, "tags": 1, "content": 1}).
+		SetLimit(limit)
+
+	cursor, err := ad.collection.Find(ctx, bson.M{"_id": bson.M{"$nin": excludeIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []model.Article
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// CountPublicByTag 统计使用指定标签的公开文章数
+func (ad *ArticleDAO) CountPublicByTag(ctx context.Context, tag string) (int64, error) {
+	return ad.collection.CountDocuments(ctx, publicFilter(bson.M{"tags": tag}))
+}
+
+// DistinctTags 返回所有文章使用过的标签
+func (ad *ArticleDAO) DistinctTags(ctx context.Context) ([]string, error) {
+	values, err := ad.collection.Distinct(ctx, "tags", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(values))
+	for _, v := range values {
+		if tag, ok := v.(string); ok && tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// BackfillTags 为只有 tag 字段的旧数据生成 tags 数组，返回更新数量
+func (ad *ArticleDAO) BackfillTags(ctx context.Context) (int64, error) {
+	result, err := ad.collection.UpdateMany(ctx,
+		bson.M{"tags": bson.M{"$exists": false}, "tag": bson.M{"$type": "string", "$ne": ""}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"tags": bson.A{"$tag"}}}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// FindAdjacent 按创建时间查找与 article 相邻的公开文章，newer 为 true 时查找下一篇
+func (ad *ArticleDAO) FindAdjacent(ctx context.Context, article *model.Article, newer bool) (*model.ArticleBrief, error) {
+	op, order := "$lt", -1
+	if newer {
+		op, order = "$gt", 1
+	}
+	filter := bson.M{
+		"_id":        bson.M{"$ne": article.ID},
+		"created_at": bson.M{op: article.CreatedAt},
+	}
+	opts := options.FindOne().
+		SetProjection(bson.M{"_id": 1, "title": 1, "slug": 1}).
+		SetSort(bson.D{{Key: "created_at", Value: order}})
+
+	var brief model.ArticleBrief
+	err := ad.collection.FindOne(ctx, publicFilter(filter), opts).Decode(&brief)
+	if err != nil {
+		return nil, err
+	}
+	return &brief, nil
+}
+
+// FindSeriesArticles 按系列顺序获取系列内的公开文章
+func (ad *ArticleDAO) FindSeriesArticles(ctx context.Context, seriesID primitive.ObjectID) ([]model.ArticleBrief, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "title": 1, "slug": 1}).
+		SetSort(bson.D{{Key: "series_order", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := ad.collection.Find(ctx, publicFilter(bson.M{"series_id": seriesID}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	articles := []model.ArticleBrief{}
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// SetSeriesArticles 将系列的文章设置为 ids 并按顺序编号，原有但不在 ids 中的文章移出系列
+func (ad *ArticleDAO) SetSeriesArticles(ctx context.Context, seriesID primitive.ObjectID, ids []primitive.ObjectID) error {
+	models := []mongo.WriteModel{
+		mongo.NewUpdateManyModel().
+			SetFilter(bson.M{"series_id": seriesID, "_id": bson.M{"$nin": ids}}).
+			SetUpdate(bson.M{"$unset": bson.M{"series_id": "", "series_order": ""}}),
+	}
+	for i, id := range ids {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"series_id": seriesID, "series_order": i + 1}}))
+	}
+
+	_, err := ad.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true))
+	return err
+}
+
+// ClearSeries 将系列内的全部文章移出系列
+func (ad *ArticleDAO) ClearSeries(ctx context.Context, seriesID primitive.ObjectID) error {
+	_, err := ad.collection.UpdateMany(ctx,
+		bson.M{"series_id": seriesID},
+		bson.M{"$unset": bson.M{"series_id": "", "series_order": ""}},
+	)
+	return err
+}
+
+// CountByIDs 统计 ids 中实际存在的文章数
+func (ad *ArticleDAO) CountByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	return ad.collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+// CountByCategory 统计属于指定分类的文章数（包括未公开的文章）
+func (ad *ArticleDAO) CountByCategory(ctx context.Context, categoryID primitive.ObjectID) (int64, error) {
+	return ad.collection.CountDocuments(ctx, bson.M{"category_id": categoryID})
+}
+
+// CountPublicGroupBy 按 field（category_id 或 series_id）分组统计公开文章数
+func (ad *ArticleDAO) CountPublicGroupBy(ctx context.Context, field string) (map[primitive.ObjectID]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: publicFilter(bson.M{field: bson.M{"$exists": true}})}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := ad.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Count int64              `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[primitive.ObjectID]int64, len(rows))
+	for _, r := range rows {
+		counts[r.ID] = r.Count
+	}
+	return counts, nil
+}
+
+// AddComment 记录文章的新评论
+func (ad *ArticleDAO) AddComment(ctx context.Context, articleID, commentID primitive.ObjectID) error {
+	_, err := ad.collection.UpdateOne(ctx,
+		bson.M{"_id": articleID},
+		bson.M{"$push": bson.M{"comments": commentID}},
+	)
+	return err
+}
+
+// RemoveComments 移除文章的评论记录
+func (ad *ArticleDAO) RemoveComments(ctx context.Context, articleID primitive.ObjectID, commentIDs []primitive.ObjectID) error {
+	_, err := ad.collection.UpdateOne(ctx,
+		bson.M{"_id": articleID},
+		bson.M{"$pull": bson.M{"comments": bson.M{"$in": commentIDs}}},
+	)
+	return err
+}
+
+// SetCommentCount 写入按评论集合重新统计的评论数，不修改 updated_at
+func (ad *ArticleDAO) SetCommentCount(ctx context.Context, articleID primitive.ObjectID, count int64) error {
+	_, err := ad.collection.UpdateOne(ctx, bson.M{"_id": articleID}, bson.M{"$set": bson.M{"comment_count": count}})
+	return err
+}
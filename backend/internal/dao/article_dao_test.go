@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// decodeFacet 模拟驱动解码聚合结果，同时校验 bson 标签
+func decodeFacet(t *testing.T, doc bson.M) articleInfoFacet {
+	t.Helper()
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result articleInfoFacet
+	if err := bson.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestArticleInfoFromFacet(t *testing.T) {
+	latest := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	result := decodeFacet(t, bson.M{
+		"totals": bson.A{bson.M{"_id": nil, "count": int64(3), "page_views": int64(42), "latest": latest}},
+		"tags": bson.A{
+			bson.M{"_id": "Go", "count": int64(2)},
+			bson.M{"_id": "", "count": int64(1)},
+			bson.M{"_id": "Rust", "count": int64(1)},
+		},
+	})
+
+	info := articleInfoFromFacet(result)
+	if info.TotalCount != 3 || info.TotalPageViews != 42 || !info.LatestUpdate.Equal(latest) {
+		t.Errorf("汇总数据不正确: %+v", info)
+	}
+	if len(info.Tags) != 2 || info.Tags[0] != "Go" || info.Tags[1] != "Rust" {
+		t.Errorf("应按顺序返回非空标签, 实际 %v", info.Tags)
+	}
+	if len(info.TagCounts) != 2 || info.TagCounts[0].Count != 2 {
+		t.Errorf("标签文章数不正确: %+v", info.TagCounts)
+	}
+}
+
+func TestArticleInfoFromFacet_NoArticles(t *testing.T) {
+	info := articleInfoFromFacet(decodeFacet(t, bson.M{"totals": bson.A{}, "tags": bson.A{}}))
+	if info.TotalCount != 0 || info.Tags == nil || info.TagCounts == nil {
+		t.Errorf("没有公开文章时应返回零值和空数组: %+v", info)
+	}
+}
@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 标题词命中的权重（正文词命中权重为 1）
+const titleTermWeight = 10
+
+// ArticleTermDAO 文章分词索引，每篇文章一条文档，_id 与文章 ID 相同
+type ArticleTermDAO struct {
+	collection *mongo.Collection
+}
+
+func NewArticleTermDAO() *ArticleTermDAO {
+	return &ArticleTermDAO{
+		collection: database.Collection("article_terms"),
+	}
+}
+
+func (td *ArticleTermDAO) Upsert(ctx context.Context, articleID primitive.ObjectID, titleTerms, contentTerms []string) error {
+	_, err := td.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": articleID},
+		bson.M{"$set": bson.M{
+			"title_terms":   titleTerms,
+			"content_terms": contentTerms,
+			"indexed_at":    time.Now(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (td *ArticleTermDAO) Delete(ctx context.Context, articleID primitive.ObjectID) error {
+	_, err := td.collection.DeleteOne(ctx, bson.M{"_id": articleID})
+	return err
+}
+
+// Search 查找包含任一查询词且满足 query 过滤条件的文章，按命中词数加权评分、浏览量降序分页返回，
+// 过滤、计数与分页都在数据库中完成
+func (td *ArticleTermDAO) Search(ctx context.Context, terms []string, query *model.ArticleSearchQuery) ([]model.ArticleSearchHit, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": []bson.M{
+			{"title_terms": bson.M{"$in": terms}},
+			{"content_terms": bson.M{"$in": terms}},
+		}}}},
+		{{Key: "$project", Value: bson.M{
+			"score": bson.M{"$add": []interface{}{
+				bson.M{"$multiply": []interface{}{
+					titleTermWeight,
+					bson.M{"$size": bson.M{"$setIntersection": []interface{}{"$title_terms", terms}}},
+				}},
+				bson.M{"$size": bson.M{"$setIntersection": []interface{}{"$content_terms", terms}}},
+			}},
+		}}},
+		// 关联文章并过滤草稿、标签和日期，不满足条件的文章在 $unwind 时被丢弃
+		{{Key: "$lookup", Value: bson.M{
+			"from": "articles",
+			"let":  bson.M{"article_id": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": []interface{}{"$_id", "$$article_id"}}}}},
+				{{Key: "$match", Value: searchFilter(query)}},
+				{{Key: "$project", Value: bson.M{
+					"_id":        1,
+					"title":      1,
+					"slug":       1,
+					"tag":        1,
+					"tags":       1,
+					"content":    1,
+					"created_at": 1,
+					"page_views": 1,
+				}}},
+			},
+			"as": "article",
+		}}},
+		{{Key: "$unwind", Value: "$article"}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"list": bson.A{
+				bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "article.page_views", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": query.Skip},
+				bson.M{"$limit": query.Limit},
+			},
+		}}},
+	}
+
+	cursor, err := td.collection.Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		List []struct {
+			Score   float64                `bson:"score"`
+			Article model.ArticleSearchHit `bson:"article"`
+		} `bson:"list"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return nil, 0, nil
+	}
+
+	hits := make([]model.ArticleSearchHit, 0, len(results[0].List))
+	for _, item := range results[0].List {
+		hit := item.Article
+		hit.Score = item.Score
+		hits = append(hits, hit)
+	}
+	return hits, results[0].Total[0].Count, nil
+}
+
+// FindIndexedIDs 返回所有已建立索引的文章 ID
+func (td *ArticleTermDAO) FindIndexedIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	cursor, err := td.collection.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, d := range docs {
+		ids = append(ids, d.ID)
+	}
+	return ids, nil
+}
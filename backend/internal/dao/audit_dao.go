@@ -0,0 +1,28 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AuditDAO struct {
+	collection *mongo.Collection
+}
+
+func NewAuditDAO() *AuditDAO {
+	return &AuditDAO{
+		collection: database.Collection("audit_logs"),
+	}
+}
+
+func (ad *AuditDAO) Create(ctx context.Context, log *model.AuditLog) error {
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	_, err := ad.collection.InsertOne(ctx, log)
+	return err
+}
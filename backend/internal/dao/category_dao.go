@@ -0,0 +1,125 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CategoryDAO struct {
+	collection *mongo.Collection
+}
+
+func NewCategoryDAO() *CategoryDAO {
+	return &CategoryDAO{
+		collection: database.Collection("categories"),
+	}
+}
+
+func (cd *CategoryDAO) FindAll(ctx context.Context) ([]model.Category, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "sort", Value: 1}, {Key: "name", Value: 1}})
+
+	cursor, err := cd.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var categories []model.Category
+	if err = cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (cd *CategoryDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Category, error) {
+	var category model.Category
+	err := cd.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&category)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (cd *CategoryDAO) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	var category model.Category
+	err := cd.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&category)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// SlugTaken 判断 slug 是否已被其他分类使用
+func (cd *CategoryDAO) SlugTaken(ctx context.Context, slug string, excludeID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"slug": slug, "_id": bson.M{"$ne": excludeID}}
+	count, err := cd.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// CountChildren 统计直接子分类数
+func (cd *CategoryDAO) CountChildren(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	return cd.collection.CountDocuments(ctx, bson.M{"parent_id": id})
+}
+
+func (cd *CategoryDAO) Create(ctx context.Context, category *model.Category) (*model.Category, error) {
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	result, err := cd.collection.InsertOne(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	category.ID = result.InsertedID.(primitive.ObjectID)
+	return category, nil
+}
+
+func (cd *CategoryDAO) Update(ctx context.Context, id primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error) {
+	set := bson.M{"updated_at": time.Now()}
+	change := bson.M{"$set": set}
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Slug != nil {
+		set["slug"] = *update.Slug
+	}
+	if update.Description != nil {
+		set["description"] = *update.Description
+	}
+	if update.Sort != nil {
+		set["sort"] = *update.Sort
+	}
+	if update.ParentID != nil {
+		if update.ParentID.IsZero() {
+			change["$unset"] = bson.M{"parent_id": ""}
+		} else {
+			set["parent_id"] = *update.ParentID
+		}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var category model.Category
+	err := cd.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, change, opts).Decode(&category)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (cd *CategoryDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := cd.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentDAO struct {
+	collection *mongo.Collection
+}
+
+func NewCommentDAO() *CommentDAO {
+	return &CommentDAO{
+		collection: database.Collection("comments"),
+	}
+}
+
+func (cd *CommentDAO) Create(ctx context.Context, comment *model.Comment) (*model.Comment, error) {
+	now := time.Now()
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+
+	result, err := cd.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	comment.ID = result.InsertedID.(primitive.ObjectID)
+	return comment, nil
+}
+
+func (cd *CommentDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	var comment model.Comment
+	err := cd.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindThread 获取顶层评论及其全部回复
+func (cd *CommentDAO) FindThread(ctx context.Context, rootID primitive.ObjectID) ([]model.Comment, error) {
+	filter := bson.M{"$or": []bson.M{{"_id": rootID}, {"root_id": rootID}}}
+	cursor, err := cd.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []model.Comment
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (cd *CommentDAO) UpdateContent(ctx context.Context, id primitive.ObjectID, content, contentHTML string) (*model.Comment, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var comment model.Comment
+	err := cd.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"content": content, "content_html": contentHTML, "updated_at": time.Now()}},
+		opts,
+	).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (cd *CommentDAO) DeleteMany(ctx context.Context, ids []primitive.ObjectID) error {
+	_, err := cd.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// DeleteByArticle 删除文章的全部评论
+func (cd *CommentDAO) DeleteByArticle(ctx context.Context, articleID primitive.ObjectID) error {
+	_, err := cd.collection.DeleteMany(ctx, bson.M{"article_id": articleID})
+	return err
+}
+
+// CountByArticle 统计文章的评论数（包括回复）
+func (cd *CommentDAO) CountByArticle(ctx context.Context, articleID primitive.ObjectID) (int64, error) {
+	return cd.collection.CountDocuments(ctx, bson.M{"article_id": articleID})
+}
+
+// CountRoots 统计文章的顶层评论数
+func (cd *CommentDAO) CountRoots(ctx context.Context, articleID primitive.ObjectID) (int64, error) {
+	return cd.collection.CountDocuments(ctx, bson.M{"article_id": articleID, "root_id": bson.M{"$exists": false}})
+}
+
+// FindRootsWithUser 分页获取文章的顶层评论（最新在前）
+func (cd *CommentDAO) FindRootsWithUser(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) ([]model.CommentWithUser, error) {
+	return cd.findWithUser(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"article_id": articleID, "root_id": bson.M{"$exists": false}}}},
+		{{Key: "$sort", Value: bson.M{"created_at": -1}}},
+		{{Key: "$skip", Value: skip}},
+		{{Key: "$limit", Value: limit}},
+	})
+}
+
+// FindRepliesWithUser 获取多个顶层评论下的全部回复（按时间顺序）
+func (cd *CommentDAO) FindRepliesWithUser(ctx context.Context, rootIDs []primitive.ObjectID) ([]model.CommentWithUser, error) {
+	return cd.findWithUser(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"root_id": bson.M{"$in": rootIDs}}}},
+		{{Key: "$sort", Value: bson.M{"created_at": 1}}},
+	})
+}
+
+// findWithUser 在 stages 之后关联评论作者以及被回复评论的作者
+func (cd *CommentDAO) findWithUser(ctx context.Context, stages mongo.Pipeline) ([]model.CommentWithUser, error) {
+	pipeline := append(stages,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "user_id",
+			"foreignField": "_id",
+			"as":           "user_info",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$user_info", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "comments",
+			"localField":   "parent_id",
+			"foreignField": "_id",
+			"as":           "parent",
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "parent.user_id",
+			"foreignField": "_id",
+			"as":           "reply_to_info",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$reply_to_info", "preserveNullAndEmptyArrays": true}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":          1,
+			"root_id":      1,
+			"parent_id":    1,
+			"content":      1,
+			"content_html": 1,
+			"created_at":   1,
+			"updated_at":   1,
+			"user": bson.M{
+				"_id":       "$user_info._id",
+				"user_name": "$user_info.user_name",
+				"avatar":    "$user_info.avatar",
+			},
+			"reply_to_user": bson.M{
+				"$cond": bson.A{
+					bson.M{"$ifNull": bson.A{"$reply_to_info", false}},
+					bson.M{
+						"_id":       "$reply_to_info._id",
+						"user_name": "$reply_to_info.user_name",
+						"avatar":    "$reply_to_info.avatar",
+					},
+					"$$REMOVE",
+				},
+			},
+		}}},
+	)
+
+	cursor, err := cd.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	comments := []model.CommentWithUser{}
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
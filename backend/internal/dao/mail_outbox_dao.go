@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MailOutboxDAO struct {
+	collection *mongo.Collection
+}
+
+func NewMailOutboxDAO() *MailOutboxDAO {
+	return &MailOutboxDAO{
+		collection: database.Collection("mail_outbox"),
+	}
+}
+
+func (md *MailOutboxDAO) Create(ctx context.Context, mail *model.OutboxMail) error {
+	now := time.Now()
+	if mail.CreatedAt.IsZero() {
+		mail.CreatedAt = now
+	}
+	if mail.NextAttemptAt.IsZero() {
+		mail.NextAttemptAt = now
+	}
+	mail.Status = model.MailStatusPending
+	_, err := md.collection.InsertOne(ctx, mail)
+	return err
+}
+
+// ClaimDue 领取一封到期的待发送邮件：尝试次数加一，并把下一次投递时间推后 lease，
+// 其他实例在租约内不会重复领取；没有到期邮件时返回 mongo.ErrNoDocuments
+func (md *MailOutboxDAO) ClaimDue(ctx context.Context, lease time.Duration) (*model.OutboxMail, error) {
+	now := time.Now()
+	var mail model.OutboxMail
+	err := md.collection.FindOneAndUpdate(ctx,
+		bson.M{
+			"status":          model.MailStatusPending,
+			"next_attempt_at": bson.M{"$lte": now},
+		},
+		bson.M{
+			"$set": bson.M{"next_attempt_at": now.Add(lease)},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.M{"next_attempt_at": 1}).
+			SetReturnDocument(options.After),
+	).Decode(&mail)
+	if err != nil {
+		return nil, err
+	}
+	return &mail, nil
+}
+
+// MarkSent 标记为已发送，并清除正文（正文可能包含重置密码、验证邮箱等一次性链接）
+func (md *MailOutboxDAO) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	_, err := md.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": model.MailStatusSent, "sent_at": time.Now()},
+		"$unset": bson.M{"last_error": "", "body": ""},
+	})
+	return err
+}
+
+// MarkRetry 记录失败原因，在 nextAttemptAt 之后重试
+func (md *MailOutboxDAO) MarkRetry(ctx context.Context, id primitive.ObjectID, nextAttemptAt time.Time, lastError string) error {
+	_, err := md.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"next_attempt_at": nextAttemptAt, "last_error": lastError},
+	})
+	return err
+}
+
+// MarkFailed 放弃投递，同样清除正文
+func (md *MailOutboxDAO) MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string) error {
+	_, err := md.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": model.MailStatusFailed, "last_error": lastError},
+		"$unset": bson.M{"body": ""},
+	})
+	return err
+}
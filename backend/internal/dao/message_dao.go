@@ -22,15 +22,27 @@ func NewMessageDAO() *MessageDAO {
 	}
 }
 
-func (md *MessageDAO) Create(ctx context.Context, userID primitive.ObjectID, content string) error {
-	msg := &model.Message{
-		UserID:    userID,
-		Content:   content,
-		CreatedAt: time.Now(),
-		Replies:   []model.ReplyMessage{},
+// approvedStatus 匹配已通过审核的留言，没有 status 字段的旧数据视为已通过
+var approvedStatus = bson.M{"$in": bson.A{model.MessageStatusApproved, nil}}
+
+// approvedReplyCond 聚合表达式中判断回复是否已通过审核
+var approvedReplyCond = bson.M{"$eq": bson.A{
+	bson.M{"$ifNull": bson.A{"$$reply.status", model.MessageStatusApproved}},
+	model.MessageStatusApproved,
+}}
+
+// Create 创建留言，自动生成创建时间
+func (md *MessageDAO) Create(ctx context.Context, msg *model.Message) (*model.Message, error) {
+	msg.CreatedAt = time.Now()
+	if msg.Replies == nil {
+		msg.Replies = []model.ReplyMessage{}
+	}
+	result, err := md.collection.InsertOne(ctx, msg)
+	if err != nil {
+		return nil, err
 	}
-	_, err := md.collection.InsertOne(ctx, msg)
-	return err
+	msg.ID = result.InsertedID.(primitive.ObjectID)
+	return msg, nil
 }
 
 func (md *MessageDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Message, error) {
@@ -42,23 +54,360 @@ func (md *MessageDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*mod
 	return &msg, nil
 }
 
-func (md *MessageDAO) AddReplyMessage(ctx context.Context, parentID, userID primitive.ObjectID, content, replyToUser string) error {
-	reply := model.ReplyMessage{
-		UserID:      userID,
-		Content:     content,
-		ReplyToUser: replyToUser,
-		CreatedAt:   time.Now(),
-	}
-	_, err := md.collection.UpdateOne(
+// AddReplyMessage 追加回复，自动生成回复 ID 和创建时间
+func (md *MessageDAO) AddReplyMessage(ctx context.Context, parentID primitive.ObjectID, reply model.ReplyMessage) (*model.ReplyMessage, error) {
+	reply.ID = primitive.NewObjectID()
+	reply.CreatedAt = time.Now()
+	result, err := md.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": parentID},
 		bson.M{"$push": bson.M{"replies": reply}},
 	)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &reply, nil
+}
+
+// SetStatus 修改留言的审核状态
+func (md *MessageDAO) SetStatus(ctx context.Context, id primitive.ObjectID, status string, moderatorID primitive.ObjectID) error {
+	result, err := md.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":       status,
+		"moderated_by": moderatorID,
+		"moderated_at": time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetReplyStatus 修改回复的审核状态
+func (md *MessageDAO) SetReplyStatus(ctx context.Context, id, replyID primitive.ObjectID, status string, moderatorID primitive.ObjectID) error {
+	result, err := md.collection.UpdateOne(ctx, bson.M{"_id": id, "replies._id": replyID}, bson.M{"$set": bson.M{
+		"replies.$.status":       status,
+		"replies.$.moderated_by": moderatorID,
+		"replies.$.moderated_at": time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CountApprovedByUser 统计用户已通过审核的发言数（按留言文档计，留言或回复任一通过即计入）
+func (md *MessageDAO) CountApprovedByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return md.collection.CountDocuments(ctx, bson.M{"$or": bson.A{
+		bson.M{"user_id": userID, "status": approvedStatus},
+		bson.M{"replies": bson.M{"$elemMatch": bson.M{"user_id": userID, "status": approvedStatus}}},
+	}})
+}
+
+// CountRecentByUser 统计用户自 since 起发布的留言和回复总数
+func (md *MessageDAO) CountRecentByUser(ctx context.Context, userID primitive.ObjectID, since time.Time) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}},
+			bson.M{"replies": bson.M{"$elemMatch": bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}}}},
+		}}}},
+		{{Key: "$project", Value: bson.M{
+			"n": bson.M{"$add": bson.A{
+				bson.M{"$cond": bson.A{
+					bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$user_id", userID}},
+						bson.M{"$gte": bson.A{"$created_at", since}},
+					}},
+					1, 0,
+				}},
+				bson.M{"$size": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$replies", bson.A{}}},
+					"as":    "reply",
+					"cond": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$$reply.user_id", userID}},
+						bson.M{"$gte": bson.A{"$$reply.created_at", since}},
+					}},
+				}}},
+			}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$n"}}}},
+	}
+
+	cursor, err := md.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total int64 `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return results[0].Total, nil
+}
+
+// ExistsRecentContent 判断用户自 since 起是否发布过相同内容的留言或回复
+func (md *MessageDAO) ExistsRecentContent(ctx context.Context, userID primitive.ObjectID, content string, since time.Time) (bool, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"user_id": userID, "content": content, "created_at": bson.M{"$gte": since}},
+		bson.M{"replies": bson.M{"$elemMatch": bson.M{"user_id": userID, "content": content, "created_at": bson.M{"$gte": since}}}},
+	}}
+	count, err := md.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// FindModerationQueue 按状态列出待处理的留言和回复（不含已删除的），按提交时间先后排序
+func (md *MessageDAO) FindModerationQueue(ctx context.Context, status string, skip, limit int64) ([]model.ModerationItem, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"status": status},
+			bson.M{"replies.status": status},
+		}}}},
+		{{Key: "$project", Value: bson.M{
+			"items": bson.M{"$concatArrays": bson.A{
+				bson.M{"$cond": bson.A{
+					bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$status", status}},
+						bson.M{"$lte": bson.A{"$deleted_at", nil}},
+					}},
+					bson.A{bson.M{
+						"type":         "message",
+						"message_id":   "$_id",
+						"user_id":      "$user_id",
+						"content":      "$content",
+						"status":       "$status",
+						"spam_score":   "$spam_score",
+						"spam_reasons": "$spam_reasons",
+						"created_at":   "$created_at",
+					}},
+					bson.A{},
+				}},
+				bson.M{"$map": bson.M{
+					"input": bson.M{"$filter": bson.M{
+						"input": bson.M{"$ifNull": bson.A{"$replies", bson.A{}}},
+						"as":    "reply",
+						"cond": bson.M{"$and": bson.A{
+							bson.M{"$eq": bson.A{"$$reply.status", status}},
+							bson.M{"$lte": bson.A{"$$reply.deleted_at", nil}},
+						}},
+					}},
+					"as": "reply",
+					"in": bson.M{
+						"type":         "reply",
+						"message_id":   "$_id",
+						"reply_id":     "$$reply._id",
+						"user_id":      "$$reply.user_id",
+						"content":      "$$reply.content",
+						"status":       "$$reply.status",
+						"spam_score":   "$$reply.spam_score",
+						"spam_reasons": "$$reply.spam_reasons",
+						"created_at":   "$$reply.created_at",
+					},
+				}},
+			}},
+		}}},
+		{{Key: "$unwind", Value: "$items"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$items"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "message_id", Value: 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"list": bson.A{
+				bson.M{"$skip": skip},
+				bson.M{"$limit": limit},
+				bson.M{"$lookup": bson.M{
+					"from":         "users",
+					"localField":   "user_id",
+					"foreignField": "_id",
+					"as":           "user_info",
+				}},
+				bson.M{"$unwind": bson.M{"path": "$user_info", "preserveNullAndEmptyArrays": true}},
+				bson.M{"$project": bson.M{
+					"type":         1,
+					"message_id":   1,
+					"reply_id":     1,
+					"content":      1,
+					"status":       1,
+					"spam_score":   1,
+					"spam_reasons": 1,
+					"created_at":   1,
+					"user": bson.M{
+						"_id":       "$user_info._id",
+						"user_name": "$user_info.user_name",
+						"avatar":    "$user_info.avatar",
+					},
+				}},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+
+	cursor, err := md.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		List  []model.ModerationItem `bson:"list"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 {
+		return []model.ModerationItem{}, 0, nil
+	}
+
+	var total int64
+	if len(results[0].Total) > 0 {
+		total = results[0].Total[0].Count
+	}
+	list := results[0].List
+	if list == nil {
+		list = []model.ModerationItem{}
+	}
+	return list, total, nil
+}
+
+// UpdateContent 修改留言内容，同时写入新的审核状态
+func (md *MessageDAO) UpdateContent(ctx context.Context, id primitive.ObjectID, content, contentHTML, status string) error {
+	result, err := md.collection.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}, bson.M{"$set": bson.M{
+		"content":      content,
+		"content_html": contentHTML,
+		"status":       status,
+		"edited_at":    time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateReplyContent 修改回复内容，同时写入新的审核状态
+func (md *MessageDAO) UpdateReplyContent(ctx context.Context, id, replyID primitive.ObjectID, content, contentHTML, status string) error {
+	filter := bson.M{"_id": id, "replies": bson.M{"$elemMatch": bson.M{
+		"_id":        replyID,
+		"deleted_at": bson.M{"$exists": false},
+	}}}
+	result, err := md.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"replies.$.content":      content,
+		"replies.$.content_html": contentHTML,
+		"replies.$.status":       status,
+		"replies.$.edited_at":    time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SoftDelete 删除留言：清空内容并打上删除标记，回复保持不变
+func (md *MessageDAO) SoftDelete(ctx context.Context, id, deletedBy primitive.ObjectID) error {
+	result, err := md.collection.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": false}}, bson.M{"$set": bson.M{
+		"content":      "",
+		"content_html": "",
+		"deleted_at":   time.Now(),
+		"deleted_by":   deletedBy,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SoftDeleteReply 删除回复：清空内容并打上删除标记
+func (md *MessageDAO) SoftDeleteReply(ctx context.Context, id, replyID, deletedBy primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "replies": bson.M{"$elemMatch": bson.M{
+		"_id":        replyID,
+		"deleted_at": bson.M{"$exists": false},
+	}}}
+	result, err := md.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"replies.$.content":      "",
+		"replies.$.content_html": "",
+		"replies.$.deleted_at":   time.Now(),
+		"replies.$.deleted_by":   deletedBy,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// BackfillReplyIDs 为旧数据中没有 _id 的回复生成 ID，返回更新的留言数
+func (md *MessageDAO) BackfillReplyIDs(ctx context.Context) (int, error) {
+	filter := bson.M{"replies": bson.M{"$elemMatch": bson.M{"_id": bson.M{"$exists": false}}}}
+	cursor, err := md.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"replies": 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	// 使用 bson.D 保留字段顺序，保证下面的数组相等条件能匹配
+	var docs []struct {
+		ID      primitive.ObjectID `bson:"_id"`
+		Replies []bson.D           `bson:"replies"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, doc := range docs {
+		original := make(bson.A, len(doc.Replies))
+		replies := make(bson.A, len(doc.Replies))
+		for i, reply := range doc.Replies {
+			original[i] = reply
+			if hasKey(reply, "_id") {
+				replies[i] = reply
+				continue
+			}
+			replies[i] = append(bson.D{{Key: "_id", Value: primitive.NewObjectID()}}, reply...)
+		}
+		// 以原回复数组为条件，期间有新回复写入时跳过，下次启动再补
+		result, err := md.collection.UpdateOne(ctx,
+			bson.M{"_id": doc.ID, "replies": original},
+			bson.M{"$set": bson.M{"replies": replies}},
+		)
+		if err != nil {
+			return updated, err
+		}
+		updated += int(result.ModifiedCount)
+	}
+	return updated, nil
 }
 
 func (md *MessageDAO) FindListWithUser(ctx context.Context, skip, limit int64) ([]model.MessageWithUser, error) {
+	deleted := bson.M{"$gt": bson.A{"$deleted_at", nil}}
+	replyDeleted := bson.M{"$gt": bson.A{"$$reply.deleted_at", nil}}
+
 	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": approvedStatus}}},
 		{{Key: "$sort", Value: bson.M{"created_at": -1}}},
 		{{Key: "$skip", Value: skip}},
 		{{Key: "$limit", Value: limit}},
@@ -75,24 +424,52 @@ func (md *MessageDAO) FindListWithUser(ctx context.Context, skip, limit int64) (
 			"foreignField": "_id",
 			"as":           "replies_users",
 		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   "replies.reply_to_user_id",
+			"foreignField": "_id",
+			"as":           "reply_to_users",
+		}}},
 		{{Key: "$project", Value: bson.M{
-			"_id":        1,
-			"content":    1,
-			"created_at": 1,
-			"user": bson.M{
+			"_id":          1,
+			"content":      1,
+			"content_html": 1,
+			"created_at":   1,
+			"edited_at":    1,
+			"deleted":      deleted,
+			"user": bson.M{"$cond": bson.A{deleted, "$$REMOVE", bson.M{
 				"_id":       "$user_info._id",
 				"user_name": "$user_info.user_name",
 				"avatar":    "$user_info.avatar",
-			},
+			}}},
 			"replies": bson.M{
 				"$map": bson.M{
-					"input": "$replies",
-					"as":    "reply",
+					"input": bson.M{
+						"$filter": bson.M{
+							"input": bson.M{"$ifNull": bson.A{"$replies", bson.A{}}},
+							"as":    "reply",
+							"cond":  approvedReplyCond,
+						},
+					},
+					"as": "reply",
 					"in": bson.M{
-						"content":       "$$reply.content",
-						"reply_to_user": "$$reply.reply_to_user",
-						"created_at":    "$$reply.created_at",
-						"user": bson.M{
+						"_id":          "$$reply._id",
+						"content":      "$$reply.content",
+						"content_html": "$$reply.content_html",
+						"reply_to_user": bson.M{"$ifNull": bson.A{
+							bson.M{"$arrayElemAt": bson.A{replyToUser("$$target.user_name"), 0}},
+							"$$reply.reply_to_user",
+						}},
+						"reply_to": bson.M{"$arrayElemAt": bson.A{replyToUser(bson.M{
+							"_id":       "$$target._id",
+							"user_name": "$$target.user_name",
+							"avatar":    "$$target.avatar",
+						}), 0}},
+						"reply_to_reply_id": "$$reply.reply_to_reply_id",
+						"created_at":        "$$reply.created_at",
+						"edited_at":         "$$reply.edited_at",
+						"deleted":           replyDeleted,
+						"user": bson.M{"$cond": bson.A{replyDeleted, "$$REMOVE", bson.M{
 							"$arrayElemAt": []interface{}{
 								bson.M{
 									"$filter": bson.M{
@@ -113,7 +490,7 @@ func (md *MessageDAO) FindListWithUser(ctx context.Context, skip, limit int64) (
 								},
 								0,
 							},
-						},
+						}}},
 					},
 				},
 			},
@@ -132,3 +509,62 @@ func (md *MessageDAO) FindListWithUser(ctx context.Context, skip, limit int64) (
 	}
 	return messages, nil
 }
+
+// replyToUser 在 reply_to_users 中查找当前回复的被回复用户，按 in 表达式映射
+func replyToUser(in interface{}) bson.M {
+	return bson.M{"$map": bson.M{
+		"input": bson.M{"$filter": bson.M{
+			"input": "$reply_to_users",
+			"as":    "target",
+			"cond":  bson.M{"$eq": bson.A{"$$target._id", "$$reply.reply_to_user_id"}},
+		}},
+		"as": "target",
+		"in": in,
+	}}
+}
+
+// CountStats 统计已通过审核且未删除的留言数和回复数
+func (md *MessageDAO) CountStats(ctx context.Context) (messages, replies int64, err error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": approvedStatus}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      nil,
+			"messages": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$deleted_at", nil}}, 0, 1}}},
+			"replies": bson.M{"$sum": bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$replies", bson.A{}}},
+				"as":    "reply",
+				"cond": bson.M{"$and": bson.A{
+					approvedReplyCond,
+					bson.M{"$lte": bson.A{"$$reply.deleted_at", nil}},
+				}},
+			}}}},
+		}}},
+	}
+
+	cursor, err := md.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Messages int64 `bson:"messages"`
+		Replies  int64 `bson:"replies"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, 0, err
+	}
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+	return results[0].Messages, results[0].Replies, nil
+}
+
+func hasKey(doc bson.D, key string) bool {
+	for _, e := range doc {
+		if e.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"backend/pkg/securetoken"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PasswordResetDAO struct {
+	collection *mongo.Collection
+}
+
+func NewPasswordResetDAO() *PasswordResetDAO {
+	return &PasswordResetDAO{
+		collection: database.Collection("password_reset_tokens"),
+	}
+}
+
+func (pd *PasswordResetDAO) Create(ctx context.Context, token *model.PasswordResetToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	_, err := pd.collection.InsertOne(ctx, token)
+	return err
+}
+
+// FindByToken 按 selector 查找令牌并以常量时间比对哈希，由调用方判断是否过期或已使用
+func (pd *PasswordResetDAO) FindByToken(ctx context.Context, selector, tokenHash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	if err := pd.collection.FindOne(ctx, bson.M{"selector": selector}).Decode(&token); err != nil {
+		return nil, err
+	}
+	if !securetoken.Equal(token.TokenHash, tokenHash) {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &token, nil
+}
+
+// Consume 将令牌标记为已使用，令牌已被使用或已过期时返回 mongo.ErrNoDocuments
+func (pd *PasswordResetDAO) Consume(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	result, err := pd.collection.UpdateOne(ctx, bson.M{
+		"_id":        id,
+		"used_at":    nil,
+		"expires_at": bson.M{"$gt": now},
+	}, bson.M{"$set": bson.M{"used_at": now}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// InvalidateByUserID 作废用户所有未使用的令牌
+func (pd *PasswordResetDAO) InvalidateByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := pd.collection.UpdateMany(ctx, bson.M{
+		"user_id": userID,
+		"used_at": nil,
+	}, bson.M{"$set": bson.M{"used_at": time.Now()}})
+	return err
+}
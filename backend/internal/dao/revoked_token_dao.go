@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RevokedTokenDAO struct {
+	collection *mongo.Collection
+}
+
+func NewRevokedTokenDAO() *RevokedTokenDAO {
+	return &RevokedTokenDAO{
+		collection: database.Collection("revoked_access_tokens"),
+	}
+}
+
+// Add 批量写入黑名单，重复的 jti 忽略
+func (rd *RevokedTokenDAO) Add(ctx context.Context, tokens []model.RevokedAccessToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(tokens))
+	for _, t := range tokens {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": t.JTI}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{
+				"user_id":    t.UserID,
+				"expires_at": t.ExpiresAt,
+				"revoked_at": t.RevokedAt,
+			}}).
+			SetUpsert(true))
+	}
+	_, err := rd.collection.BulkWrite(ctx, models)
+	return err
+}
+
+// FindActive 返回尚未过期的黑名单记录
+func (rd *RevokedTokenDAO) FindActive(ctx context.Context) ([]model.RevokedAccessToken, error) {
+	cursor, err := rd.collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": time.Now()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []model.RevokedAccessToken
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SensitiveWordDAO struct {
+	collection *mongo.Collection
+}
+
+func NewSensitiveWordDAO() *SensitiveWordDAO {
+	return &SensitiveWordDAO{
+		collection: database.Collection("sensitive_words"),
+	}
+}
+
+func (sd *SensitiveWordDAO) FindAll(ctx context.Context) ([]model.SensitiveWord, error) {
+	opts := options.Find().SetSort(bson.M{"word": 1})
+
+	cursor, err := sd.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var words []model.SensitiveWord
+	if err = cursor.All(ctx, &words); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+func (sd *SensitiveWordDAO) ExistsByWord(ctx context.Context, word string) (bool, error) {
+	count, err := sd.collection.CountDocuments(ctx, bson.M{"word": word}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+func (sd *SensitiveWordDAO) Create(ctx context.Context, word *model.SensitiveWord) (*model.SensitiveWord, error) {
+	word.CreatedAt = time.Now()
+	result, err := sd.collection.InsertOne(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	word.ID = result.InsertedID.(primitive.ObjectID)
+	return word, nil
+}
+
+func (sd *SensitiveWordDAO) UpdateWeight(ctx context.Context, id primitive.ObjectID, weight int) (*model.SensitiveWord, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var word model.SensitiveWord
+	err := sd.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"weight": weight}}, opts).Decode(&word)
+	if err != nil {
+		return nil, err
+	}
+	return &word, nil
+}
+
+func (sd *SensitiveWordDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := sd.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SeriesDAO struct {
+	collection *mongo.Collection
+}
+
+func NewSeriesDAO() *SeriesDAO {
+	return &SeriesDAO{
+		collection: database.Collection("series"),
+	}
+}
+
+func (sd *SeriesDAO) FindAll(ctx context.Context) ([]model.Series, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := sd.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var seriesList []model.Series
+	if err = cursor.All(ctx, &seriesList); err != nil {
+		return nil, err
+	}
+	return seriesList, nil
+}
+
+func (sd *SeriesDAO) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Series, error) {
+	var series model.Series
+	err := sd.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&series)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (sd *SeriesDAO) FindBySlug(ctx context.Context, slug string) (*model.Series, error) {
+	var series model.Series
+	err := sd.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&series)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// SlugTaken 判断 slug 是否已被其他系列使用
+func (sd *SeriesDAO) SlugTaken(ctx context.Context, slug string, excludeID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"slug": slug, "_id": bson.M{"$ne": excludeID}}
+	count, err := sd.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+func (sd *SeriesDAO) Create(ctx context.Context, series *model.Series) (*model.Series, error) {
+	now := time.Now()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	result, err := sd.collection.InsertOne(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	series.ID = result.InsertedID.(primitive.ObjectID)
+	return series, nil
+}
+
+func (sd *SeriesDAO) Update(ctx context.Context, id primitive.ObjectID, update *model.SeriesUpdate) (*model.Series, error) {
+	set := bson.M{"updated_at": time.Now()}
+	if update.Title != nil {
+		set["title"] = *update.Title
+	}
+	if update.Slug != nil {
+		set["slug"] = *update.Slug
+	}
+	if update.Description != nil {
+		set["description"] = *update.Description
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var series model.Series
+	err := sd.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, opts).Decode(&series)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (sd *SeriesDAO) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := sd.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"backend/internal/model"
+	"backend/pkg/database"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TagDAO struct {
+	collection *mongo.Collection
+}
+
+func NewTagDAO() *TagDAO {
+	return &TagDAO{
+		collection: database.Collection("tags"),
+	}
+}
+
+// FindInUse 查询公开文章数大于 0 的标签
+func (td *TagDAO) FindInUse(ctx context.Context) ([]model.Tag, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "count", Value: -1}, {Key: "name", Value: 1}})
+
+	cursor, err := td.collection.Find(ctx, bson.M{"count": bson.M{"$gt": 0}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []model.Tag
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (td *TagDAO) FindByName(ctx context.Context, name string) (*model.Tag, error) {
+	var tag model.Tag
+	err := td.collection.FindOne(ctx, bson.M{"name": name}).Decode(&tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (td *TagDAO) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	count, err := td.collection.CountDocuments(ctx, bson.M{"slug": slug}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+func (td *TagDAO) Create(ctx context.Context, tag *model.Tag) error {
+	now := time.Now()
+	tag.CreatedAt = now
+	tag.UpdatedAt = now
+	_, err := td.collection.InsertOne(ctx, tag)
+	return err
+}
+
+func (td *TagDAO) UpdateCount(ctx context.Context, name string, count int64) error {
+	_, err := td.collection.UpdateOne(
+		ctx,
+		bson.M{"name": name},
+		bson.M{"$set": bson.M{"count": count, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+func (td *TagDAO) UpdateDescription(ctx context.Context, slug, description string) (*model.Tag, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var tag model.Tag
+	err := td.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"slug": slug},
+		bson.M{"$set": bson.M{"description": description, "updated_at": time.Now()}},
+		opts,
+	).Decode(&tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
@@ -3,12 +3,15 @@ package dao
 import (
 	"backend/internal/model"
 	"backend/pkg/database"
+	"backend/pkg/securetoken"
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TokenDAO struct {
@@ -21,34 +24,244 @@ func NewTokenDAO() *TokenDAO {
 	}
 }
 
-func (td *TokenDAO) Create(ctx context.Context, userID primitive.ObjectID, token string, expiresAt time.Time) error {
-	refreshToken := &model.RefreshToken{
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: expiresAt,
-		CreatedAt: time.Now(),
-		Revoked:   false,
+func (td *TokenDAO) Create(ctx context.Context, refreshToken *model.RefreshToken) error {
+	if refreshToken.CreatedAt.IsZero() {
+		refreshToken.CreatedAt = time.Now()
 	}
 	_, err := td.collection.InsertOne(ctx, refreshToken)
 	return err
 }
 
-func (td *TokenDAO) FindByToken(ctx context.Context, token string) (*model.RefreshToken, error) {
+// FindActiveByUserID 返回用户所有未吊销且未过期的令牌（每个会话一条），最近使用的在前
+func (td *TokenDAO) FindActiveByUserID(ctx context.Context, userID primitive.ObjectID) ([]model.RefreshToken, error) {
+	cursor, err := td.collection.Find(ctx, bson.M{
+		"user_id":    userID,
+		"revoked":    false,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []model.RefreshToken
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// FindByToken 按 selector 查找令牌并以常量时间比对哈希（包括已吊销的），
+// 由调用方根据 Revoked/RotatedAt 判断是否可用
+func (td *TokenDAO) FindByToken(ctx context.Context, selector, tokenHash string) (*model.RefreshToken, error) {
+	var refreshToken model.RefreshToken
+	err := td.collection.FindOne(ctx, bson.M{"selector": selector}).Decode(&refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if !securetoken.Equal(refreshToken.TokenHash, tokenHash) {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &refreshToken, nil
+}
+
+// FindLegacyToken 查找尚未迁移的明文令牌
+func (td *TokenDAO) FindLegacyToken(ctx context.Context, token string) (*model.RefreshToken, error) {
 	var refreshToken model.RefreshToken
-	err := td.collection.FindOne(ctx, bson.M{
-		"token":   token,
-		"revoked": false,
-	}).Decode(&refreshToken)
+	err := td.collection.FindOne(ctx, bson.M{"token": token}).Decode(&refreshToken)
 	if err != nil {
 		return nil, err
 	}
 	return &refreshToken, nil
 }
 
-func (td *TokenDAO) Revoke(ctx context.Context, token string) error {
+func (td *TokenDAO) Revoke(ctx context.Context, selector, tokenHash string) error {
+	_, err := td.collection.UpdateOne(
+		ctx,
+		bson.M{"selector": selector, "token_hash": tokenHash},
+		bson.M{"$set": bson.M{"revoked": true}},
+	)
+	return err
+}
+
+// SetHash 将明文令牌替换为 selector 与哈希
+func (td *TokenDAO) SetHash(ctx context.Context, id primitive.ObjectID, selector, tokenHash string) error {
 	_, err := td.collection.UpdateOne(
 		ctx,
-		bson.M{"token": token},
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"selector": selector, "token_hash": tokenHash},
+			"$unset": bson.M{"token": ""},
+		},
+	)
+	return err
+}
+
+// FindLegacyTokens 返回仍以明文存储的令牌
+func (td *TokenDAO) FindLegacyTokens(ctx context.Context) ([]model.RefreshToken, error) {
+	cursor, err := td.collection.Find(ctx, bson.M{"token": bson.M{"$exists": true}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []model.RefreshToken
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// DropLegacyIndexes 删除旧版 token 字段上的唯一索引，
+// 迁移后新记录不再有 token 字段，保留该索引会导致插入时唯一键冲突
+func (td *TokenDAO) DropLegacyIndexes(ctx context.Context) error {
+	for _, name := range []string{"idx_token_unique", "token_1"} {
+		_, err := td.collection.Indexes().DropOne(ctx, name)
+		var cmdErr mongo.CommandError
+		if err != nil && !(errors.As(err, &cmdErr) && (cmdErr.Name == "IndexNotFound" || cmdErr.Name == "NamespaceNotFound")) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rotate 将未吊销的令牌标记为已轮换，令牌已被吊销时返回 mongo.ErrNoDocuments
+func (td *TokenDAO) Rotate(ctx context.Context, id primitive.ObjectID) error {
+	result, err := td.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "revoked": false},
+		bson.M{"$set": bson.M{"revoked": true, "rotated_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindFamilyCreatedSince 返回同一 family 中 since 之后签发的令牌（兼容没有 family_id 的旧令牌）
+func (td *TokenDAO) FindFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) ([]model.RefreshToken, error) {
+	cursor, err := td.collection.Find(ctx, bson.M{
+		"$or":        bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}},
+		"created_at": bson.M{"$gte": since},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []model.RefreshToken
+	if err = cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// ClaimReuseGrace 占用已轮换令牌的宽限期重用机会，令牌在 rotatedAfter 之前轮换或已用过时返回 mongo.ErrNoDocuments
+func (td *TokenDAO) ClaimReuseGrace(ctx context.Context, id primitive.ObjectID, rotatedAfter time.Time) error {
+	result, err := td.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":              id,
+			"rotated_at":       bson.M{"$gte": rotatedAfter},
+			"reuse_grace_used": bson.M{"$ne": true},
+		},
+		bson.M{"$set": bson.M{"reuse_grace_used": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SupersedeFamilyCreatedSince 将同一 family 中 since 之后签发且仍有效的令牌标记为已轮换，
+// 之后再使用这些令牌按重用处理
+func (td *TokenDAO) SupersedeFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) error {
+	_, err := td.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"$or":        bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}},
+			"created_at": bson.M{"$gte": since},
+			"revoked":    false,
+		},
+		bson.M{"$set": bson.M{"revoked": true, "rotated_at": time.Now(), "reuse_grace_used": true}},
+	)
+	return err
+}
+
+// RevokeFamily 吊销同一 family 的全部令牌（兼容没有 family_id 的旧令牌）
+func (td *TokenDAO) RevokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	_, err := td.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"$or":     bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}},
+			"revoked": false,
+		},
+		bson.M{"$set": bson.M{"revoked": true}},
+	)
+	return err
+}
+
+// FindLiveAccessByUserID 返回用户所有 access token 尚未过期的令牌记录（包括已吊销的 refresh token）
+func (td *TokenDAO) FindLiveAccessByUserID(ctx context.Context, userID primitive.ObjectID) ([]model.RefreshToken, error) {
+	return td.findLiveAccess(ctx, bson.M{"user_id": userID})
+}
+
+// FindLiveAccessByFamily 返回同一会话中 access token 尚未过期的令牌记录
+func (td *TokenDAO) FindLiveAccessByFamily(ctx context.Context, familyID primitive.ObjectID) ([]model.RefreshToken, error) {
+	return td.findLiveAccess(ctx, bson.M{"$or": bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}}})
+}
+
+func (td *TokenDAO) findLiveAccess(ctx context.Context, filter bson.M) ([]model.RefreshToken, error) {
+	filter["access_expires_at"] = bson.M{"$gt": time.Now()}
+	cursor, err := td.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tokens []model.RefreshToken
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeUserFamily 吊销用户的指定会话，会话不存在或已失效时返回 mongo.ErrNoDocuments
+func (td *TokenDAO) RevokeUserFamily(ctx context.Context, userID, familyID primitive.ObjectID) error {
+	result, err := td.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"user_id": userID,
+			"$or":     bson.A{bson.M{"family_id": familyID}, bson.M{"_id": familyID}},
+			"revoked": false,
+		},
+		bson.M{"$set": bson.M{"revoked": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RevokeOthersByUserID 吊销用户除 keepFamilyID 以外的全部会话
+func (td *TokenDAO) RevokeOthersByUserID(ctx context.Context, userID, keepFamilyID primitive.ObjectID) error {
+	_, err := td.collection.UpdateMany(
+		ctx,
+		bson.M{
+			"user_id":   userID,
+			"family_id": bson.M{"$ne": keepFamilyID},
+			"_id":       bson.M{"$ne": keepFamilyID},
+			"revoked":   false,
+		},
 		bson.M{"$set": bson.M{"revoked": true}},
 	)
 	return err
@@ -64,3 +64,79 @@ func (ud *UserDAO) ExistsByUsername(ctx context.Context, username string) (bool,
 	count, err := ud.collection.CountDocuments(ctx, bson.M{"user_name": username})
 	return count > 0, err
 }
+
+func (ud *UserDAO) UpdateDisabled(ctx context.Context, id primitive.ObjectID, disabled bool) error {
+	result, err := ud.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"is_disabled": disabled}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdatePassword 更新密码哈希，用户不存在时返回 mongo.ErrNoDocuments
+func (ud *UserDAO) UpdatePassword(ctx context.Context, id primitive.ObjectID, passwordHash string) error {
+	result, err := ud.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"password": passwordHash}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindByVerifiedEmail 按已验证的邮箱查找用户
+func (ud *UserDAO) FindByVerifiedEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := ud.collection.FindOne(ctx, bson.M{"email": email, "email_verified": true}).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// VerifiedEmailExists 邮箱是否已被其他账号验证
+func (ud *UserDAO) VerifiedEmailExists(ctx context.Context, email string, excludeID primitive.ObjectID) (bool, error) {
+	count, err := ud.collection.CountDocuments(ctx, bson.M{
+		"email":          email,
+		"email_verified": true,
+		"_id":            bson.M{"$ne": excludeID},
+	})
+	return count > 0, err
+}
+
+// UpdateEmail 设置新邮箱并重置验证状态
+func (ud *UserDAO) UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error {
+	result, err := ud.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"email": email, "email_verified": false},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// MarkEmailVerified 将邮箱标记为已验证，用户已改用其他邮箱时返回 mongo.ErrNoDocuments
+func (ud *UserDAO) MarkEmailVerified(ctx context.Context, id primitive.ObjectID, email string) error {
+	result, err := ud.collection.UpdateOne(ctx, bson.M{"_id": id, "email": email}, bson.M{
+		"$set": bson.M{"email_verified": true},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Count 统计用户总数
+func (ud *UserDAO) Count(ctx context.Context) (int64, error) {
+	return ud.collection.CountDocuments(ctx, bson.M{})
+}
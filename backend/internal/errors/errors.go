@@ -15,6 +15,16 @@ const (
 	CodeUnauthorized  = 3
 	CodeServerError   = 4
 	CodeConflict      = 5
+	CodeForbidden     = 6
+
+	// 登录防爆破
+	CodeCaptchaRequired = 7
+	CodeCaptchaInvalid  = 8
+	CodeTooManyAttempts = 9
+	CodeAccountLocked   = 10
+
+	// 接口限流
+	CodeTooManyRequests = 11
 )
 
 // 通用业务错误
@@ -23,6 +33,7 @@ var (
 	ErrInvalidParams    = errors.New("参数无效")
 	ErrUnauthorized     = errors.New("未授权")
 	ErrConflict         = errors.New("资源冲突")
+	ErrForbidden        = errors.New("权限不足")
 	ErrInternalServer   = errors.New("服务器内部错误")
 	ErrDatabaseError    = errors.New("数据库错误")
 	ErrOperationTimeout = errors.New("操作超时")
@@ -91,6 +102,15 @@ func ConflictError(msg string) *AppError {
 	}
 }
 
+// ForbiddenError 创建权限不足错误
+func ForbiddenError(msg string) *AppError {
+	return &AppError{
+		Code:    CodeForbidden,
+		Message: msg,
+		Err:     ErrForbidden,
+	}
+}
+
 // ServerError 创建服务器错误
 func ServerError(err error) *AppError {
 	return &AppError{
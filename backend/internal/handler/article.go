@@ -2,9 +2,13 @@ package handler
 
 import (
 	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
 	"backend/internal/service"
 	"context"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,6 +21,46 @@ type ArticleHandler struct {
 	service service.ArticleServiceInterface
 }
 
+// 管理接口请求结构体
+type (
+	CreateArticleRequest struct {
+		ArticleType string   `json:"article_type" binding:"required,max=20"`
+		Title       string   `json:"title" binding:"required,max=100"`
+		Slug        string   `json:"slug" binding:"omitempty,max=80"`
+		Content     string   `json:"content" binding:"required"`
+		Tag         string   `json:"tag" binding:"omitempty,max=30"`
+		Tags        []string `json:"tags" binding:"omitempty,max=10,dive,max=30"`
+		CoverImage  string   `json:"cover_image" binding:"omitempty,url"`
+		CategoryID  string   `json:"category_id"`
+	}
+
+	PublishArticleRequest struct {
+		CreateArticleRequest
+		Status    string    `json:"status" binding:"omitempty,oneof=draft scheduled published"`
+		PublishAt time.Time `json:"publish_at"`
+	}
+
+	ChangeStatusRequest struct {
+		Status    string    `json:"status" binding:"required,oneof=draft scheduled published archived"`
+		PublishAt time.Time `json:"publish_at"`
+	}
+
+	// UpdateArticleRequest PUT 整体替换，字段要求与创建一致
+	UpdateArticleRequest = CreateArticleRequest
+
+	PatchArticleRequest struct {
+		ArticleType *string  `json:"article_type" binding:"omitempty,max=20"`
+		Title       *string  `json:"title" binding:"omitempty,max=100"`
+		Slug        *string  `json:"slug" binding:"omitempty,max=80"`
+		Content     *string  `json:"content"`
+		Tag         *string  `json:"tag" binding:"omitempty,max=30"`
+		Tags        []string `json:"tags" binding:"omitempty,max=10,dive,max=30"`
+		CoverImage  *string  `json:"cover_image" binding:"omitempty,url"`
+		// CategoryID 为空字符串表示移出分类
+		CategoryID *string `json:"category_id"`
+	}
+)
+
 // Legacy API 请求结构体
 type (
 	GetArticleRequest struct {
@@ -90,13 +134,60 @@ func (h *ArticleHandler) GetArticle(c *gin.Context) {
 		h.service.IncrementPageViews(ctx, articleID)
 	}(id)
 
-	SuccessWithData(c, "查询成功", article)
+	h.respondDetail(c, article)
 }
 
-// Extend GET /api/v1/articles/extend?tag=xxx
+// GetArticleBySlug GET /api/v1/articles/by-slug/:slug
+func (h *ArticleHandler) GetArticleBySlug(c *gin.Context) {
+	articleSlug := c.Param("slug")
+	if articleSlug == "" {
+		BadRequest(c, "请传入要查询的文章slug")
+		return
+	}
+
+	article, err := h.service.GetBySlug(c.Request.Context(), articleSlug)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			NotFound(c, "没有对应的文章")
+			return
+		}
+		ServerError(c)
+		return
+	}
+
+	// 命中历史 slug：告知客户端新的地址
+	if article.Slug != articleSlug {
+		c.Header("Location", "/api/v1/articles/by-slug/"+article.Slug)
+		c.JSON(http.StatusMovedPermanently, Response{
+			Code: apperrors.CodeSuccess,
+			Msg:  "文章地址已变更",
+			Data: gin.H{"slug": article.Slug},
+		})
+		return
+	}
+
+	go func(articleID primitive.ObjectID) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		h.service.IncrementPageViews(ctx, articleID)
+	}(article.ID)
+
+	h.respondDetail(c, article)
+}
+
+// respondDetail 返回附带分类、系列和上一篇/下一篇导航的文章详情
+func (h *ArticleHandler) respondDetail(c *gin.Context, article *model.Article) {
+	detail, err := h.service.GetDetail(c.Request.Context(), article)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "查询成功", detail)
+}
+
+// Extend GET /api/v1/articles/extend?tags=a,b&match=any
 func (h *ArticleHandler) Extend(c *gin.Context) {
-	tag := c.Query("tag")
-	articles, err := h.service.GetExtend(c.Request.Context(), tag, 2)
+	articles, err := h.service.GetExtend(c.Request.Context(), parseTagFilter(c), 2)
 	if err != nil {
 		ServerError(c)
 		return
@@ -129,11 +220,17 @@ func (h *ArticleHandler) GetHot(c *gin.Context) {
 	SuccessList(c, articles)
 }
 
-// GetShow GET /api/v1/articles?skip=0&limit=10&tag=xxx
+// GetShow GET /api/v1/articles?skip=0&limit=10&tags=a,b&match=all&category=xxx
 func (h *ArticleHandler) GetShow(c *gin.Context) {
 	skip := int64(0)
 	limit := int64(10)
-	tag := c.Query("tag")
+	filter := parseTagFilter(c)
+	if category := c.Query("category"); category != "" {
+		if filter == nil {
+			filter = &model.ArticleFilter{}
+		}
+		filter.Category = category
+	}
 
 	if s, err := strconv.ParseInt(c.Query("skip"), 10, 64); err == nil {
 		skip = s
@@ -142,15 +239,15 @@ func (h *ArticleHandler) GetShow(c *gin.Context) {
 		limit = l
 	}
 
-	articles, err := h.service.GetList(c.Request.Context(), tag, skip, limit)
+	articles, err := h.service.GetList(c.Request.Context(), filter, skip, limit)
 	if err != nil {
-		ServerError(c)
+		HandleError(c, err)
 		return
 	}
 	SuccessList(c, articles)
 }
 
-// Search GET /api/v1/articles/search?q=xxx
+// Search GET /api/v1/articles/search?q=xxx&tag=xxx&from=2024-01-01&to=2024-12-31&skip=0&limit=10
 func (h *ArticleHandler) Search(c *gin.Context) {
 	keywords := c.Query("q")
 	if keywords == "" {
@@ -158,12 +255,304 @@ func (h *ArticleHandler) Search(c *gin.Context) {
 		return
 	}
 
-	articles, err := h.service.Search(c.Request.Context(), keywords, 5)
+	query := &model.ArticleSearchQuery{
+		Keywords: keywords,
+		Tag:      c.Query("tag"),
+		Limit:    10,
+	}
+	if s, err := strconv.ParseInt(c.Query("skip"), 10, 64); err == nil {
+		query.Skip = s
+	}
+	if l, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && l > 0 {
+		query.Limit = l
+	}
+
+	var err error
+	if query.From, err = parseDateQuery(c.Query("from"), false); err != nil {
+		BadRequest(c, "无效的开始日期")
+		return
+	}
+	if query.To, err = parseDateQuery(c.Query("to"), true); err != nil {
+		BadRequest(c, "无效的结束日期")
+		return
+	}
+
+	result, err := h.service.SearchArticles(c.Request.Context(), query)
 	if err != nil {
-		ServerError(c)
+		HandleError(c, err)
 		return
 	}
-	SuccessListWithMsg(c, "查询成功", articles)
+	SuccessWithData(c, "查询成功", result)
+}
+
+// parseDateQuery 解析 YYYY-MM-DD 或 RFC3339 格式的日期参数；
+// 仅有日期且 endOfDay 为 true 时返回次日零点，便于作为开区间上界
+func parseDateQuery(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseTagFilter 解析标签筛选参数：tags 为逗号分隔的多个标签（兼容单个 tag），
+// match=all 时要求同时包含全部标签，默认包含任意一个即可
+func parseTagFilter(c *gin.Context) *model.ArticleFilter {
+	var tags []string
+	for _, t := range strings.Split(c.Query("tags"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if tag := strings.TrimSpace(c.Query("tag")); tag != "" {
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return &model.ArticleFilter{Tags: tags, MatchAllTags: c.Query("match") == "all"}
+}
+
+// legacyTagFilter 旧版接口只支持单个标签
+func legacyTagFilter(tag string) *model.ArticleFilter {
+	if tag == "" {
+		return nil
+	}
+	return &model.ArticleFilter{Tags: []string{tag}}
+}
+
+// parseCategoryID 解析请求中的分类 ID，空字符串解析为 NilObjectID（表示不属于任何分类）
+func parseCategoryID(c *gin.Context, value string) (*primitive.ObjectID, bool) {
+	if value == "" {
+		id := primitive.NilObjectID
+		return &id, true
+	}
+	id, err := primitive.ObjectIDFromHex(value)
+	if err != nil {
+		BadRequest(c, "无效的分类id")
+		return nil, false
+	}
+	return &id, true
+}
+
+// mergeTags 合并单个 tag 与 tags 字段，tag 排在最前作为主标签
+func mergeTags(tag string, tags []string) []string {
+	if tag == "" {
+		return tags
+	}
+	return append([]string{tag}, tags...)
+}
+
+// ========== 管理接口（需管理员权限） ==========
+
+// ManageList GET /api/v1/manage/articles?status=draft&skip=0&limit=10
+// 包括草稿、定时和归档文章；没有 article:manage 权限时只返回自己的文章
+func (h *ArticleHandler) ManageList(c *gin.Context) {
+	skip := int64(0)
+	limit := int64(10)
+	if s, err := strconv.ParseInt(c.Query("skip"), 10, 64); err == nil && s > 0 {
+		skip = s
+	}
+	if l, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && l > 0 {
+		limit = l
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	articles, err := h.service.ListManaged(c.Request.Context(), userID, role, c.Query("status"), skip, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessList(c, articles)
+}
+
+// ManageGet GET /api/v1/manage/articles/:id
+// 不限状态，用于编辑草稿和定时文章
+func (h *ArticleHandler) ManageGet(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	article, err := h.service.GetManaged(c.Request.Context(), id, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Success(c, article)
+}
+
+// Create POST /api/v1/articles
+func (h *ArticleHandler) Create(c *gin.Context) {
+	var req PublishArticleRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	article := &model.Article{
+		ArticleType: req.ArticleType,
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Content:     req.Content,
+		Tags:        mergeTags(req.Tag, req.Tags),
+		CoverImage:  req.CoverImage,
+		Status:      req.Status,
+		PublishAt:   req.PublishAt,
+	}
+	if userID, ok := middleware.GetUserID(c); ok {
+		article.AuthorID = &userID
+	}
+	if req.CategoryID != "" {
+		categoryID, ok := parseCategoryID(c, req.CategoryID)
+		if !ok {
+			return
+		}
+		article.CategoryID = categoryID
+	}
+
+	created, err := h.service.Create(c.Request.Context(), article)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	Created(c, "发布成功", created)
+}
+
+// Update PUT /api/v1/articles/:id
+func (h *ArticleHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	var req UpdateArticleRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	// 整体替换时标签不能缺省，空切片交由 service 校验
+	tags := mergeTags(req.Tag, req.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	categoryID, ok := parseCategoryID(c, req.CategoryID)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	article, err := h.service.Update(c.Request.Context(), id, userID, role, &model.ArticleUpdate{
+		ArticleType: &req.ArticleType,
+		Title:       &req.Title,
+		Slug:        &req.Slug,
+		Content:     &req.Content,
+		Tags:        tags,
+		CoverImage:  &req.CoverImage,
+		CategoryID:  categoryID,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessWithData(c, "更新成功", article)
+}
+
+// Patch PATCH /api/v1/articles/:id
+func (h *ArticleHandler) Patch(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	var req PatchArticleRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	update := &model.ArticleUpdate{
+		ArticleType: req.ArticleType,
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Content:     req.Content,
+		Tags:        req.Tags,
+		CoverImage:  req.CoverImage,
+	}
+	if req.Tag != nil {
+		update.Tags = mergeTags(*req.Tag, req.Tags)
+	}
+	if req.CategoryID != nil {
+		categoryID, ok := parseCategoryID(c, *req.CategoryID)
+		if !ok {
+			return
+		}
+		update.CategoryID = categoryID
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	article, err := h.service.Update(c.Request.Context(), id, userID, role, update)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessWithData(c, "更新成功", article)
+}
+
+// ChangeStatus PATCH /api/v1/articles/:id/status
+func (h *ArticleHandler) ChangeStatus(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	var req ChangeStatusRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	article, err := h.service.ChangeStatus(c.Request.Context(), id, userID, role, req.Status, req.PublishAt)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessWithData(c, "状态已更新", article)
+}
+
+// Delete DELETE /api/v1/articles/:id
+func (h *ArticleHandler) Delete(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	if err := h.service.Delete(c.Request.Context(), id, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessWithMsg(c, "删除成功")
 }
 
 // ========== Legacy API (旧版兼容) ==========
@@ -204,7 +593,7 @@ func (h *ArticleHandler) ExtendLegacy(c *gin.Context) {
 		req.Tag = ""
 	}
 
-	articles, err := h.service.GetExtend(c.Request.Context(), req.Tag, 2)
+	articles, err := h.service.GetExtend(c.Request.Context(), legacyTagFilter(req.Tag), 2)
 	if err != nil {
 		ErrorWithData(c, 4, "服务器异常~")
 		return
@@ -246,7 +635,7 @@ func (h *ArticleHandler) GetShowLegacy(c *gin.Context) {
 		req.Limit = 10
 	}
 
-	articles, err := h.service.GetList(c.Request.Context(), req.Tag, req.Skip, req.Limit)
+	articles, err := h.service.GetList(c.Request.Context(), legacyTagFilter(req.Tag), req.Skip, req.Limit)
 	if err != nil {
 		Error(c, 4, "服务器错误")
 		return
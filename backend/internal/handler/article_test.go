@@ -1,13 +1,17 @@
 package handler
 
 import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
 	"backend/internal/model"
 	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,12 +21,21 @@ import (
 type MockArticleService struct {
 	// 用于控制返回值的字段
 	GetByIDFunc            func(ctx context.Context, id primitive.ObjectID) (*model.Article, error)
+	GetBySlugFunc          func(ctx context.Context, slug string) (*model.Article, error)
+	GetDetailFunc          func(ctx context.Context, article *model.Article) (*model.ArticleDetail, error)
 	GetHotFunc             func(ctx context.Context, limit int64) ([]model.Article, error)
-	GetListFunc            func(ctx context.Context, tag string, skip, limit int64) ([]model.Article, error)
+	GetListFunc            func(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error)
 	SearchFunc             func(ctx context.Context, keywords string, limit int64) ([]model.ArticleBrief, error)
-	GetExtendFunc          func(ctx context.Context, tag string, limit int64) ([]model.ArticleBrief, error)
+	SearchArticlesFunc     func(ctx context.Context, query *model.ArticleSearchQuery) (*model.ArticleSearchResult, error)
+	GetExtendFunc          func(ctx context.Context, filter *model.ArticleFilter, limit int64) ([]model.ArticleBrief, error)
 	GetInfoFunc            func(ctx context.Context) (*model.ArticleInfo, error)
 	IncrementPageViewsFunc func(ctx context.Context, id primitive.ObjectID) error
+	GetManagedFunc         func(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error)
+	ListManagedFunc        func(ctx context.Context, userID primitive.ObjectID, role, status string, skip, limit int64) ([]model.Article, error)
+	CreateFunc             func(ctx context.Context, article *model.Article) (*model.Article, error)
+	UpdateFunc             func(ctx context.Context, id, userID primitive.ObjectID, role string, update *model.ArticleUpdate) (*model.Article, error)
+	DeleteFunc             func(ctx context.Context, id, userID primitive.ObjectID, role string) error
+	ChangeStatusFunc       func(ctx context.Context, id, userID primitive.ObjectID, role, status string, publishAt time.Time) (*model.Article, error)
 }
 
 func (m *MockArticleService) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error) {
@@ -32,6 +45,20 @@ func (m *MockArticleService) GetByID(ctx context.Context, id primitive.ObjectID)
 	return nil, nil
 }
 
+func (m *MockArticleService) GetBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	if m.GetBySlugFunc != nil {
+		return m.GetBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockArticleService) GetDetail(ctx context.Context, article *model.Article) (*model.ArticleDetail, error) {
+	if m.GetDetailFunc != nil {
+		return m.GetDetailFunc(ctx, article)
+	}
+	return &model.ArticleDetail{Article: *article}, nil
+}
+
 func (m *MockArticleService) GetHot(ctx context.Context, limit int64) ([]model.Article, error) {
 	if m.GetHotFunc != nil {
 		return m.GetHotFunc(ctx, limit)
@@ -39,9 +66,9 @@ func (m *MockArticleService) GetHot(ctx context.Context, limit int64) ([]model.A
 	return nil, nil
 }
 
-func (m *MockArticleService) GetList(ctx context.Context, tag string, skip, limit int64) ([]model.Article, error) {
+func (m *MockArticleService) GetList(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error) {
 	if m.GetListFunc != nil {
-		return m.GetListFunc(ctx, tag, skip, limit)
+		return m.GetListFunc(ctx, filter, skip, limit)
 	}
 	return nil, nil
 }
@@ -53,9 +80,16 @@ func (m *MockArticleService) Search(ctx context.Context, keywords string, limit
 	return nil, nil
 }
 
-func (m *MockArticleService) GetExtend(ctx context.Context, tag string, limit int64) ([]model.ArticleBrief, error) {
+func (m *MockArticleService) SearchArticles(ctx context.Context, query *model.ArticleSearchQuery) (*model.ArticleSearchResult, error) {
+	if m.SearchArticlesFunc != nil {
+		return m.SearchArticlesFunc(ctx, query)
+	}
+	return &model.ArticleSearchResult{List: []model.ArticleSearchHit{}}, nil
+}
+
+func (m *MockArticleService) GetExtend(ctx context.Context, filter *model.ArticleFilter, limit int64) ([]model.ArticleBrief, error) {
 	if m.GetExtendFunc != nil {
-		return m.GetExtendFunc(ctx, tag, limit)
+		return m.GetExtendFunc(ctx, filter, limit)
 	}
 	return nil, nil
 }
@@ -74,6 +108,48 @@ func (m *MockArticleService) IncrementPageViews(ctx context.Context, id primitiv
 	return nil
 }
 
+func (m *MockArticleService) GetManaged(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error) {
+	if m.GetManagedFunc != nil {
+		return m.GetManagedFunc(ctx, id, userID, role)
+	}
+	return nil, nil
+}
+
+func (m *MockArticleService) ListManaged(ctx context.Context, userID primitive.ObjectID, role, status string, skip, limit int64) ([]model.Article, error) {
+	if m.ListManagedFunc != nil {
+		return m.ListManagedFunc(ctx, userID, role, status, skip, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockArticleService) Create(ctx context.Context, article *model.Article) (*model.Article, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, article)
+	}
+	return article, nil
+}
+
+func (m *MockArticleService) Update(ctx context.Context, id, userID primitive.ObjectID, role string, update *model.ArticleUpdate) (*model.Article, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, userID, role, update)
+	}
+	return nil, nil
+}
+
+func (m *MockArticleService) Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id, userID, role)
+	}
+	return nil
+}
+
+func (m *MockArticleService) ChangeStatus(ctx context.Context, id, userID primitive.ObjectID, role, status string, publishAt time.Time) (*model.Article, error) {
+	if m.ChangeStatusFunc != nil {
+		return m.ChangeStatusFunc(ctx, id, userID, role, status, publishAt)
+	}
+	return nil, nil
+}
+
 func TestArticleHandler_GetArticle_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -123,6 +199,58 @@ func TestArticleHandler_GetArticle_Success(t *testing.T) {
 	}
 }
 
+func TestArticleHandler_GetArticle_SeriesNav(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	articleID := primitive.NewObjectID()
+	next := model.ArticleBrief{ID: primitive.NewObjectID(), Title: "第二篇"}
+	mockService := &MockArticleService{
+		GetByIDFunc: func(ctx context.Context, id primitive.ObjectID) (*model.Article, error) {
+			return &model.Article{ID: id, Title: "第一篇"}, nil
+		},
+		GetDetailFunc: func(ctx context.Context, article *model.Article) (*model.ArticleDetail, error) {
+			return &model.ArticleDetail{
+				Article: *article,
+				Series:  &model.SeriesNav{Title: "Go 入门", Part: 1, Total: 5, Next: &next},
+			}, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles/"+articleID.Hex(), nil)
+
+	handler.GetArticle(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data struct {
+			Title  string `json:"title"`
+			Series struct {
+				Part  int `json:"part"`
+				Total int `json:"total"`
+				Next  *struct {
+					Title string `json:"title"`
+				} `json:"next"`
+			} `json:"series"`
+		} `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	if response.Data.Title != "第一篇" || response.Data.Series.Part != 1 || response.Data.Series.Total != 5 {
+		t.Errorf("系列位置不正确: %+v", response.Data)
+	}
+	if response.Data.Series.Next == nil || response.Data.Series.Next.Title != "第二篇" {
+		t.Errorf("下一篇不正确: %+v", response.Data.Series.Next)
+	}
+}
+
 func TestArticleHandler_GetArticle_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -183,3 +311,356 @@ func TestArticleHandler_GetHot_Success(t *testing.T) {
 		t.Errorf("期望返回 2 篇文章, 实际 %d", len(list))
 	}
 }
+
+func TestArticleHandler_Create_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received *model.Article
+	mockService := &MockArticleService{
+		CreateFunc: func(ctx context.Context, article *model.Article) (*model.Article, error) {
+			received = article
+			article.ID = primitive.NewObjectID()
+			return article, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"article_type":"原创","title":"新文章","content":"正文","tag":"Go","tags":["Gin"]}`
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/articles", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Create(c)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusCreated, w.Code)
+	}
+	if received == nil || received.Title != "新文章" || len(received.Tags) != 2 || received.Tags[0] != "Go" {
+		t.Errorf("Service 收到的文章不正确: %+v", received)
+	}
+}
+
+func TestArticleHandler_Create_MissingFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	called := false
+	mockService := &MockArticleService{
+		CreateFunc: func(ctx context.Context, article *model.Article) (*model.Article, error) {
+			called = true
+			return article, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/articles", strings.NewReader(`{"title":"只有标题"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Create(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusBadRequest, w.Code)
+	}
+	if called {
+		t.Error("参数校验失败时不应调用 Service")
+	}
+}
+
+func TestArticleHandler_Delete_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockArticleService{
+		DeleteFunc: func(ctx context.Context, id, userID primitive.ObjectID, role string) error {
+			return apperrors.NotFoundError("文章")
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	articleID := primitive.NewObjectID()
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/articles/"+articleID.Hex(), nil)
+
+	handler.Delete(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestArticleHandler_Patch_OtherAuthorForbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID()
+	var receivedUser primitive.ObjectID
+	var receivedRole string
+	mockService := &MockArticleService{
+		UpdateFunc: func(ctx context.Context, id, uID primitive.ObjectID, role string, update *model.ArticleUpdate) (*model.Article, error) {
+			receivedUser, receivedRole = uID, role
+			return nil, apperrors.ForbiddenError("只能管理自己的文章")
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, userID)
+	c.Set(middleware.ContextRole, model.RoleAuthor)
+	articleID := primitive.NewObjectID()
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/api/v1/articles/"+articleID.Hex(), strings.NewReader(`{"title":"改别人的文章"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Patch(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusForbidden, w.Code)
+	}
+	if receivedUser != userID || receivedRole != model.RoleAuthor {
+		t.Errorf("Service 应收到当前用户和角色, 实际 %s, %q", receivedUser.Hex(), receivedRole)
+	}
+}
+
+func TestArticleHandler_ChangeStatus_Lifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// mock 按照 model 中的状态流转规则维护一篇文章的状态
+	articleID := primitive.NewObjectID()
+	current := &model.Article{ID: articleID, Status: model.ArticleStatusDraft}
+	mockService := &MockArticleService{
+		ChangeStatusFunc: func(ctx context.Context, id, userID primitive.ObjectID, role, status string, publishAt time.Time) (*model.Article, error) {
+			if !model.CanTransitionArticleStatus(current.GetStatus(), status) {
+				return nil, apperrors.InvalidParamsError("文章不能从 " + current.GetStatus() + " 变更为 " + status)
+			}
+			if status == model.ArticleStatusScheduled && !publishAt.After(time.Now()) {
+				return nil, apperrors.InvalidParamsError("定时发布时间必须晚于当前时间")
+			}
+			current.Status, current.PublishAt = status, publishAt
+			return current, nil
+		},
+	}
+	handler := NewArticleHandlerWithService(mockService)
+
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	steps := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"草稿不能直接归档", `{"status":"archived"}`, http.StatusBadRequest},
+		{"定时时间已过", `{"status":"scheduled","publish_at":"` + past + `"}`, http.StatusBadRequest},
+		{"草稿定时发布", `{"status":"scheduled","publish_at":"` + future + `"}`, http.StatusOK},
+		{"定时改为立即发布", `{"status":"published"}`, http.StatusOK},
+		{"已发布不能再定时", `{"status":"scheduled","publish_at":"` + future + `"}`, http.StatusBadRequest},
+		{"归档", `{"status":"archived"}`, http.StatusOK},
+		{"归档退回草稿", `{"status":"draft"}`, http.StatusOK},
+		{"无效状态", `{"status":"deleted"}`, http.StatusBadRequest},
+	}
+
+	for _, step := range steps {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set(middleware.ContextUserID, primitive.NewObjectID())
+		c.Set(middleware.ContextRole, model.RoleAuthor)
+		c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+		c.Request, _ = http.NewRequest(http.MethodPatch, "/api/v1/articles/"+articleID.Hex()+"/status", strings.NewReader(step.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.ChangeStatus(c)
+
+		if w.Code != step.want {
+			t.Fatalf("%s: 期望状态码 %d, 实际 %d (%s)", step.name, step.want, w.Code, w.Body.String())
+		}
+	}
+	if current.Status != model.ArticleStatusDraft {
+		t.Errorf("期望最终状态为 draft, 实际 %s", current.Status)
+	}
+}
+
+func TestArticleHandler_ManageList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID()
+	mockService := &MockArticleService{
+		ListManagedFunc: func(ctx context.Context, uID primitive.ObjectID, role, status string, skip, limit int64) ([]model.Article, error) {
+			if uID != userID || role != model.RoleAuthor || status != model.ArticleStatusDraft || skip != 10 || limit != 5 {
+				t.Errorf("参数不正确: %s, %q, %q, %d, %d", uID.Hex(), role, status, skip, limit)
+			}
+			return []model.Article{{Title: "草稿", Status: model.ArticleStatusDraft}}, nil
+		},
+	}
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, userID)
+	c.Set(middleware.ContextRole, model.RoleAuthor)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/manage/articles?status=draft&skip=10&limit=5", nil)
+
+	handler.ManageList(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"status":"draft"`) {
+		t.Errorf("响应应包含草稿: %s", w.Body.String())
+	}
+}
+
+func TestArticleHandler_ManageGet_Forbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockArticleService{
+		GetManagedFunc: func(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error) {
+			return nil, apperrors.ForbiddenError("只能管理自己的文章")
+		},
+	}
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	articleID := primitive.NewObjectID()
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/manage/articles/"+articleID.Hex(), nil)
+
+	handler.ManageGet(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestArticleHandler_GetArticleBySlug_Redirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 旧 slug 命中后返回的文章带有新的 slug
+	mockService := &MockArticleService{
+		GetBySlugFunc: func(ctx context.Context, slug string) (*model.Article, error) {
+			return &model.Article{ID: primitive.NewObjectID(), Slug: "new-slug"}, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "slug", Value: "old-slug"}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles/by-slug/old-slug", nil)
+
+	handler.GetArticleBySlug(c)
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusMovedPermanently, w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v1/articles/by-slug/new-slug" {
+		t.Errorf("Location 不正确: %s", loc)
+	}
+}
+
+func TestArticleHandler_GetShow_TagFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received *model.ArticleFilter
+	mockService := &MockArticleService{
+		GetListFunc: func(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error) {
+			received = filter
+			return []model.Article{}, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles?tags=Go,%20Gin,&match=all", nil)
+
+	handler.GetShow(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusOK, w.Code)
+	}
+	if received == nil || !received.MatchAllTags || len(received.Tags) != 2 || received.Tags[1] != "Gin" {
+		t.Errorf("标签筛选解析不正确: %+v", received)
+	}
+}
+
+func TestArticleHandler_GetShow_CategoryNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received *model.ArticleFilter
+	mockService := &MockArticleService{
+		GetListFunc: func(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error) {
+			received = filter
+			return nil, apperrors.NotFoundError("分类")
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles?category=backend&tag=Go", nil)
+
+	handler.GetShow(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusNotFound, w.Code)
+	}
+	if received == nil || received.Category != "backend" || len(received.Tags) != 1 {
+		t.Errorf("分类筛选解析不正确: %+v", received)
+	}
+}
+
+func TestArticleHandler_Search_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received *model.ArticleSearchQuery
+	mockService := &MockArticleService{
+		SearchArticlesFunc: func(ctx context.Context, query *model.ArticleSearchQuery) (*model.ArticleSearchResult, error) {
+			received = query
+			return &model.ArticleSearchResult{List: []model.ArticleSearchHit{}}, nil
+		},
+	}
+
+	handler := NewArticleHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles/search?q=gin&tag=Go&from=2024-01-01&to=2024-01-31&skip=10&limit=5", nil)
+
+	handler.Search(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusOK, w.Code)
+	}
+	if received.Tag != "Go" || received.Skip != 10 || received.Limit != 5 {
+		t.Errorf("查询参数解析不正确: %+v", received)
+	}
+	if !received.To.After(received.From) || received.To.Day() != 1 || received.To.Month() != time.February {
+		t.Errorf("结束日期应为次日零点: %v", received.To)
+	}
+}
+
+func TestArticleHandler_Search_InvalidDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewArticleHandlerWithService(&MockArticleService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/articles/search?q=gin&from=yesterday", nil)
+
+	handler.Search(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -1,9 +1,18 @@
 package handler
 
 import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
 	"backend/internal/service"
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,10 +21,8 @@ import (
 
 // ========== 常量 ==========
 
-var (
-	userRegex = regexp.MustCompile(`^[\w\p{Han}\p{Hangul}\x{0800}-\x{4e00}\-]{2,7}$`)
-	pwdRegex  = regexp.MustCompile(`^[\w<>,.?|;':"{}!@#$%^&*()/\-\[\]\\]{6,18}$`)
-)
+// 密码强度由 service 层的 PasswordPolicy 校验，登录时不再限制密码格式以兼容旧密码
+var userRegex = regexp.MustCompile(`^[\w\p{Han}\p{Hangul}\x{0800}-\x{4e00}\-]{2,7}$`)
 
 // ========== 类型定义 ==========
 
@@ -28,6 +35,9 @@ type AuthHandler struct {
 type LoginRequest struct {
 	UserName string `json:"user_name" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// 多次失败后必填
+	CaptchaCode string `json:"captcha_code"`
+	CaptchaID   string `json:"captcha_id"`
 }
 
 type LogoutRequest struct {
@@ -39,8 +49,10 @@ type RefreshRequest struct {
 }
 
 type RegisterRequest struct {
-	UserName    string `json:"user_name" binding:"required"`
-	Password    string `json:"password" binding:"required"`
+	UserName string `json:"user_name" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	// 可选，填写后发送验证邮件
+	Email       string `json:"email"`
 	CaptchaCode string `json:"captcha_code" binding:"required"`
 	CaptchaID   string `json:"captcha_id" binding:"required"`
 }
@@ -82,22 +94,24 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	if !userRegex.MatchString(req.UserName) || !pwdRegex.MatchString(req.Password) {
+	if !userRegex.MatchString(req.UserName) {
 		Error(c, 2, "用户名或密码不符合规则")
 		return
 	}
 
-	user, err := h.authService.Login(c.Request.Context(), req.UserName, req.Password)
+	user, err := h.authService.Login(c.Request.Context(), service.LoginInput{
+		UserName:    req.UserName,
+		Password:    req.Password,
+		ClientIP:    c.ClientIP(),
+		CaptchaID:   req.CaptchaID,
+		CaptchaCode: req.CaptchaCode,
+	})
 	if err != nil {
-		if err == service.ErrInvalidCredentials {
-			Error(c, 2, "用户名或密码错误")
-			return
-		}
-		ServerError(c)
+		loginError(c, err)
 		return
 	}
 
-	tokenPair, err := h.authService.GenerateTokenPair(c.Request.Context(), user.ID)
+	tokenPair, err := h.authService.GenerateTokenPair(c.Request.Context(), user.ID, deviceInfo(c))
 	if err != nil {
 		ServerError(c)
 		return
@@ -123,6 +137,10 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err == nil && req.RefreshToken != "" {
 		_ = h.authService.RevokeRefreshToken(c.Request.Context(), req.RefreshToken)
 	}
+	// 携带 access token 时一并吊销，使其立即失效
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && token != "" {
+		_ = h.authService.RevokeAccessToken(c.Request.Context(), token)
+	}
 
 	SuccessWithMsg(c, "退出登陆成功")
 }
@@ -134,8 +152,16 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	tokenPair, err := h.authService.RefreshTokenPair(c.Request.Context(), req.RefreshToken)
+	tokenPair, err := h.authService.RefreshTokenPair(c.Request.Context(), req.RefreshToken, deviceInfo(c))
 	if err != nil {
+		if err == service.ErrUserDisabled {
+			Error(c, apperrors.CodeForbidden, "账号已被禁用")
+			return
+		}
+		if err == service.ErrTokenReused {
+			Error(c, apperrors.CodeUnauthorized, "登录状态已失效，请重新登录")
+			return
+		}
 		Error(c, 2, "Token刷新失败")
 		return
 	}
@@ -147,6 +173,62 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+// ListSessions 列出当前用户已登录的设备
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	sessionID, _ := middleware.GetSessionID(c)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessList(c, sessions)
+}
+
+// RevokeSession 结束指定会话（下线指定设备）
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的会话id")
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "已下线该设备")
+}
+
+// RevokeAllSessions 退出所有设备，keep_current=true 时保留当前会话
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var keep primitive.ObjectID
+	if c.Query("keep_current") == "true" {
+		sessionID, ok := middleware.GetSessionID(c)
+		if !ok {
+			BadRequest(c, "无法识别当前会话，请重新登录后再试")
+			return
+		}
+		keep = sessionID
+	}
+
+	if err := h.authService.RevokeAllSessions(c.Request.Context(), userID, keep); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "已退出所有设备")
+}
+
+// JWKS 公开 access token 的验证公钥（RFC 7517 格式，不使用统一响应结构）
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -159,18 +241,31 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	if !userRegex.MatchString(req.UserName) || !pwdRegex.MatchString(req.Password) {
-		Error(c, 2, "用户名或密码不符合规则")
+	if !userRegex.MatchString(req.UserName) {
+		Error(c, 2, "用户名不符合规则")
 		return
 	}
 
-	_, err := h.authService.Register(c.Request.Context(), req.UserName, req.Password)
+	_, err := h.authService.Register(c.Request.Context(), service.RegisterInput{
+		UserName: req.UserName,
+		Password: req.Password,
+		Email:    req.Email,
+		Language: requestLanguage(c),
+	})
 	if err != nil {
-		if err == service.ErrUserExists {
+		var appErr *apperrors.AppError
+		switch {
+		case err == service.ErrUserExists:
 			Error(c, 3, "用户名已存在")
-			return
+		case err == service.ErrEmailTaken:
+			Error(c, 3, err.Error())
+		case err == service.ErrEmailInvalid:
+			Error(c, 2, err.Error())
+		case errors.As(err, &appErr) && appErr.Code == apperrors.CodeInvalidParams:
+			Error(c, 2, appErr.Message)
+		default:
+			ServerError(c)
 		}
-		ServerError(c)
 		return
 	}
 
@@ -206,3 +301,53 @@ func (h *AuthHandler) CheckCaptcha(c *gin.Context) {
 
 	SuccessWithMsg(c, "验证成功")
 }
+
+// deviceInfo 提取会话列表展示用的客户端信息
+func deviceInfo(c *gin.Context) model.DeviceInfo {
+	return model.DeviceInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// requestLanguage 根据 Accept-Language 选择邮件语言
+func requestLanguage(c *gin.Context) string {
+	return service.NormalizeLanguage(c.GetHeader("Accept-Language"))
+}
+
+// loginError 返回登录失败原因，防爆破相关的错误在 data 中附带 captcha_required 和 retry_after（秒）
+func loginError(c *gin.Context, err error) {
+	var data gin.H
+	var retryAfter int
+	var loginErr *service.LoginError
+	if errors.As(err, &loginErr) {
+		retryAfter = int(math.Ceil(loginErr.RetryAfter.Seconds()))
+		data = gin.H{"captcha_required": loginErr.CaptchaRequired}
+		if retryAfter > 0 {
+			data["retry_after"] = retryAfter
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+	}
+
+	var code int
+	var msg string
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials):
+		code, msg = 2, "用户名或密码错误"
+	case errors.Is(err, service.ErrUserDisabled):
+		code, msg = apperrors.CodeForbidden, "账号已被禁用"
+	case errors.Is(err, service.ErrCaptchaRequired):
+		code, msg = apperrors.CodeCaptchaRequired, "登录失败次数较多，请输入验证码"
+	case errors.Is(err, service.ErrCaptchaInvalid):
+		code, msg = apperrors.CodeCaptchaInvalid, "验证码错误"
+	case errors.Is(err, service.ErrLoginTooFrequent):
+		code, msg = apperrors.CodeTooManyAttempts, fmt.Sprintf("登录尝试过于频繁，请 %d 秒后再试", retryAfter)
+	case errors.Is(err, service.ErrAccountLocked):
+		code, msg = apperrors.CodeAccountLocked, fmt.Sprintf("登录失败次数过多，请 %d 分钟后再试", int(math.Ceil(float64(retryAfter)/60)))
+	default:
+		ServerError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Code: code, Msg: msg, Data: data})
+}
@@ -0,0 +1,462 @@
+package handler
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/service"
+	"backend/pkg/jwtkeys"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockAuthService 是 AuthServiceInterface 的 mock 实现
+type MockAuthService struct {
+	LoginFunc             func(ctx context.Context, input service.LoginInput) (*model.User, error)
+	GenerateTokenPairFunc func(ctx context.Context, userID primitive.ObjectID, device model.DeviceInfo) (*model.TokenPair, error)
+	RefreshTokenPairFunc  func(ctx context.Context, refreshTokenStr string, device model.DeviceInfo) (*model.TokenPair, error)
+	ListSessionsFunc      func(ctx context.Context, userID, currentSessionID primitive.ObjectID) ([]model.Session, error)
+	RevokeSessionFunc     func(ctx context.Context, userID, sessionID primitive.ObjectID) error
+	RevokeAllSessionsFunc func(ctx context.Context, userID, keepSessionID primitive.ObjectID) error
+	RevokeAccessTokenFunc func(ctx context.Context, accessToken string) error
+	RegisterFunc          func(ctx context.Context, input service.RegisterInput) (*model.User, error)
+}
+
+func (m *MockAuthService) Login(ctx context.Context, input service.LoginInput) (*model.User, error) {
+	if m.LoginFunc != nil {
+		return m.LoginFunc(ctx, input)
+	}
+	return nil, service.ErrInvalidCredentials
+}
+
+func (m *MockAuthService) Register(ctx context.Context, input service.RegisterInput) (*model.User, error) {
+	if m.RegisterFunc != nil {
+		return m.RegisterFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *MockAuthService) GenerateTokenPair(ctx context.Context, userID primitive.ObjectID, device model.DeviceInfo) (*model.TokenPair, error) {
+	if m.GenerateTokenPairFunc != nil {
+		return m.GenerateTokenPairFunc(ctx, userID, device)
+	}
+	return &model.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3600}, nil
+}
+
+func (m *MockAuthService) ValidateAccessToken(tokenString string) (*service.Claims, error) {
+	return nil, service.ErrInvalidToken
+}
+
+func (m *MockAuthService) RefreshTokenPair(ctx context.Context, refreshTokenStr string, device model.DeviceInfo) (*model.TokenPair, error) {
+	if m.RefreshTokenPairFunc != nil {
+		return m.RefreshTokenPairFunc(ctx, refreshTokenStr, device)
+	}
+	return nil, service.ErrInvalidToken
+}
+
+func (m *MockAuthService) ListSessions(ctx context.Context, userID, currentSessionID primitive.ObjectID) ([]model.Session, error) {
+	if m.ListSessionsFunc != nil {
+		return m.ListSessionsFunc(ctx, userID, currentSessionID)
+	}
+	return []model.Session{}, nil
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID primitive.ObjectID) error {
+	if m.RevokeSessionFunc != nil {
+		return m.RevokeSessionFunc(ctx, userID, sessionID)
+	}
+	return nil
+}
+
+func (m *MockAuthService) RevokeAllSessions(ctx context.Context, userID, keepSessionID primitive.ObjectID) error {
+	if m.RevokeAllSessionsFunc != nil {
+		return m.RevokeAllSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return nil
+}
+
+func (m *MockAuthService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	return nil
+}
+
+func (m *MockAuthService) RevokeAccessToken(ctx context.Context, accessToken string) error {
+	if m.RevokeAccessTokenFunc != nil {
+		return m.RevokeAccessTokenFunc(ctx, accessToken)
+	}
+	return nil
+}
+
+func (m *MockAuthService) IsAccessTokenRevoked(jti string) bool {
+	return false
+}
+
+func (m *MockAuthService) JWKS() jwtkeys.JWKS {
+	return jwtkeys.JWKS{Keys: []jwtkeys.JWK{}}
+}
+
+func (m *MockAuthService) CheckUserStatus(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	return model.RoleUser, nil
+}
+
+// MockVisitorService 是 VisitorServiceInterface 的 mock 实现
+type MockVisitorService struct{}
+
+func (m *MockVisitorService) GetListWithUser(ctx context.Context, limit int64) ([]model.VisitorWithUser, error) {
+	return nil, nil
+}
+
+func (m *MockVisitorService) RecordVisit(ctx context.Context, userID primitive.ObjectID) error {
+	return nil
+}
+
+func doLogin(t *testing.T, h *AuthHandler, body string) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.RemoteAddr = "192.0.2.10:5000"
+
+	h.Login(c)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	return w, resp
+}
+
+func TestAuthHandler_Login_PassesGuardInput(t *testing.T) {
+	var received service.LoginInput
+	mockService := &MockAuthService{
+		LoginFunc: func(ctx context.Context, input service.LoginInput) (*model.User, error) {
+			received = input
+			return &model.User{ID: primitive.NewObjectID(), UserName: input.UserName}, nil
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	_, resp := doLogin(t, h, `{"user_name":"alice","password":"secret1","captcha_id":"cid","captcha_code":"ab12"}`)
+
+	if resp.Code != apperrors.CodeSuccess {
+		t.Fatalf("期望登录成功, 实际 code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	if received.ClientIP != "192.0.2.10" {
+		t.Errorf("期望传入客户端 IP, 实际 %q", received.ClientIP)
+	}
+	if received.CaptchaID != "cid" || received.CaptchaCode != "ab12" {
+		t.Errorf("期望传入验证码, 实际 %+v", received)
+	}
+}
+
+func TestAuthHandler_Login_GuardErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantRetry  string
+		wantFields map[string]interface{}
+	}{
+		{
+			name:       "密码错误且需要验证码",
+			err:        &service.LoginError{Err: service.ErrInvalidCredentials, CaptchaRequired: true},
+			wantCode:   2,
+			wantFields: map[string]interface{}{"captcha_required": true},
+		},
+		{
+			name:       "缺少验证码",
+			err:        &service.LoginError{Err: service.ErrCaptchaRequired, CaptchaRequired: true},
+			wantCode:   apperrors.CodeCaptchaRequired,
+			wantFields: map[string]interface{}{"captcha_required": true},
+		},
+		{
+			name:     "验证码错误",
+			err:      &service.LoginError{Err: service.ErrCaptchaInvalid, CaptchaRequired: true},
+			wantCode: apperrors.CodeCaptchaInvalid,
+		},
+		{
+			name:       "递增等待",
+			err:        &service.LoginError{Err: service.ErrLoginTooFrequent, RetryAfter: 1500 * time.Millisecond, CaptchaRequired: true},
+			wantCode:   apperrors.CodeTooManyAttempts,
+			wantRetry:  "2",
+			wantFields: map[string]interface{}{"retry_after": float64(2)},
+		},
+		{
+			name:       "账号锁定",
+			err:        &service.LoginError{Err: service.ErrAccountLocked, RetryAfter: 15 * time.Minute, CaptchaRequired: true},
+			wantCode:   apperrors.CodeAccountLocked,
+			wantRetry:  "900",
+			wantFields: map[string]interface{}{"retry_after": float64(900)},
+		},
+		{
+			name:     "账号禁用",
+			err:      service.ErrUserDisabled,
+			wantCode: apperrors.CodeForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockAuthService{
+				LoginFunc: func(ctx context.Context, input service.LoginInput) (*model.User, error) {
+					return nil, tc.err
+				},
+			}
+			h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+			w, resp := doLogin(t, h, `{"user_name":"alice","password":"secret1"}`)
+
+			if resp.Code != tc.wantCode {
+				t.Errorf("期望 code=%d, 实际 %d (%s)", tc.wantCode, resp.Code, resp.Msg)
+			}
+			if got := w.Header().Get("Retry-After"); got != tc.wantRetry {
+				t.Errorf("期望 Retry-After=%q, 实际 %q", tc.wantRetry, got)
+			}
+			data, _ := resp.Data.(map[string]interface{})
+			for key, want := range tc.wantFields {
+				if data[key] != want {
+					t.Errorf("期望 data.%s=%v, 实际 %v", key, want, data[key])
+				}
+			}
+		})
+	}
+}
+
+func TestAuthHandler_RefreshToken_Reused(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthService{
+		RefreshTokenPairFunc: func(ctx context.Context, refreshTokenStr string, device model.DeviceInfo) (*model.TokenPair, error) {
+			return nil, service.ErrTokenReused
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", strings.NewReader(`{"refresh_token":"stolen"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.RefreshToken(c)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if resp.Code != apperrors.CodeUnauthorized {
+		t.Errorf("重用令牌期望 code=%d, 实际 %d", apperrors.CodeUnauthorized, resp.Code)
+	}
+}
+
+func TestAuthHandler_ListSessions_PassesCurrentSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID()
+	sessionID := primitive.NewObjectID()
+	var receivedCurrent primitive.ObjectID
+	mockService := &MockAuthService{
+		ListSessionsFunc: func(ctx context.Context, uID, current primitive.ObjectID) ([]model.Session, error) {
+			receivedCurrent = current
+			return []model.Session{{ID: current, Current: true}}, nil
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, userID)
+	c.Set(middleware.ContextSessionID, sessionID)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+
+	h.ListSessions(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if receivedCurrent != sessionID {
+		t.Errorf("期望传入当前会话 %s, 实际 %s", sessionID.Hex(), receivedCurrent.Hex())
+	}
+	if !strings.Contains(w.Body.String(), `"current":true`) {
+		t.Errorf("响应应标记当前会话: %s", w.Body.String())
+	}
+}
+
+func TestAuthHandler_RevokeSession_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthService{
+		RevokeSessionFunc: func(ctx context.Context, userID, sessionID primitive.ObjectID) error {
+			return apperrors.NotFoundError("会话")
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Params = gin.Params{{Key: "id", Value: primitive.NewObjectID().Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/auth/sessions/x", nil)
+
+	h.RevokeSession(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("期望状态码 404, 实际 %d", w.Code)
+	}
+}
+
+func TestAuthHandler_RevokeAllSessions_KeepCurrent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sessionID := primitive.NewObjectID()
+	var receivedKeep primitive.ObjectID
+	mockService := &MockAuthService{
+		RevokeAllSessionsFunc: func(ctx context.Context, userID, keep primitive.ObjectID) error {
+			receivedKeep = keep
+			return nil
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Set(middleware.ContextSessionID, sessionID)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/auth/sessions?keep_current=true", nil)
+
+	h.RevokeAllSessions(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if receivedKeep != sessionID {
+		t.Errorf("期望保留当前会话 %s, 实际 %s", sessionID.Hex(), receivedKeep.Hex())
+	}
+}
+
+func TestAuthHandler_Logout_RevokesAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var revoked string
+	mockService := &MockAuthService{
+		RevokeAccessTokenFunc: func(ctx context.Context, accessToken string) error {
+			revoked = accessToken
+			return nil
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, nil, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/logout", strings.NewReader(`{"refresh_token":"r"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("Authorization", "Bearer access-token")
+
+	h.Logout(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if revoked != "access-token" {
+		t.Errorf("期望吊销请求携带的 access token, 实际 %q", revoked)
+	}
+}
+
+// MockCaptchaService 验证码固定校验通过
+type MockCaptchaService struct{}
+
+func (m *MockCaptchaService) Generate() (*service.CaptchaResult, error) { return nil, nil }
+func (m *MockCaptchaService) Verify(id, answer string) bool             { return true }
+func (m *MockCaptchaService) Get(id string) string                      { return "" }
+
+func TestAuthHandler_Register_WeakPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthService{
+		RegisterFunc: func(ctx context.Context, input service.RegisterInput) (*model.User, error) {
+			return nil, apperrors.InvalidParamsError("密码过于常见，请更换")
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, &MockCaptchaService{}, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/register",
+		strings.NewReader(`{"user_name":"tester","password":"password","captcha_code":"1234","captcha_id":"id"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Register(c)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if resp.Code != 2 || resp.Msg != "密码过于常见，请更换" {
+		t.Errorf("期望返回密码策略错误, 实际 code=%d msg=%q", resp.Code, resp.Msg)
+	}
+}
+
+func TestAuthHandler_Register_PassesEmailAndLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received service.RegisterInput
+	mockService := &MockAuthService{
+		RegisterFunc: func(ctx context.Context, input service.RegisterInput) (*model.User, error) {
+			received = input
+			return &model.User{}, nil
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, &MockCaptchaService{}, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/register",
+		strings.NewReader(`{"user_name":"tester","password":"s3cret-Pass","email":"Tester@Example.com","captcha_code":"1234","captcha_id":"id"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	h.Register(c)
+
+	if received.Email != "Tester@Example.com" {
+		t.Errorf("期望传入邮箱, 实际 %q", received.Email)
+	}
+	if received.Language != service.LanguageEN {
+		t.Errorf("期望邮件语言 en, 实际 %q", received.Language)
+	}
+}
+
+func TestAuthHandler_Register_EmailTaken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthService{
+		RegisterFunc: func(ctx context.Context, input service.RegisterInput) (*model.User, error) {
+			return nil, service.ErrEmailTaken
+		},
+	}
+	h := NewAuthHandlerWithServices(mockService, &MockCaptchaService{}, &MockVisitorService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/register",
+		strings.NewReader(`{"user_name":"tester","password":"s3cret-Pass","email":"a@example.com","captcha_code":"1234","captcha_id":"id"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Register(c)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if resp.Code != 3 {
+		t.Errorf("期望 code=3, 实际 %d (%s)", resp.Code, resp.Msg)
+	}
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type CategoryHandler struct {
+	service service.CategoryServiceInterface
+}
+
+type (
+	CreateCategoryRequest struct {
+		Name        string `json:"name" binding:"required,max=30"`
+		Slug        string `json:"slug" binding:"omitempty,max=80"`
+		Description string `json:"description" binding:"max=200"`
+		ParentID    string `json:"parent_id"`
+		Sort        int    `json:"sort"`
+	}
+
+	// UpdateCategoryRequest ParentID 为空字符串表示移动到顶层
+	UpdateCategoryRequest struct {
+		Name        *string `json:"name" binding:"omitempty,max=30"`
+		Slug        *string `json:"slug" binding:"omitempty,max=80"`
+		Description *string `json:"description" binding:"omitempty,max=200"`
+		ParentID    *string `json:"parent_id"`
+		Sort        *int    `json:"sort"`
+	}
+)
+
+// ========== 构造函数 ==========
+
+func NewCategoryHandler() *CategoryHandler {
+	return &CategoryHandler{
+		service: service.NewCategoryService(),
+	}
+}
+
+// NewCategoryHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewCategoryHandlerWithService(svc service.CategoryServiceInterface) *CategoryHandler {
+	return &CategoryHandler{
+		service: svc,
+	}
+}
+
+// ========== RESTful API ==========
+
+// Tree GET /api/v1/categories
+func (h *CategoryHandler) Tree(c *gin.Context) {
+	tree, err := h.service.Tree(c.Request.Context())
+	if err != nil {
+		ServerError(c)
+		return
+	}
+	SuccessList(c, tree)
+}
+
+// Get GET /api/v1/categories/:slug
+func (h *CategoryHandler) Get(c *gin.Context) {
+	category, err := h.service.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "查询成功", category)
+}
+
+// ========== 管理接口 ==========
+
+// Create POST /api/v1/categories
+func (h *CategoryHandler) Create(c *gin.Context) {
+	var req CreateCategoryRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	category := &model.Category{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		Sort:        req.Sort,
+	}
+	if req.ParentID != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			BadRequest(c, "无效的上级分类id")
+			return
+		}
+		category.ParentID = &parentID
+	}
+
+	created, err := h.service.Create(c.Request.Context(), category)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Created(c, "创建成功", created)
+}
+
+// Update PATCH /api/v1/categories/:id
+func (h *CategoryHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的分类id")
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	update := &model.CategoryUpdate{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		Sort:        req.Sort,
+	}
+	if req.ParentID != nil {
+		parentID := primitive.NilObjectID
+		if *req.ParentID != "" {
+			if parentID, err = primitive.ObjectIDFromHex(*req.ParentID); err != nil {
+				BadRequest(c, "无效的上级分类id")
+				return
+			}
+		}
+		update.ParentID = &parentID
+	}
+
+	category, err := h.service.Update(c.Request.Context(), id, update)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", category)
+}
+
+// Delete DELETE /api/v1/categories/:id
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的分类id")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
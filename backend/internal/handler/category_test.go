@@ -0,0 +1,171 @@
+package handler
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCategoryService 是 CategoryServiceInterface 的 mock 实现
+type MockCategoryService struct {
+	TreeFunc      func(ctx context.Context) ([]model.CategoryNode, error)
+	GetBySlugFunc func(ctx context.Context, slug string) (*model.Category, error)
+	CreateFunc    func(ctx context.Context, category *model.Category) (*model.Category, error)
+	UpdateFunc    func(ctx context.Context, id primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error)
+	DeleteFunc    func(ctx context.Context, id primitive.ObjectID) error
+}
+
+func (m *MockCategoryService) Tree(ctx context.Context) ([]model.CategoryNode, error) {
+	if m.TreeFunc != nil {
+		return m.TreeFunc(ctx)
+	}
+	return []model.CategoryNode{}, nil
+}
+
+func (m *MockCategoryService) GetBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	if m.GetBySlugFunc != nil {
+		return m.GetBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryService) Create(ctx context.Context, category *model.Category) (*model.Category, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, category)
+	}
+	return category, nil
+}
+
+func (m *MockCategoryService) Update(ctx context.Context, id primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, update)
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func TestCategoryHandler_Tree(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rootID := primitive.NewObjectID()
+	childID := primitive.NewObjectID()
+	h := NewCategoryHandlerWithService(&MockCategoryService{
+		TreeFunc: func(ctx context.Context) ([]model.CategoryNode, error) {
+			return []model.CategoryNode{{
+				Category: model.Category{ID: rootID, Name: "后端", Slug: "backend"},
+				Count:    3,
+				Children: []model.CategoryNode{{
+					Category: model.Category{ID: childID, Name: "Go", Slug: "go", ParentID: &rootID},
+					Count:    2,
+					Children: []model.CategoryNode{},
+				}},
+			}}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+
+	h.Tree(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	var resp struct {
+		Data struct {
+			List []struct {
+				Slug     string `json:"slug"`
+				Count    int64  `json:"count"`
+				Children []struct {
+					Slug     string `json:"slug"`
+					ParentID string `json:"parent_id"`
+					Count    int64  `json:"count"`
+				} `json:"children"`
+			} `json:"list"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if len(resp.Data.List) != 1 || resp.Data.List[0].Slug != "backend" || resp.Data.List[0].Count != 3 {
+		t.Fatalf("顶层分类不正确: %s", w.Body.String())
+	}
+	children := resp.Data.List[0].Children
+	if len(children) != 1 || children[0].Slug != "go" || children[0].ParentID != rootID.Hex() || children[0].Count != 2 {
+		t.Errorf("子分类不正确: %s", w.Body.String())
+	}
+}
+
+func TestCategoryHandler_Update_ParentCycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := primitive.NewObjectID()
+	childID := primitive.NewObjectID()
+	h := NewCategoryHandlerWithService(&MockCategoryService{
+		UpdateFunc: func(ctx context.Context, cID primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error) {
+			if update.ParentID == nil || *update.ParentID != childID {
+				t.Errorf("期望上级分类 %s, 实际 %v", childID.Hex(), update.ParentID)
+			}
+			return nil, apperrors.InvalidParamsError("不能将分类移动到自身或其子分类下")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: id.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/api/v1/categories/"+id.Hex(),
+		strings.NewReader(`{"parent_id":"`+childID.Hex()+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Update(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("期望状态码 400, 实际 %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "不能将分类移动到自身或其子分类下") {
+		t.Errorf("响应应包含循环错误: %s", w.Body.String())
+	}
+}
+
+func TestCategoryHandler_Update_MoveToTop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := primitive.NewObjectID()
+	var received *primitive.ObjectID
+	h := NewCategoryHandlerWithService(&MockCategoryService{
+		UpdateFunc: func(ctx context.Context, cID primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error) {
+			received = update.ParentID
+			return &model.Category{ID: cID}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: id.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/api/v1/categories/"+id.Hex(), strings.NewReader(`{"parent_id":""}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Update(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if received == nil || !received.IsZero() {
+		t.Errorf("空的 parent_id 应转换为 NilObjectID, 实际 %v", received)
+	}
+}
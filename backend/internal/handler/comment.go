@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type CommentHandler struct {
+	service service.CommentServiceInterface
+}
+
+type (
+	CreateCommentRequest struct {
+		Content  string `json:"content" binding:"required,max=1000"`
+		ParentID string `json:"parent_id"`
+	}
+
+	UpdateCommentRequest struct {
+		Content string `json:"content" binding:"required,max=1000"`
+	}
+)
+
+// ========== 构造函数 ==========
+
+func NewCommentHandler() *CommentHandler {
+	return &CommentHandler{
+		service: service.NewCommentService(),
+	}
+}
+
+// NewCommentHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewCommentHandlerWithService(svc service.CommentServiceInterface) *CommentHandler {
+	return &CommentHandler{
+		service: svc,
+	}
+}
+
+// ========== RESTful API ==========
+
+// List GET /api/v1/articles/:id/comments?skip=0&limit=10
+func (h *CommentHandler) List(c *gin.Context) {
+	articleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	skip := int64(0)
+	limit := int64(10)
+	if s, err := strconv.ParseInt(c.Query("skip"), 10, 64); err == nil && s >= 0 {
+		skip = s
+	}
+	if l, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && l > 0 && l <= 50 {
+		limit = l
+	}
+
+	page, err := h.service.List(c.Request.Context(), articleID, skip, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "查询成功", page)
+}
+
+// Create POST /api/v1/articles/:id/comments
+func (h *CommentHandler) Create(c *gin.Context) {
+	articleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return
+	}
+
+	var req CreateCommentRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+
+	var parentID *primitive.ObjectID
+	if req.ParentID != "" {
+		id, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			BadRequest(c, "无效的评论id")
+			return
+		}
+		parentID = &id
+	}
+
+	comment, err := h.service.Create(c.Request.Context(), articleID, userID, parentID, req.Content)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Created(c, "评论成功！", comment)
+}
+
+// Update PATCH /api/v1/articles/:id/comments/:commentId
+func (h *CommentHandler) Update(c *gin.Context) {
+	articleID, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCommentRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	comment, err := h.service.Update(c.Request.Context(), articleID, commentID, userID, role, req.Content)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", comment)
+}
+
+// Delete DELETE /api/v1/articles/:id/comments/:commentId
+func (h *CommentHandler) Delete(c *gin.Context) {
+	articleID, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetRole(c)
+	if err := h.service.Delete(c.Request.Context(), articleID, commentID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
+
+func parseCommentParams(c *gin.Context) (articleID, commentID primitive.ObjectID, ok bool) {
+	articleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的文章id")
+		return articleID, commentID, false
+	}
+	commentID, err = primitive.ObjectIDFromHex(c.Param("commentId"))
+	if err != nil {
+		BadRequest(c, "无效的评论id")
+		return articleID, commentID, false
+	}
+	return articleID, commentID, true
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCommentService 是 CommentServiceInterface 的 mock 实现
+type MockCommentService struct {
+	ListFunc   func(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) (*model.CommentPage, error)
+	CreateFunc func(ctx context.Context, articleID, userID primitive.ObjectID, parentID *primitive.ObjectID, content string) (*model.Comment, error)
+	UpdateFunc func(ctx context.Context, articleID, id, userID primitive.ObjectID, role, content string) (*model.Comment, error)
+	DeleteFunc func(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) error
+}
+
+func (m *MockCommentService) List(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) (*model.CommentPage, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, articleID, skip, limit)
+	}
+	return &model.CommentPage{List: []model.CommentWithUser{}}, nil
+}
+
+func (m *MockCommentService) Create(ctx context.Context, articleID, userID primitive.ObjectID, parentID *primitive.ObjectID, content string) (*model.Comment, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, articleID, userID, parentID, content)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentService) Update(ctx context.Context, articleID, id, userID primitive.ObjectID, role, content string) (*model.Comment, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, articleID, id, userID, role, content)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentService) Delete(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, articleID, id, userID, role)
+	}
+	return nil
+}
+
+func TestCommentHandler_Create_Reply(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	articleID := primitive.NewObjectID()
+	parentID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+
+	var receivedParent *primitive.ObjectID
+	mockService := &MockCommentService{
+		CreateFunc: func(ctx context.Context, aID, uID primitive.ObjectID, pID *primitive.ObjectID, content string) (*model.Comment, error) {
+			receivedParent = pID
+			return &model.Comment{ID: primitive.NewObjectID(), ArticleID: aID, UserID: uID, ParentID: pID, Content: content}, nil
+		},
+	}
+
+	handler := NewCommentHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, userID)
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	body := `{"content":"说得好","parent_id":"` + parentID.Hex() + `"}`
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/articles/"+articleID.Hex()+"/comments", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Create(c)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusCreated, w.Code)
+	}
+	if receivedParent == nil || *receivedParent != parentID {
+		t.Errorf("被回复的评论 ID 不正确: %v", receivedParent)
+	}
+}
+
+func TestCommentHandler_Create_InvalidParent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewCommentHandlerWithService(&MockCommentService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	articleID := primitive.NewObjectID()
+	c.Params = gin.Params{{Key: "id", Value: articleID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/articles/"+articleID.Hex()+"/comments", strings.NewReader(`{"content":"hi","parent_id":"bad"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Create(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCommentHandler_Delete_Forbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var receivedRole string
+	mockService := &MockCommentService{
+		DeleteFunc: func(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) error {
+			receivedRole = role
+			return apperrors.ForbiddenError("只能修改或删除自己的评论")
+		},
+	}
+
+	handler := NewCommentHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Set(middleware.ContextRole, model.RoleUser)
+	c.Params = gin.Params{
+		{Key: "id", Value: primitive.NewObjectID().Hex()},
+		{Key: "commentId", Value: primitive.NewObjectID().Hex()},
+	}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/", nil)
+
+	handler.Delete(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusForbidden, w.Code)
+	}
+	if receivedRole != model.RoleUser {
+		t.Errorf("角色未传递给 service: %q", receivedRole)
+	}
+}
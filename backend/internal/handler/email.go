@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ========== 类型定义 ==========
+
+type EmailHandler struct {
+	service service.EmailServiceInterface
+}
+
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ========== 构造函数 ==========
+
+func NewEmailHandler() *EmailHandler {
+	return &EmailHandler{
+		service: service.NewEmailService(),
+	}
+}
+
+// NewEmailHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewEmailHandlerWithService(svc service.EmailServiceInterface) *EmailHandler {
+	return &EmailHandler{
+		service: svc,
+	}
+}
+
+// ========== Handler 方法 ==========
+
+// Change PUT /api/v1/auth/email
+func (h *EmailHandler) Change(c *gin.Context) {
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "请输入邮箱")
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	if err := h.service.ChangeEmail(c.Request.Context(), userID, req.Email); err != nil {
+		emailError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "验证邮件已发送，请查收")
+}
+
+// ResendVerification POST /api/v1/auth/email/verification
+func (h *EmailHandler) ResendVerification(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	if err := h.service.ResendVerification(c.Request.Context(), userID); err != nil {
+		emailError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "验证邮件已发送，请查收")
+}
+
+// Verify POST /api/v1/auth/email/verify
+func (h *EmailHandler) Verify(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "缺少验证令牌")
+		return
+	}
+
+	if err := h.service.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		emailError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "邮箱验证成功")
+}
+
+// emailError 业务错误返回 400 或 409，其余按 AppError 处理
+func emailError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmailTaken):
+		Conflict(c, err.Error())
+	case errors.Is(err, service.ErrEmailInvalid),
+		errors.Is(err, service.ErrEmailNotSet),
+		errors.Is(err, service.ErrEmailAlreadyVerified),
+		errors.Is(err, service.ErrVerifyTokenInvalid):
+		BadRequest(c, err.Error())
+	default:
+		HandleError(c, err)
+	}
+}
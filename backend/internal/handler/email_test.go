@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockEmailService 是 EmailServiceInterface 的 mock 实现
+type MockEmailService struct {
+	ChangeEmailFunc        func(ctx context.Context, userID primitive.ObjectID, email string) error
+	ResendVerificationFunc func(ctx context.Context, userID primitive.ObjectID) error
+	VerifyEmailFunc        func(ctx context.Context, rawToken string) error
+}
+
+func (m *MockEmailService) ChangeEmail(ctx context.Context, userID primitive.ObjectID, email string) error {
+	if m.ChangeEmailFunc != nil {
+		return m.ChangeEmailFunc(ctx, userID, email)
+	}
+	return nil
+}
+
+func (m *MockEmailService) ResendVerification(ctx context.Context, userID primitive.ObjectID) error {
+	if m.ResendVerificationFunc != nil {
+		return m.ResendVerificationFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockEmailService) VerifyEmail(ctx context.Context, rawToken string) error {
+	if m.VerifyEmailFunc != nil {
+		return m.VerifyEmailFunc(ctx, rawToken)
+	}
+	return nil
+}
+
+func TestEmailHandler_Change(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"成功", nil, http.StatusOK},
+		{"格式错误", service.ErrEmailInvalid, http.StatusBadRequest},
+		{"已被占用", service.ErrEmailTaken, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := primitive.NewObjectID()
+			h := NewEmailHandlerWithService(&MockEmailService{
+				ChangeEmailFunc: func(ctx context.Context, uID primitive.ObjectID, email string) error {
+					if uID != userID || email != "a@example.com" {
+						t.Errorf("参数不正确: %s, %q", uID.Hex(), email)
+					}
+					return tt.err
+				},
+			})
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set(middleware.ContextUserID, userID)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/auth/email", strings.NewReader(`{"email":"a@example.com"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Change(c)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("期望状态码 %d, 实际 %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestEmailHandler_Verify_InvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewEmailHandlerWithService(&MockEmailService{
+		VerifyEmailFunc: func(ctx context.Context, rawToken string) error {
+			return service.ErrVerifyTokenInvalid
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/email/verify", strings.NewReader(`{"token":"sel.ver"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Verify(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 400, 实际 %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "验证链接无效或已过期") {
+		t.Errorf("响应应说明令牌无效: %s", w.Body.String())
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	apperrors "backend/internal/errors"
 	"backend/internal/middleware"
+	"backend/internal/model"
 	"backend/internal/service"
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -21,9 +24,11 @@ type (
 		Content string `json:"content" binding:"required"`
 	}
 
+	// ReplyRequest 回复对象留空时默认回复留言作者
 	ReplyRequest struct {
-		Content     string `json:"content" binding:"required"`
-		ReplyToUser string `json:"reply_to_user" binding:"required"`
+		Content        string `json:"content" binding:"required"`
+		ReplyToUserID  string `json:"reply_to_user_id"`
+		ReplyToReplyID string `json:"reply_to_reply_id"`
 	}
 
 	// Legacy API
@@ -33,6 +38,14 @@ type (
 		ReplyToUser string `json:"reply_to_user" binding:"required"`
 	}
 
+	UpdateMessageRequest struct {
+		Content string `json:"content" binding:"required"`
+	}
+
+	ModerateRequest struct {
+		Status string `json:"status" binding:"required,oneof=pending approved rejected spam"`
+	}
+
 	GetListRequestLegacy struct {
 		Skip  int64 `json:"skip"`
 		Limit int64 `json:"limit"`
@@ -70,12 +83,14 @@ func (h *MessageHandler) Commit(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Create(c.Request.Context(), userID, req.Content); err != nil {
-		ServerError(c)
+	role, _ := middleware.GetRole(c)
+	msg, err := h.service.Create(c.Request.Context(), userID, role, req.Content)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
-	Created(c, "留言成功!", nil)
+	Created(c, messageCommitMsg(msg.Status, "留言成功!"), gin.H{"_id": msg.ID, "status": msg.Status})
 }
 
 // ReplyCommit POST /api/v1/messages/:id/replies
@@ -104,12 +119,32 @@ func (h *MessageHandler) ReplyCommit(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddReply(c.Request.Context(), parentID, userID, req.Content, req.ReplyToUser); err != nil {
-		ServerError(c)
+	var target model.ReplyTarget
+	if req.ReplyToUserID != "" {
+		id, err := primitive.ObjectIDFromHex(req.ReplyToUserID)
+		if err != nil {
+			BadRequest(c, "无效的回复对象ID")
+			return
+		}
+		target.UserID = &id
+	}
+	if req.ReplyToReplyID != "" {
+		id, err := primitive.ObjectIDFromHex(req.ReplyToReplyID)
+		if err != nil {
+			BadRequest(c, "无效的回复ID")
+			return
+		}
+		target.ReplyID = &id
+	}
+
+	role, _ := middleware.GetRole(c)
+	reply, err := h.service.AddReply(c.Request.Context(), parentID, userID, role, req.Content, target)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
-	Created(c, "评论成功！", nil)
+	Created(c, messageCommitMsg(reply.Status, "评论成功！"), gin.H{"_id": reply.ID, "status": reply.Status})
 }
 
 // GetList GET /api/v1/messages?skip=0&limit=10
@@ -133,6 +168,174 @@ func (h *MessageHandler) GetList(c *gin.Context) {
 	SuccessList(c, messages)
 }
 
+// Update PATCH /api/v1/messages/:id
+func (h *MessageHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的留言ID")
+		return
+	}
+
+	var req UpdateMessageRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+	role, _ := middleware.GetRole(c)
+
+	msg, err := h.service.Update(c.Request.Context(), id, userID, role, req.Content)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, messageCommitMsg(msg.Status, "修改成功"), msg)
+}
+
+// Delete DELETE /api/v1/messages/:id
+func (h *MessageHandler) Delete(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的留言ID")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+	role, _ := middleware.GetRole(c)
+
+	if err := h.service.Delete(c.Request.Context(), id, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
+
+// UpdateReply PATCH /api/v1/messages/:id/replies/:replyId
+func (h *MessageHandler) UpdateReply(c *gin.Context) {
+	id, replyID, ok := parseReplyParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMessageRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+	role, _ := middleware.GetRole(c)
+
+	reply, err := h.service.UpdateReply(c.Request.Context(), id, replyID, userID, role, req.Content)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, messageCommitMsg(reply.Status, "修改成功"), reply)
+}
+
+// DeleteReply DELETE /api/v1/messages/:id/replies/:replyId
+func (h *MessageHandler) DeleteReply(c *gin.Context) {
+	id, replyID, ok := parseReplyParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+	role, _ := middleware.GetRole(c)
+
+	if err := h.service.DeleteReply(c.Request.Context(), id, replyID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
+
+// ModerationQueue GET /api/v1/moderation/messages?status=pending&skip=0&limit=20
+func (h *MessageHandler) ModerationQueue(c *gin.Context) {
+	skip := int64(0)
+	limit := int64(20)
+	if s, err := strconv.ParseInt(c.Query("skip"), 10, 64); err == nil && s >= 0 {
+		skip = s
+	}
+	if l, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	page, err := h.service.ModerationQueue(c.Request.Context(), c.Query("status"), skip, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "查询成功", page)
+}
+
+// Moderate PATCH /api/v1/moderation/messages/:id
+func (h *MessageHandler) Moderate(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的留言ID")
+		return
+	}
+
+	var req ModerateRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	moderatorID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+
+	if err := h.service.Moderate(c.Request.Context(), id, moderatorID, req.Status); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "审核状态已更新")
+}
+
+// ModerateReply PATCH /api/v1/moderation/messages/:id/replies/:replyId
+func (h *MessageHandler) ModerateReply(c *gin.Context) {
+	id, replyID, ok := parseReplyParams(c)
+	if !ok {
+		return
+	}
+
+	var req ModerateRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	moderatorID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+
+	if err := h.service.ModerateReply(c.Request.Context(), id, replyID, moderatorID, req.Status); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "审核状态已更新")
+}
+
 // ========== Legacy API (旧版兼容) ==========
 
 // CommitLegacy POST /message/commit (旧版)
@@ -149,12 +352,14 @@ func (h *MessageHandler) CommitLegacy(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Create(c.Request.Context(), userID, req.Content); err != nil {
-		ServerError(c)
+	role, _ := middleware.GetRole(c)
+	msg, err := h.service.Create(c.Request.Context(), userID, role, req.Content)
+	if err != nil {
+		legacyMessageError(c, err)
 		return
 	}
 
-	SuccessWithMsg(c, "留言成功!")
+	SuccessWithMsg(c, messageCommitMsg(msg.Status, "留言成功!"))
 }
 
 // ReplyCommitLegacy POST /message/reply_commit (旧版)
@@ -183,12 +388,14 @@ func (h *MessageHandler) ReplyCommitLegacy(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddReply(c.Request.Context(), parentID, userID, req.Content, req.ReplyToUser); err != nil {
-		ServerError(c)
+	role, _ := middleware.GetRole(c)
+	reply, err := h.service.AddReply(c.Request.Context(), parentID, userID, role, req.Content, model.ReplyTarget{LegacyName: req.ReplyToUser})
+	if err != nil {
+		legacyMessageError(c, err)
 		return
 	}
 
-	SuccessWithMsg(c, "评论成功！")
+	SuccessWithMsg(c, messageCommitMsg(reply.Status, "评论成功！"))
 }
 
 // GetListLegacy POST /message/getList (旧版)
@@ -208,3 +415,37 @@ func (h *MessageHandler) GetListLegacy(c *gin.Context) {
 
 	SuccessWithData(c, "请求成功", messages)
 }
+
+// ========== 辅助函数 ==========
+
+// legacyMessageError 旧版接口中参数类错误（如未通过垃圾内容过滤）以 code 1 返回提示
+func legacyMessageError(c *gin.Context, err error) {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) && appErr.Code == apperrors.CodeInvalidParams {
+		Error(c, 1, appErr.Message)
+		return
+	}
+	ServerError(c)
+}
+
+// messageCommitMsg 发言进入审核队列时提示用户
+func messageCommitMsg(status, approvedMsg string) string {
+	if status == model.MessageStatusPending {
+		return "提交成功，审核通过后将公开显示"
+	}
+	return approvedMsg
+}
+
+func parseReplyParams(c *gin.Context) (id, replyID primitive.ObjectID, ok bool) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的留言ID")
+		return id, replyID, false
+	}
+	replyID, err = primitive.ObjectIDFromHex(c.Param("replyId"))
+	if err != nil {
+		BadRequest(c, "无效的回复ID")
+		return id, replyID, false
+	}
+	return id, replyID, true
+}
@@ -0,0 +1,344 @@
+package handler
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockMessageService 是 MessageServiceInterface 的 mock 实现
+type MockMessageService struct {
+	CreateFunc          func(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error)
+	GetByIDFunc         func(ctx context.Context, id primitive.ObjectID) (*model.Message, error)
+	AddReplyFunc        func(ctx context.Context, parentID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error)
+	GetListWithUserFunc func(ctx context.Context, skip, limit int64) ([]model.MessageWithUser, error)
+	UpdateFunc          func(ctx context.Context, id, userID primitive.ObjectID, role, content string) (*model.Message, error)
+	DeleteFunc          func(ctx context.Context, id, userID primitive.ObjectID, role string) error
+	UpdateReplyFunc     func(ctx context.Context, id, replyID, userID primitive.ObjectID, role, content string) (*model.ReplyMessage, error)
+	DeleteReplyFunc     func(ctx context.Context, id, replyID, userID primitive.ObjectID, role string) error
+	ModerationQueueFunc func(ctx context.Context, status string, skip, limit int64) (*model.ModerationPage, error)
+	ModerateFunc        func(ctx context.Context, id, moderatorID primitive.ObjectID, status string) error
+	ModerateReplyFunc   func(ctx context.Context, id, replyID, moderatorID primitive.ObjectID, status string) error
+}
+
+func (m *MockMessageService) Create(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, role, content)
+	}
+	return &model.Message{ID: primitive.NewObjectID(), UserID: userID, Content: content, Status: model.MessageStatusApproved}, nil
+}
+
+func (m *MockMessageService) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Message, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return &model.Message{ID: id}, nil
+}
+
+func (m *MockMessageService) AddReply(ctx context.Context, parentID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error) {
+	if m.AddReplyFunc != nil {
+		return m.AddReplyFunc(ctx, parentID, userID, role, content, target)
+	}
+	return &model.ReplyMessage{ID: primitive.NewObjectID(), UserID: userID, Content: content, Status: model.MessageStatusApproved}, nil
+}
+
+func (m *MockMessageService) GetListWithUser(ctx context.Context, skip, limit int64) ([]model.MessageWithUser, error) {
+	if m.GetListWithUserFunc != nil {
+		return m.GetListWithUserFunc(ctx, skip, limit)
+	}
+	return []model.MessageWithUser{}, nil
+}
+
+func (m *MockMessageService) Update(ctx context.Context, id, userID primitive.ObjectID, role, content string) (*model.Message, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, userID, role, content)
+	}
+	return &model.Message{ID: id, UserID: userID, Content: content, Status: model.MessageStatusApproved}, nil
+}
+
+func (m *MockMessageService) Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id, userID, role)
+	}
+	return nil
+}
+
+func (m *MockMessageService) UpdateReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role, content string) (*model.ReplyMessage, error) {
+	if m.UpdateReplyFunc != nil {
+		return m.UpdateReplyFunc(ctx, id, replyID, userID, role, content)
+	}
+	return &model.ReplyMessage{ID: replyID, UserID: userID, Content: content, Status: model.MessageStatusApproved}, nil
+}
+
+func (m *MockMessageService) DeleteReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role string) error {
+	if m.DeleteReplyFunc != nil {
+		return m.DeleteReplyFunc(ctx, id, replyID, userID, role)
+	}
+	return nil
+}
+
+func (m *MockMessageService) ModerationQueue(ctx context.Context, status string, skip, limit int64) (*model.ModerationPage, error) {
+	if m.ModerationQueueFunc != nil {
+		return m.ModerationQueueFunc(ctx, status, skip, limit)
+	}
+	return &model.ModerationPage{List: []model.ModerationItem{}}, nil
+}
+
+func (m *MockMessageService) Moderate(ctx context.Context, id, moderatorID primitive.ObjectID, status string) error {
+	if m.ModerateFunc != nil {
+		return m.ModerateFunc(ctx, id, moderatorID, status)
+	}
+	return nil
+}
+
+func (m *MockMessageService) ModerateReply(ctx context.Context, id, replyID, moderatorID primitive.ObjectID, status string) error {
+	if m.ModerateReplyFunc != nil {
+		return m.ModerateReplyFunc(ctx, id, replyID, moderatorID, status)
+	}
+	return nil
+}
+
+func TestMessageHandler_Commit_Pending(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var receivedRole string
+	mockService := &MockMessageService{
+		CreateFunc: func(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error) {
+			receivedRole = role
+			return &model.Message{ID: primitive.NewObjectID(), UserID: userID, Content: content, Status: model.MessageStatusPending}, nil
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Set(middleware.ContextRole, model.RoleUser)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/messages", strings.NewReader(`{"content":"第一次留言"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Commit(c)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusCreated, w.Code)
+	}
+	if receivedRole != model.RoleUser {
+		t.Errorf("角色未传递给 service: %q", receivedRole)
+	}
+
+	var resp struct {
+		Msg  string `json:"msg"`
+		Data struct {
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if resp.Data.Status != model.MessageStatusPending {
+		t.Errorf("期望状态 %q, 实际 %q", model.MessageStatusPending, resp.Data.Status)
+	}
+	if resp.Msg == "留言成功!" {
+		t.Error("待审核的留言应提示审核中")
+	}
+}
+
+func TestMessageHandler_Moderate_InvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	called := false
+	mockService := &MockMessageService{
+		ModerateFunc: func(ctx context.Context, id, moderatorID primitive.ObjectID, status string) error {
+			called = true
+			return nil
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Params = gin.Params{{Key: "id", Value: primitive.NewObjectID().Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"status":"deleted"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.Moderate(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusBadRequest, w.Code)
+	}
+	if called {
+		t.Error("非法状态不应调用 service")
+	}
+}
+
+func TestMessageHandler_UpdateReply_EditWindowExpired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	messageID := primitive.NewObjectID()
+	replyID := primitive.NewObjectID()
+
+	var receivedReply primitive.ObjectID
+	mockService := &MockMessageService{
+		UpdateReplyFunc: func(ctx context.Context, id, rID, userID primitive.ObjectID, role, content string) (*model.ReplyMessage, error) {
+			receivedReply = rID
+			return nil, apperrors.ForbiddenError("已超过可修改的时间")
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Set(middleware.ContextRole, model.RoleUser)
+	c.Params = gin.Params{
+		{Key: "id", Value: messageID.Hex()},
+		{Key: "replyId", Value: replyID.Hex()},
+	}
+	c.Request, _ = http.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"content":"改一下"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.UpdateReply(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusForbidden, w.Code)
+	}
+	if receivedReply != replyID {
+		t.Errorf("回复 ID 未传递给 service: %v", receivedReply)
+	}
+}
+
+func TestMessageHandler_DeleteReply_InvalidReplyID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewMessageHandlerWithService(&MockMessageService{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Params = gin.Params{
+		{Key: "id", Value: primitive.NewObjectID().Hex()},
+		{Key: "replyId", Value: "0"},
+	}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/", nil)
+
+	handler.DeleteReply(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 %d, 实际 %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestMessageHandler_ReplyCommit_TargetIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	parentID := primitive.NewObjectID()
+	targetUserID := primitive.NewObjectID()
+	targetReplyID := primitive.NewObjectID()
+
+	var received model.ReplyTarget
+	mockService := &MockMessageService{
+		AddReplyFunc: func(ctx context.Context, pID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error) {
+			received = target
+			return &model.ReplyMessage{ID: primitive.NewObjectID(), UserID: userID, Status: model.MessageStatusApproved}, nil
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Params = gin.Params{{Key: "id", Value: parentID.Hex()}}
+	body := `{"content":"同意","reply_to_user_id":"` + targetUserID.Hex() + `","reply_to_reply_id":"` + targetReplyID.Hex() + `"}`
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/messages/"+parentID.Hex()+"/replies", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.ReplyCommit(c)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusCreated, w.Code)
+	}
+	if received.UserID == nil || *received.UserID != targetUserID {
+		t.Errorf("回复对象用户 ID 不正确: %v", received.UserID)
+	}
+	if received.ReplyID == nil || *received.ReplyID != targetReplyID {
+		t.Errorf("回复对象回复 ID 不正确: %v", received.ReplyID)
+	}
+}
+
+func TestMessageHandler_ReplyCommitLegacy_KeepsUserName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var received model.ReplyTarget
+	mockService := &MockMessageService{
+		AddReplyFunc: func(ctx context.Context, pID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error) {
+			received = target
+			return &model.ReplyMessage{ID: primitive.NewObjectID(), UserID: userID, Status: model.MessageStatusApproved}, nil
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	body := `{"parent_id":"` + primitive.NewObjectID().Hex() + `","content":"好","reply_to_user":"张三"}`
+	c.Request, _ = http.NewRequest(http.MethodPost, "/message/reply_commit", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.ReplyCommitLegacy(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 %d, 实际 %d", http.StatusOK, w.Code)
+	}
+	if received.LegacyName != "张三" || received.UserID != nil {
+		t.Errorf("旧版回复对象不正确: %+v", received)
+	}
+}
+
+func TestMessageHandler_CommitLegacy_SpamRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockMessageService{
+		CreateFunc: func(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error) {
+			return nil, apperrors.InvalidParamsError("发布失败：发言过于频繁")
+		},
+	}
+
+	handler := NewMessageHandlerWithService(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, primitive.NewObjectID())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/message/commit", strings.NewReader(`{"content":"刷屏"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.CommitLegacy(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("旧版接口应返回 200, 实际 %d", w.Code)
+	}
+	var resp struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if resp.Code != 1 || resp.Msg != "发布失败：发言过于频繁" {
+		t.Errorf("响应不正确: %+v", resp)
+	}
+}
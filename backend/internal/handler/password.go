@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type PasswordHandler struct {
+	service service.PasswordServiceInterface
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ========== 构造函数 ==========
+
+func NewPasswordHandler() *PasswordHandler {
+	return &PasswordHandler{
+		service: service.NewPasswordService(),
+	}
+}
+
+// NewPasswordHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewPasswordHandlerWithService(svc service.PasswordServiceInterface) *PasswordHandler {
+	return &PasswordHandler{
+		service: svc,
+	}
+}
+
+// ========== Handler 方法 ==========
+
+// Change PUT /api/v1/auth/password
+// 修改密码后其他设备需要重新登录，当前会话保持有效
+func (h *PasswordHandler) Change(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "请输入原密码和新密码")
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	sessionID, _ := middleware.GetSessionID(c)
+	if err := h.service.ChangePassword(c.Request.Context(), userID, sessionID, req.OldPassword, req.NewPassword); err != nil {
+		passwordError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "密码已修改，其他设备需要重新登录")
+}
+
+// Reset POST /api/v1/auth/password/reset
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "请输入重置令牌和新密码")
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), req.Token, req.NewPassword, c.ClientIP()); err != nil {
+		passwordError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "密码已重置，请使用新密码登录")
+}
+
+// Forgot POST /api/v1/auth/password/forgot
+// 无论邮箱是否存在都返回相同结果，避免被用来探测注册邮箱
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "请输入邮箱")
+		return
+	}
+
+	if err := h.service.RequestReset(c.Request.Context(), req.Email, c.ClientIP()); err != nil {
+		passwordError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "如果该邮箱已绑定并验证，重置邮件将很快送达")
+}
+
+// ========== 管理接口（需用户管理权限） ==========
+
+// IssueReset POST /api/v1/users/:id/password-reset
+// 返回的令牌只显示一次，由管理员转交给用户
+func (h *PasswordHandler) IssueReset(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	issuerID, _ := middleware.GetUserID(c)
+	ticket, err := h.service.CreateResetToken(c.Request.Context(), id, issuerID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Created(c, "已生成密码重置令牌", ticket)
+}
+
+// passwordError 业务错误返回 400，其余按 AppError 处理
+func passwordError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPasswordMismatch),
+		errors.Is(err, service.ErrPasswordUnchanged),
+		errors.Is(err, service.ErrResetTokenInvalid),
+		errors.Is(err, service.ErrEmailInvalid):
+		BadRequest(c, err.Error())
+	default:
+		HandleError(c, err)
+	}
+}
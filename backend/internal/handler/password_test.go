@@ -0,0 +1,179 @@
+package handler
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/service"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockPasswordService 是 PasswordServiceInterface 的 mock 实现
+type MockPasswordService struct {
+	ChangePasswordFunc   func(ctx context.Context, userID, sessionID primitive.ObjectID, oldPassword, newPassword string) error
+	CreateResetTokenFunc func(ctx context.Context, userID, issuerID primitive.ObjectID) (*model.PasswordResetTicket, error)
+	ResetPasswordFunc    func(ctx context.Context, rawToken, newPassword, clientIP string) error
+	RequestResetFunc     func(ctx context.Context, email, clientIP string) error
+}
+
+func (m *MockPasswordService) ChangePassword(ctx context.Context, userID, sessionID primitive.ObjectID, oldPassword, newPassword string) error {
+	if m.ChangePasswordFunc != nil {
+		return m.ChangePasswordFunc(ctx, userID, sessionID, oldPassword, newPassword)
+	}
+	return nil
+}
+
+func (m *MockPasswordService) CreateResetToken(ctx context.Context, userID, issuerID primitive.ObjectID) (*model.PasswordResetTicket, error) {
+	if m.CreateResetTokenFunc != nil {
+		return m.CreateResetTokenFunc(ctx, userID, issuerID)
+	}
+	return nil, nil
+}
+
+func (m *MockPasswordService) ResetPassword(ctx context.Context, rawToken, newPassword, clientIP string) error {
+	if m.ResetPasswordFunc != nil {
+		return m.ResetPasswordFunc(ctx, rawToken, newPassword, clientIP)
+	}
+	return nil
+}
+
+func (m *MockPasswordService) RequestReset(ctx context.Context, email, clientIP string) error {
+	if m.RequestResetFunc != nil {
+		return m.RequestResetFunc(ctx, email, clientIP)
+	}
+	return nil
+}
+
+func TestPasswordHandler_Change_KeepsCurrentSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID()
+	sessionID := primitive.NewObjectID()
+	var receivedSession primitive.ObjectID
+	h := NewPasswordHandlerWithService(&MockPasswordService{
+		ChangePasswordFunc: func(ctx context.Context, uID, sID primitive.ObjectID, oldPassword, newPassword string) error {
+			receivedSession = sID
+			if oldPassword != "old-Pass1" || newPassword != "new-Pass2" {
+				t.Errorf("密码参数不正确: %q, %q", oldPassword, newPassword)
+			}
+			return nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, userID)
+	c.Set(middleware.ContextSessionID, sessionID)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/auth/password",
+		strings.NewReader(`{"old_password":"old-Pass1","new_password":"new-Pass2"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Change(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if receivedSession != sessionID {
+		t.Errorf("期望保留当前会话 %s, 实际 %s", sessionID.Hex(), receivedSession.Hex())
+	}
+}
+
+func TestPasswordHandler_Change_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"原密码错误", service.ErrPasswordMismatch, "原密码错误"},
+		{"新旧密码相同", service.ErrPasswordUnchanged, "新密码不能与原密码相同"},
+		{"强度不足", apperrors.InvalidParamsError("密码过于常见，请更换"), "密码过于常见，请更换"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPasswordHandlerWithService(&MockPasswordService{
+				ChangePasswordFunc: func(ctx context.Context, userID, sessionID primitive.ObjectID, oldPassword, newPassword string) error {
+					return tt.err
+				},
+			})
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set(middleware.ContextUserID, primitive.NewObjectID())
+			c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/auth/password",
+				strings.NewReader(`{"old_password":"a","new_password":"b"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Change(c)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("期望状态码 400, 实际 %d", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("响应应包含 %q: %s", tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPasswordHandler_Reset_InvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewPasswordHandlerWithService(&MockPasswordService{
+		ResetPasswordFunc: func(ctx context.Context, rawToken, newPassword, clientIP string) error {
+			return service.ErrResetTokenInvalid
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/password/reset",
+		strings.NewReader(`{"token":"used.token","new_password":"new-Pass2"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.Reset(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("期望状态码 400, 实际 %d", w.Code)
+	}
+}
+
+func TestPasswordHandler_IssueReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	adminID := primitive.NewObjectID()
+	userID := primitive.NewObjectID()
+	h := NewPasswordHandlerWithService(&MockPasswordService{
+		CreateResetTokenFunc: func(ctx context.Context, uID, issuerID primitive.ObjectID) (*model.PasswordResetTicket, error) {
+			if uID != userID || issuerID != adminID {
+				t.Errorf("期望用户 %s 由 %s 发起, 实际 %s 由 %s 发起", userID.Hex(), adminID.Hex(), uID.Hex(), issuerID.Hex())
+			}
+			return &model.PasswordResetTicket{Token: "sel.ver", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.ContextUserID, adminID)
+	c.Params = gin.Params{{Key: "id", Value: userID.Hex()}}
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/users/x/password-reset", nil)
+
+	h.IssueReset(c)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("期望状态码 201, 实际 %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"token":"sel.ver"`) {
+		t.Errorf("响应应包含重置令牌: %s", w.Body.String())
+	}
+}
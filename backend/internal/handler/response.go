@@ -2,6 +2,7 @@ package handler
 
 import (
 	apperrors "backend/internal/errors"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -99,6 +100,14 @@ func Unauthorized(c *gin.Context, msg string) {
 	})
 }
 
+// Forbidden 403 - 无权限
+func Forbidden(c *gin.Context, msg string) {
+	c.JSON(http.StatusForbidden, Response{
+		Code: apperrors.CodeForbidden,
+		Msg:  msg,
+	})
+}
+
 // NotFound 404 - 资源不存在
 func NotFound(c *gin.Context, msg string) {
 	c.JSON(http.StatusNotFound, Response{
@@ -115,6 +124,14 @@ func Conflict(c *gin.Context, msg string) {
 	})
 }
 
+// TooManyRequests 429 - 请求过于频繁
+func TooManyRequests(c *gin.Context, msg string) {
+	c.JSON(http.StatusTooManyRequests, Response{
+		Code: apperrors.CodeTooManyRequests,
+		Msg:  msg,
+	})
+}
+
 // ServerError 500 - 服务器内部错误
 func ServerError(c *gin.Context) {
 	c.JSON(http.StatusInternalServerError, Response{
@@ -123,6 +140,30 @@ func ServerError(c *gin.Context) {
 	})
 }
 
+// HandleError 根据 AppError 的错误码返回对应的 HTTP 响应，其余错误一律按 500 处理
+func HandleError(c *gin.Context, err error) {
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		ServerError(c)
+		return
+	}
+
+	switch appErr.Code {
+	case apperrors.CodeInvalidParams:
+		BadRequest(c, appErr.Message)
+	case apperrors.CodeUnauthorized:
+		Unauthorized(c, appErr.Message)
+	case apperrors.CodeForbidden:
+		Forbidden(c, appErr.Message)
+	case apperrors.CodeNotFound:
+		NotFound(c, appErr.Message)
+	case apperrors.CodeConflict:
+		Conflict(c, appErr.Message)
+	default:
+		ServerError(c)
+	}
+}
+
 // ========== 旧版兼容（Legacy API 使用，返回 HTTP 200） ==========
 
 // Error 旧版错误响应 - 保持向后兼容，始终返回 HTTP 200
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type SensitiveWordHandler struct {
+	service service.SensitiveWordServiceInterface
+}
+
+type (
+	CreateSensitiveWordRequest struct {
+		Word   string `json:"word" binding:"required,max=50"`
+		Weight int    `json:"weight" binding:"omitempty,min=1,max=1000"`
+	}
+
+	UpdateSensitiveWordRequest struct {
+		Weight int `json:"weight" binding:"required,min=1,max=1000"`
+	}
+)
+
+// ========== 构造函数 ==========
+
+func NewSensitiveWordHandler() *SensitiveWordHandler {
+	return &SensitiveWordHandler{
+		service: service.NewSensitiveWordService(),
+	}
+}
+
+// NewSensitiveWordHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewSensitiveWordHandlerWithService(svc service.SensitiveWordServiceInterface) *SensitiveWordHandler {
+	return &SensitiveWordHandler{
+		service: svc,
+	}
+}
+
+// ========== RESTful API ==========
+
+// List GET /api/v1/sensitive-words
+func (h *SensitiveWordHandler) List(c *gin.Context) {
+	words, err := h.service.List(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessList(c, words)
+}
+
+// Create POST /api/v1/sensitive-words
+func (h *SensitiveWordHandler) Create(c *gin.Context) {
+	var req CreateSensitiveWordRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		Unauthorized(c, "请先登录")
+		return
+	}
+
+	word, err := h.service.Create(c.Request.Context(), req.Word, req.Weight, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Created(c, "添加成功", word)
+}
+
+// Update PATCH /api/v1/sensitive-words/:id
+func (h *SensitiveWordHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的敏感词ID")
+		return
+	}
+
+	var req UpdateSensitiveWordRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	word, err := h.service.UpdateWeight(c.Request.Context(), id, req.Weight)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", word)
+}
+
+// Delete DELETE /api/v1/sensitive-words/:id
+func (h *SensitiveWordHandler) Delete(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的敏感词ID")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type SeriesHandler struct {
+	service service.SeriesServiceInterface
+}
+
+type (
+	CreateSeriesRequest struct {
+		Title       string `json:"title" binding:"required,max=100"`
+		Slug        string `json:"slug" binding:"omitempty,max=80"`
+		Description string `json:"description" binding:"max=500"`
+	}
+
+	UpdateSeriesRequest struct {
+		Title       *string `json:"title" binding:"omitempty,max=100"`
+		Slug        *string `json:"slug" binding:"omitempty,max=80"`
+		Description *string `json:"description" binding:"omitempty,max=500"`
+	}
+
+	// SetSeriesArticlesRequest 按顺序列出系列包含的文章
+	SetSeriesArticlesRequest struct {
+		ArticleIDs []string `json:"article_ids" binding:"max=100"`
+	}
+)
+
+// ========== 构造函数 ==========
+
+func NewSeriesHandler() *SeriesHandler {
+	return &SeriesHandler{
+		service: service.NewSeriesService(),
+	}
+}
+
+// NewSeriesHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewSeriesHandlerWithService(svc service.SeriesServiceInterface) *SeriesHandler {
+	return &SeriesHandler{
+		service: svc,
+	}
+}
+
+// ========== RESTful API ==========
+
+// List GET /api/v1/series
+func (h *SeriesHandler) List(c *gin.Context) {
+	list, err := h.service.List(c.Request.Context())
+	if err != nil {
+		ServerError(c)
+		return
+	}
+	SuccessList(c, list)
+}
+
+// Get GET /api/v1/series/:slug
+func (h *SeriesHandler) Get(c *gin.Context) {
+	detail, err := h.service.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "查询成功", detail)
+}
+
+// ========== 管理接口 ==========
+
+// Create POST /api/v1/series
+func (h *SeriesHandler) Create(c *gin.Context) {
+	var req CreateSeriesRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	series, err := h.service.Create(c.Request.Context(), &model.Series{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	Created(c, "创建成功", series)
+}
+
+// Update PATCH /api/v1/series/:id
+func (h *SeriesHandler) Update(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的系列id")
+		return
+	}
+
+	var req UpdateSeriesRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	series, err := h.service.Update(c.Request.Context(), id, &model.SeriesUpdate{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", series)
+}
+
+// SetArticles PUT /api/v1/series/:id/articles
+func (h *SeriesHandler) SetArticles(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的系列id")
+		return
+	}
+
+	var req SetSeriesArticlesRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	articleIDs := make([]primitive.ObjectID, 0, len(req.ArticleIDs))
+	for _, hex := range req.ArticleIDs {
+		articleID, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			BadRequest(c, "无效的文章id")
+			return
+		}
+		articleIDs = append(articleIDs, articleID)
+	}
+
+	detail, err := h.service.SetArticles(c.Request.Context(), id, articleIDs)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", detail)
+}
+
+// Delete DELETE /api/v1/series/:id
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的系列id")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithMsg(c, "删除成功")
+}
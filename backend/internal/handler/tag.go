@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ========== 类型定义 ==========
+
+type TagHandler struct {
+	service service.TagServiceInterface
+}
+
+type UpdateTagRequest struct {
+	Description string `json:"description" binding:"max=200"`
+}
+
+// ========== 构造函数 ==========
+
+func NewTagHandler() *TagHandler {
+	return &TagHandler{
+		service: service.NewTagService(),
+	}
+}
+
+// NewTagHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewTagHandlerWithService(svc service.TagServiceInterface) *TagHandler {
+	return &TagHandler{
+		service: svc,
+	}
+}
+
+// ========== RESTful API ==========
+
+// List GET /api/v1/tags
+func (h *TagHandler) List(c *gin.Context) {
+	tags, err := h.service.List(c.Request.Context())
+	if err != nil {
+		ServerError(c)
+		return
+	}
+	SuccessList(c, tags)
+}
+
+// UpdateDescription PUT /api/v1/tags/:slug
+func (h *TagHandler) UpdateDescription(c *gin.Context) {
+	var req UpdateTagRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	tag, err := h.service.UpdateDescription(c.Request.Context(), c.Param("slug"), req.Description)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	SuccessWithData(c, "更新成功", tag)
+}
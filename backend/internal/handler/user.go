@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"backend/internal/middleware"
+	"backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ========== 类型定义 ==========
+
+type UserHandler struct {
+	service service.UserServiceInterface
+}
+
+// ========== 构造函数 ==========
+
+func NewUserHandler() *UserHandler {
+	return &UserHandler{
+		service: service.NewUserService(),
+	}
+}
+
+// NewUserHandlerWithService 使用指定的 Service 创建 Handler（用于测试）
+func NewUserHandlerWithService(svc service.UserServiceInterface) *UserHandler {
+	return &UserHandler{
+		service: svc,
+	}
+}
+
+// ========== 管理接口（需用户管理权限） ==========
+
+// Disable POST /api/v1/users/:id/disable
+func (h *UserHandler) Disable(c *gin.Context) {
+	h.setDisabled(c, true, "已禁用该用户")
+}
+
+// Enable POST /api/v1/users/:id/enable
+func (h *UserHandler) Enable(c *gin.Context) {
+	h.setDisabled(c, false, "已启用该用户")
+}
+
+func (h *UserHandler) setDisabled(c *gin.Context, disabled bool, msg string) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "无效的用户ID")
+		return
+	}
+
+	if currentID, ok := middleware.GetUserID(c); ok && currentID == id && disabled {
+		BadRequest(c, "不能禁用自己的账号")
+		return
+	}
+
+	if err := h.service.SetDisabled(c.Request.Context(), id, disabled); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessWithMsg(c, msg)
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	apperrors "backend/internal/errors"
 	"backend/internal/service"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -10,7 +12,9 @@ import (
 )
 
 const (
-	ContextUserID = "userID"
+	ContextUserID    = "userID"
+	ContextRole      = "role"
+	ContextSessionID = "sessionID"
 )
 
 // 使用单例模式避免每次请求创建新实例
@@ -65,6 +69,16 @@ func AuthWithService(authService service.AuthServiceInterface) gin.HandlerFunc {
 			return
 		}
 
+		// 已退出登录、修改密码或被禁用的会话立即失效
+		if authService.IsAccessTokenRevoked(claims.ID) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code": 401,
+				"msg":  "Token已失效，请重新登录",
+			})
+			c.Abort()
+			return
+		}
+
 		userID, err := primitive.ObjectIDFromHex(claims.UserID)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -75,7 +89,36 @@ func AuthWithService(authService service.AuthServiceInterface) gin.HandlerFunc {
 			return
 		}
 
+		// 被禁用的用户立即拒绝；角色取自数据库（短暂缓存），降级不必等待 access token 过期
+		role, err := authService.CheckUserStatus(c.Request.Context(), userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrUserDisabled):
+				c.JSON(http.StatusForbidden, gin.H{
+					"code": apperrors.CodeForbidden,
+					"msg":  "账号已被禁用",
+				})
+			case apperrors.IsNotFound(err):
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"code": 401,
+					"msg":  "用户不存在",
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"code": apperrors.CodeServerError,
+					"msg":  "服务器错误，请稍后再试",
+				})
+			}
+			c.Abort()
+			return
+		}
+
 		c.Set(ContextUserID, userID)
+		c.Set(ContextRole, role)
+		// 旧令牌没有 sid，此时无法识别当前会话
+		if sessionID, err := primitive.ObjectIDFromHex(claims.SessionID); err == nil {
+			c.Set(ContextSessionID, sessionID)
+		}
 		c.Next()
 	}
 }
@@ -87,3 +130,21 @@ func GetUserID(c *gin.Context) (primitive.ObjectID, bool) {
 	}
 	return userID.(primitive.ObjectID), true
 }
+
+// GetRole 获取当前请求用户的角色，需在 Auth 之后使用
+func GetRole(c *gin.Context) (string, bool) {
+	role, exists := c.Get(ContextRole)
+	if !exists {
+		return "", false
+	}
+	return role.(string), true
+}
+
+// GetSessionID 获取当前请求所属的会话 ID（refresh token family），需在 Auth 之后使用
+func GetSessionID(c *gin.Context) (primitive.ObjectID, bool) {
+	sessionID, exists := c.Get(ContextSessionID)
+	if !exists {
+		return primitive.NilObjectID, false
+	}
+	return sessionID.(primitive.ObjectID), true
+}
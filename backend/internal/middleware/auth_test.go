@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"backend/internal/model"
+	"backend/internal/service"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// stubAuthService 只实现 Auth 中间件用到的方法，CheckUserStatus 与 AuthService 一样先查缓存再查 status
+type stubAuthService struct {
+	service.AuthServiceInterface
+	userID  primitive.ObjectID
+	cache   *service.UserStatusCache
+	status  service.UserStatus
+	lookups int
+}
+
+func (s *stubAuthService) ValidateAccessToken(tokenString string) (*service.Claims, error) {
+	// 令牌中的角色可能已过时，Auth 应以 CheckUserStatus 返回的角色为准
+	return &service.Claims{UserID: s.userID.Hex(), Role: model.RoleAdmin}, nil
+}
+
+func (s *stubAuthService) IsAccessTokenRevoked(jti string) bool {
+	return false
+}
+
+func (s *stubAuthService) CheckUserStatus(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	status, ok := s.cache.Get(userID)
+	if !ok {
+		s.lookups++
+		status = s.status
+		s.cache.Set(userID, status)
+	}
+	if status.Disabled {
+		return "", service.ErrUserDisabled
+	}
+	return status.Role, nil
+}
+
+func TestAuth_DisabledUserRejectedAfterInvalidate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &stubAuthService{
+		userID: primitive.NewObjectID(),
+		cache:  service.NewUserStatusCache(time.Hour),
+		status: service.UserStatus{Role: model.RoleUser},
+	}
+	r := gin.New()
+	r.GET("/test", AuthWithService(svc), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	request := func() int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := request(); code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", code)
+	}
+
+	// 禁用写入数据库后，未失效的缓存仍返回旧状态
+	svc.status.Disabled = true
+	if code := request(); code != http.StatusOK {
+		t.Fatalf("缓存未失效时期望状态码 200, 实际 %d", code)
+	}
+
+	// UserService.SetDisabled 在更新数据库后使缓存失效，同一令牌的下一次请求即被拒绝
+	svc.cache.Invalidate(svc.userID)
+	if code := request(); code != http.StatusForbidden {
+		t.Errorf("禁用后期望状态码 403, 实际 %d", code)
+	}
+	if code := request(); code != http.StatusForbidden {
+		t.Errorf("禁用状态应被缓存, 期望状态码 403, 实际 %d", code)
+	}
+	if svc.lookups != 2 {
+		t.Errorf("期望查询 2 次用户状态, 实际 %d", svc.lookups)
+	}
+}
+
+func TestAuth_RoleFromUserStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := &stubAuthService{
+		userID: primitive.NewObjectID(),
+		cache:  service.NewUserStatusCache(time.Hour),
+		status: service.UserStatus{Role: model.RoleModerator},
+	}
+	var role string
+	r := gin.New()
+	r.GET("/test", AuthWithService(svc), func(c *gin.Context) {
+		role, _ = GetRole(c)
+		c.Status(http.StatusOK)
+	})
+	request := func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+	}
+
+	request()
+	if role != model.RoleModerator {
+		t.Errorf("角色应取自用户状态而不是令牌, 实际 %s", role)
+	}
+
+	// 降级后缓存失效即生效，不必等待 access token 过期
+	svc.status.Role = model.RoleUser
+	svc.cache.Invalidate(svc.userID)
+	request()
+	if role != model.RoleUser {
+		t.Errorf("降级后期望角色 %s, 实际 %s", model.RoleUser, role)
+	}
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"backend/internal/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitPolicy 令牌桶限流策略：桶容量为 Limit，每 Period 补满
+type RateLimitPolicy struct {
+	// Name 策略名，作为存储 key 的前缀，不同路由组的桶互不影响
+	Name   string
+	Limit  int
+	Period time.Duration
+	// Key 计算限流维度（IP、用户 ID、用户名等），返回空字符串表示不限流
+	Key RateLimitKeyFunc
+	// Reject 超限时写入响应体，由调用方提供统一响应格式；为空时只返回 429 状态码
+	Reject gin.HandlerFunc
+}
+
+// RateLimitKeyFunc 从请求中提取限流 key
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitResult 一次取令牌的结果
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset 桶重新补满所需时间
+	Reset time.Duration
+	// RetryAfter 被拒绝时距离下一个令牌可用的时间
+	RetryAfter time.Duration
+}
+
+// RateLimitStore 限流状态存储。MemoryRateLimitStore 适用于单实例部署，
+// 多实例部署时实现该接口接入共享存储（如 Redis），保证各实例共用同一个桶
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error)
+}
+
+// RateLimit 限流中间件，写入 RateLimit-Limit/Remaining/Reset 响应头，超限时返回 429 和 Retry-After。
+// 存储出错时放行请求，避免限流组件故障导致服务不可用
+func RateLimit(store RateLimitStore, policy RateLimitPolicy) gin.HandlerFunc {
+	if policy.Limit <= 0 || policy.Period <= 0 {
+		// 策略未启用
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		key := policy.Key(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), policy.Name+":"+key, policy)
+		if err != nil {
+			logger.Warn("限流存储不可用，已放行请求", logger.String("policy", policy.Name), logger.Err(err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			if policy.Reject != nil {
+				policy.Reject(c)
+				c.Abort()
+			} else {
+				c.AbortWithStatus(http.StatusTooManyRequests)
+			}
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// KeyByIP 按客户端 IP 限流。只有来自 TRUSTED_PROXIES 的请求才会采用 X-Forwarded-For，
+// 否则客户端可以伪造该请求头绕过限流
+func KeyByIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// KeyByUser 按登录用户限流，需在 Auth 之后使用；未登录时退化为按 IP
+func KeyByUser(c *gin.Context) string {
+	if userID, ok := GetUserID(c); ok {
+		return "user:" + userID.Hex()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByUsername 按请求体中的用户名（或邮箱等）字段限流，如登录、找回密码接口，读取后会还原请求体供 handler 绑定；
+// 请求体中没有该字段时不限流，由其他策略兜底
+func KeyByUsername(field string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+		// 只读取有限长度，剩余部分原样留给 handler
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxUsernameBodySize))
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+		if err != nil {
+			return ""
+		}
+
+		var payload map[string]interface{}
+		if json.Unmarshal(body, &payload) != nil {
+			return ""
+		}
+		name, _ := payload[field].(string)
+		return strings.ToLower(strings.TrimSpace(name))
+	}
+}
+
+// 按用户名限流时最多读取的请求体长度
+const maxUsernameBodySize = 64 << 10
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// MemoryRateLimitStore 进程内令牌桶存储
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// full 桶补满的时刻，之后可以安全清理
+	full time.Time
+}
+
+// 清理已补满的桶的间隔
+const rateLimitSweepInterval = time.Minute
+
+// NewMemoryRateLimitStore 创建进程内限流存储
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take 从 key 对应的桶中取一个令牌
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(policy.Limit)
+	// 每秒补充的令牌数
+	rate := capacity / policy.Period.Seconds()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: capacity, last: now}
+		s.buckets[key] = b
+	} else {
+		b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+		b.last = now
+	}
+
+	result := RateLimitResult{Limit: policy.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = secondsToDuration((capacity - b.tokens) / rate)
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep 定期清理已补满的桶，避免按 IP 限流时 map 无限增长
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(sec float64) time.Duration {
+	return time.Duration(sec * float64(time.Second))
+}
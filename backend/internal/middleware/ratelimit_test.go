@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"backend/internal/logger"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitTestRouter(store RateLimitStore, policy RateLimitPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/test", RateLimit(store, policy), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	return r
+}
+
+func doRateLimitRequest(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "192.0.2.1:1234"
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_ExceedLimit(t *testing.T) {
+	r := newRateLimitTestRouter(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 2, Period: time.Minute, Key: KeyByIP,
+		Reject: func(c *gin.Context) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"code": 11, "msg": "请求过于频繁"})
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		w := doRateLimitRequest(r, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("第 %d 次请求期望 200, 实际 %d", i+1, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("期望 RateLimit-Limit=2, 实际 %q", got)
+		}
+	}
+
+	w := doRateLimitRequest(r, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("期望状态码 429, 实际 %d", w.Code)
+	}
+	if w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("期望 RateLimit-Remaining=0, 实际 %q", w.Header().Get("RateLimit-Remaining"))
+	}
+	// 每 30 秒补充一个令牌
+	if w.Header().Get("Retry-After") != "30" {
+		t.Errorf("期望 Retry-After=30, 实际 %q", w.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(w.Body.String(), `"code":11`) {
+		t.Errorf("响应体应由 Reject 写入, 实际 %s", w.Body.String())
+	}
+}
+
+func TestKeyByIP_IgnoresForwardedForFromUntrustedPeer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if err := r.SetTrustedProxies(nil); err != nil {
+		t.Fatalf("设置受信任代理失败: %v", err)
+	}
+	r.POST("/test", RateLimit(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 1, Period: time.Minute, Key: KeyByIP,
+	}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// 每次伪造不同的 X-Forwarded-For，仍按连接地址限流
+	for i, forwarded := range []string{"203.0.113.1", "203.0.113.2"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/test", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		r.ServeHTTP(w, req)
+
+		want := http.StatusOK
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Errorf("第 %d 次请求期望 %d, 实际 %d", i+1, want, w.Code)
+		}
+	}
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	r := newRateLimitTestRouter(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "test", Limit: 0, Period: time.Minute, Key: KeyByIP,
+	})
+
+	for i := 0; i < 5; i++ {
+		if w := doRateLimitRequest(r, ""); w.Code != http.StatusOK {
+			t.Fatalf("未启用的策略不应限流, 实际 %d", w.Code)
+		}
+	}
+}
+
+func TestRateLimit_KeyByUsername(t *testing.T) {
+	r := newRateLimitTestRouter(NewMemoryRateLimitStore(), RateLimitPolicy{
+		Name: "login", Limit: 1, Period: time.Minute, Key: KeyByUsername("user_name"),
+	})
+
+	body := `{"user_name":"Alice","password":"secret"}`
+	w := doRateLimitRequest(r, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望状态码 200, 实际 %d", w.Code)
+	}
+	if w.Body.String() != body {
+		t.Errorf("读取用户名后应还原请求体, 实际 %q", w.Body.String())
+	}
+
+	// 用户名不区分大小写
+	if w := doRateLimitRequest(r, `{"user_name":"alice"}`); w.Code != http.StatusTooManyRequests {
+		t.Errorf("同一用户名期望 429, 实际 %d", w.Code)
+	}
+	if w := doRateLimitRequest(r, `{"user_name":"bob"}`); w.Code != http.StatusOK {
+		t.Errorf("不同用户名不应受影响, 实际 %d", w.Code)
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, RateLimitPolicy) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("store down")
+}
+
+func TestRateLimit_StoreErrorFailsOpen(t *testing.T) {
+	logger.Init("test")
+	r := newRateLimitTestRouter(failingRateLimitStore{}, RateLimitPolicy{
+		Name: "test", Limit: 1, Period: time.Minute, Key: KeyByIP,
+	})
+
+	if w := doRateLimitRequest(r, ""); w.Code != http.StatusOK {
+		t.Errorf("存储不可用时应放行, 实际 %d", w.Code)
+	}
+}
+
+func TestMemoryRateLimitStore_Refill(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	policy := RateLimitPolicy{Name: "test", Limit: 2, Period: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		if res, _ := store.Take(context.Background(), "k", policy); !res.Allowed {
+			t.Fatalf("第 %d 次应放行", i+1)
+		}
+	}
+	if res, _ := store.Take(context.Background(), "k", policy); res.Allowed {
+		t.Fatal("令牌耗尽后应拒绝")
+	}
+
+	now = now.Add(30 * time.Second)
+	res, _ := store.Take(context.Background(), "k", policy)
+	if !res.Allowed {
+		t.Fatal("补充令牌后应放行")
+	}
+	if res.Remaining != 0 {
+		t.Errorf("期望剩余 0, 实际 %d", res.Remaining)
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission 权限校验中间件，需在 Auth 之后使用；须同时具备所有列出的权限
+func RequirePermission(perms ...model.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := GetRole(c)
+		if !ok {
+			abortUnauthenticated(c)
+			return
+		}
+
+		for _, perm := range perms {
+			if !model.HasPermission(role, perm) {
+				abortForbidden(c)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func abortUnauthenticated(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"code": 401,
+		"msg":  "请先登录",
+	})
+	c.Abort()
+}
+
+func abortForbidden(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"code": apperrors.CodeForbidden,
+		"msg":  "权限不足",
+	})
+	c.Abort()
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRoleTestRouter 构造一个在 Auth 位置直接注入角色的路由
+func newRoleTestRouter(role string, guard gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/test", func(c *gin.Context) {
+		if role != "" {
+			c.Set(ContextRole, role)
+		}
+		c.Next()
+	}, guard, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequirePermission(t *testing.T) {
+	cases := []struct {
+		name string
+		role string
+		perm model.Permission
+		want int
+	}{
+		{"管理员拥有全部权限", model.RoleAdmin, model.PermArticleDelete, http.StatusOK},
+		{"作者可以发布文章", model.RoleAuthor, model.PermArticleCreate, http.StatusOK},
+		{"作者不能删除文章", model.RoleAuthor, model.PermArticleDelete, http.StatusForbidden},
+		{"普通用户不能发布文章", model.RoleUser, model.PermArticleCreate, http.StatusForbidden},
+		{"未登录", "", model.PermMessageWrite, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRoleTestRouter(tc.role, RequirePermission(tc.perm))
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			r.ServeHTTP(w, req)
+
+			if w.Code != tc.want {
+				t.Errorf("期望状态码 %d, 实际 %d", tc.want, w.Code)
+			}
+			if tc.want == http.StatusForbidden {
+				var resp struct {
+					Code int `json:"code"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Code != apperrors.CodeForbidden {
+					t.Errorf("期望业务码 %d, 实际 %s", apperrors.CodeForbidden, w.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -1,31 +1,181 @@
 package model
 
 import (
+	"backend/pkg/markdown"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// 文章状态
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusScheduled = "scheduled"
+	ArticleStatusPublished = "published"
+	ArticleStatusArchived  = "archived"
+)
+
+// articleStatusTransitions 允许的状态流转：from -> []to
+var articleStatusTransitions = map[string][]string{
+	ArticleStatusDraft:     {ArticleStatusScheduled, ArticleStatusPublished},
+	ArticleStatusScheduled: {ArticleStatusDraft, ArticleStatusScheduled, ArticleStatusPublished},
+	ArticleStatusPublished: {ArticleStatusDraft, ArticleStatusArchived},
+	ArticleStatusArchived:  {ArticleStatusDraft, ArticleStatusPublished},
+}
+
+// CanTransitionArticleStatus 判断文章状态能否从 from 变更为 to
+func CanTransitionArticleStatus(from, to string) bool {
+	for _, s := range articleStatusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
 type Article struct {
-	ID         primitive.ObjectID   `bson:"_id,omitempty" json:"_id"`
-	ArticleType string              `bson:"article_type" json:"article_type"`
-	Title      string               `bson:"title" json:"title"`
-	Content    string               `bson:"content" json:"content"`
-	Tag        string               `bson:"tag" json:"tag"`
-	UpdatedAt  time.Time            `bson:"updated_at" json:"updated_at"`
-	CreatedAt  time.Time            `bson:"created_at" json:"created_at"`
-	CoverImage string               `bson:"cover_image" json:"cover_image"`
-	PageViews  int                  `bson:"page_views" json:"page_views"`
-	Comments   []primitive.ObjectID `bson:"comments" json:"comments"`
+	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"_id"`
+	ArticleType  string               `bson:"article_type" json:"article_type"`
+	Title        string               `bson:"title" json:"title"`
+	Slug         string               `bson:"slug,omitempty" json:"slug"`
+	OldSlugs     []string             `bson:"old_slugs,omitempty" json:"-"`
+	Content      string               `bson:"content" json:"content"`                               // Markdown 原文
+	ContentHTML  string               `bson:"content_html,omitempty" json:"content_html,omitempty"` // 渲染并过滤后的 HTML，列表接口不返回
+	TOC          []markdown.Heading   `bson:"toc,omitempty" json:"toc,omitempty"`                   // 文章目录
+	Tag          string               `bson:"tag" json:"tag"`                                       // 主标签，等于 Tags[0]，兼容旧版接口
+	Tags         []string             `bson:"tags" json:"tags"`
+	UpdatedAt    time.Time            `bson:"updated_at" json:"updated_at"`
+	CreatedAt    time.Time            `bson:"created_at" json:"created_at"`
+	CoverImage   string               `bson:"cover_image" json:"cover_image"`
+	PageViews    int                  `bson:"page_views" json:"page_views"`
+	Comments     []primitive.ObjectID `bson:"comments" json:"comments"`
+	CommentCount int                  `bson:"comment_count" json:"comment_count"` // 评论数，每次评论写入后按评论集合重新统计
+	Status       string               `bson:"status,omitempty" json:"status"`
+	PublishAt    time.Time            `bson:"publish_at,omitempty" json:"publish_at,omitempty"`
+	AuthorID     *primitive.ObjectID  `bson:"author_id,omitempty" json:"author_id,omitempty"` // 没有作者的旧文章只能由拥有 article:manage 权限的用户修改
+	CategoryID   *primitive.ObjectID  `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	SeriesID     *primitive.ObjectID  `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	SeriesOrder  int                  `bson:"series_order,omitempty" json:"series_order,omitempty"`
 }
 
+// GetStatus 获取文章状态，没有 status 字段的旧数据视为已发布
+func (a *Article) GetStatus() string {
+	if a.Status == "" {
+		return ArticleStatusPublished
+	}
+	return a.Status
+}
+
+// GetTags 获取文章标签，没有 tags 字段的旧数据使用 tag
+func (a *Article) GetTags() []string {
+	if len(a.Tags) == 0 && a.Tag != "" {
+		return []string{a.Tag}
+	}
+	return a.Tags
+}
+
+// IsPublic 判断文章在 now 时刻是否对外可见
+func (a *Article) IsPublic(now time.Time) bool {
+	switch a.GetStatus() {
+	case ArticleStatusPublished:
+		return true
+	case ArticleStatusScheduled:
+		return !a.PublishAt.After(now)
+	default:
+		return false
+	}
+}
+
+// ArticleInfo 站点统计信息，由公开文章、留言和用户数据实时聚合；
+// Tags 与 TotalCount 保留旧版字段含义
 type ArticleInfo struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
-	Tags       []string           `bson:"tags" json:"tags"`
-	TotalCount int                `bson:"total_count" json:"total_count"`
+	TotalCount     int64      `json:"total_count"`
+	Tags           []string   `json:"tags"`
+	TagCounts      []TagCount `json:"tag_counts"`
+	TotalPageViews int64      `json:"total_page_views"`
+	MessageCount   int64      `json:"message_count"`
+	ReplyCount     int64      `json:"reply_count"`
+	UserCount      int64      `json:"user_count"`
+	LatestUpdate   time.Time  `json:"latest_update"`
+}
+
+// TagCount 标签及其公开文章数
+type TagCount struct {
+	Name  string `bson:"_id" json:"name"`
+	Count int64  `bson:"count" json:"count"`
 }
 
 type ArticleBrief struct {
 	ID    primitive.ObjectID `bson:"_id" json:"_id"`
 	Title string             `bson:"title" json:"title"`
+	Slug  string             `bson:"slug,omitempty" json:"slug,omitempty"`
+}
+
+// ArticleDetail 文章详情，附带分类、系列位置以及按创建时间排列的上一篇/下一篇
+type ArticleDetail struct {
+	Article
+	Category *Category     `json:"category,omitempty"`
+	Series   *SeriesNav    `json:"series,omitempty"`
+	Prev     *ArticleBrief `json:"prev"`
+	Next     *ArticleBrief `json:"next"`
+}
+
+// ArticleUpdate 文章更新字段，nil 表示不修改
+type ArticleUpdate struct {
+	ArticleType *string
+	Title       *string
+	Slug        *string
+	Content     *string
+	// ContentHTML 和 TOC 由 service 根据 Content 渲染生成
+	ContentHTML *string
+	TOC         []markdown.Heading
+	Tags        []string
+	CoverImage  *string
+	// OldSlug 由 service 在 slug 变化时设置，与新 slug 在同一次更新中写入历史 slug
+	OldSlug *string
+	// CategoryID 为 NilObjectID 表示移出分类
+	CategoryID *primitive.ObjectID
+}
+
+// ArticleFilter 文章列表过滤条件
+type ArticleFilter struct {
+	Tags []string
+	// MatchAllTags 为 true 时要求包含全部标签（AND），否则包含任一标签即可（OR）
+	MatchAllTags bool
+	// Category 分类 slug，由 service 解析为包含子分类在内的 CategoryIDs
+	Category    string
+	CategoryIDs []primitive.ObjectID
+}
+
+// ArticleSearchQuery 文章全文搜索条件
+type ArticleSearchQuery struct {
+	Keywords string
+	Tag      string
+	From     time.Time
+	To       time.Time
+	Skip     int64
+	Limit    int64
+}
+
+// ArticleSearchHit 搜索结果条目，Snippet/TitleHighlight 为已转义并带 <mark> 高亮的 HTML
+type ArticleSearchHit struct {
+	ID             primitive.ObjectID `bson:"_id" json:"_id"`
+	Title          string             `bson:"title" json:"title"`
+	Slug           string             `bson:"slug,omitempty" json:"slug,omitempty"`
+	Tag            string             `bson:"tag" json:"tag"`
+	Tags           []string           `bson:"tags" json:"tags"`
+	Content        string             `bson:"content" json:"-"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	PageViews      int                `bson:"page_views" json:"page_views"`
+	Score          float64            `bson:"-" json:"score"`
+	TitleHighlight string             `bson:"-" json:"title_highlight"`
+	Snippet        string             `bson:"-" json:"snippet"`
+}
+
+// ArticleSearchResult 分页搜索结果
+type ArticleSearchResult struct {
+	List  []ArticleSearchHit `json:"list"`
+	Total int64              `json:"total"`
+	Skip  int64              `json:"skip"`
+	Limit int64              `json:"limit"`
 }
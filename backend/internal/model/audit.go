@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 审计事件类型
+const (
+	AuditLoginLocked       = "login_locked"
+	AuditRefreshTokenReuse = "refresh_token_reuse"
+	AuditPasswordChanged   = "password_changed"
+	AuditPasswordResetSent = "password_reset_issued"
+	AuditPasswordReset     = "password_reset"
+)
+
+// AuditLog 安全审计记录
+type AuditLog struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"_id"`
+	Event     string              `bson:"event" json:"event"`
+	UserID    *primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	UserName  string              `bson:"user_name,omitempty" json:"user_name,omitempty"`
+	IP        string              `bson:"ip,omitempty" json:"ip,omitempty"`
+	Detail    string              `bson:"detail,omitempty" json:"detail,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+}
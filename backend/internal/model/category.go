@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category 文章分类，通过 ParentID 组成树形结构
+type Category struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"_id"`
+	Name        string              `bson:"name" json:"name"`
+	Slug        string              `bson:"slug" json:"slug"`
+	Description string              `bson:"description" json:"description"`
+	ParentID    *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Sort        int                 `bson:"sort" json:"sort"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// CategoryNode 分类树节点，Count 为该分类及其子分类下的公开文章数
+type CategoryNode struct {
+	Category
+	Count    int64          `json:"count"`
+	Children []CategoryNode `json:"children"`
+}
+
+// CategoryUpdate 分类更新字段，nil 表示不修改；ParentID 为 NilObjectID 表示移动到顶层
+type CategoryUpdate struct {
+	Name        *string
+	Slug        *string
+	Description *string
+	ParentID    *primitive.ObjectID
+	Sort        *int
+}
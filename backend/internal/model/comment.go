@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Comment 文章评论；回复评论时 ParentID 为被回复的评论，RootID 为所属的顶层评论
+type Comment struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"_id"`
+	ArticleID   primitive.ObjectID  `bson:"article_id" json:"article_id"`
+	UserID      primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	RootID      *primitive.ObjectID `bson:"root_id,omitempty" json:"root_id,omitempty"`
+	ParentID    *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Content     string              `bson:"content" json:"content"`
+	ContentHTML string              `bson:"content_html" json:"content_html"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// CommentWithUser 带用户信息的评论，顶层评论的 Replies 按时间顺序包含整个楼层的回复
+type CommentWithUser struct {
+	ID          primitive.ObjectID  `bson:"_id" json:"_id"`
+	User        *UserBrief          `bson:"user" json:"user"`
+	RootID      *primitive.ObjectID `bson:"root_id,omitempty" json:"root_id,omitempty"`
+	ParentID    *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	ReplyToUser *UserBrief          `bson:"reply_to_user,omitempty" json:"reply_to_user,omitempty"`
+	Content     string              `bson:"content" json:"content"`
+	ContentHTML string              `bson:"content_html" json:"content_html"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at" json:"updated_at"`
+	Replies     []CommentWithUser   `bson:"-" json:"replies,omitempty"`
+}
+
+// CommentPage 分页的顶层评论
+type CommentPage struct {
+	List  []CommentWithUser `json:"list"`
+	Total int64             `json:"total"`
+	Skip  int64             `json:"skip"`
+	Limit int64             `json:"limit"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 发件箱邮件状态
+const (
+	MailStatusPending = "pending"
+	MailStatusSent    = "sent"
+	MailStatusFailed  = "failed"
+)
+
+// OutboxMail 发件箱中的邮件。业务代码只负责写入，由后台任务投递并在失败时重试，
+// 进程重启或 SMTP 暂时不可用都不会丢失邮件。发送成功或放弃投递后 Body 被清除，
+// 数据库中不长期保留邮件里的一次性链接
+type OutboxMail struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	To       string             `bson:"to" json:"to"`
+	Subject  string             `bson:"subject" json:"subject"`
+	Body     string             `bson:"body" json:"-"`
+	Template string             `bson:"template" json:"template"`
+	Status   string             `bson:"status" json:"status"`
+	Attempts int                `bson:"attempts" json:"attempts"`
+	// NextAttemptAt 下一次投递时间，投递中的邮件会被推后一个租约时长，防止多实例重复发送
+	NextAttemptAt time.Time  `bson:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	SentAt        *time.Time `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+}
+
+// EmailVerificationToken 邮箱验证令牌，只能使用一次。Email 为发送时的邮箱，
+// 用户在此之后修改邮箱则令牌失效
+type EmailVerificationToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Email     string             `bson:"email" json:"email"`
+	Selector  string             `bson:"selector" json:"-"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -6,32 +6,166 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// 留言与回复的审核状态
+const (
+	MessageStatusPending  = "pending"
+	MessageStatusApproved = "approved"
+	MessageStatusRejected = "rejected"
+	MessageStatusSpam     = "spam"
+)
+
+// IsValidMessageStatus 判断审核状态是否合法
+func IsValidMessageStatus(status string) bool {
+	switch status {
+	case MessageStatusPending, MessageStatusApproved, MessageStatusRejected, MessageStatusSpam:
+		return true
+	}
+	return false
+}
+
+// messageStatusOrApproved 没有 status 字段的旧数据视为已通过
+func messageStatusOrApproved(status string) string {
+	if status == "" {
+		return MessageStatusApproved
+	}
+	return status
+}
+
 type ReplyMessage struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
 	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
 	Content     string             `bson:"content" json:"content"`
-	ReplyToUser string             `bson:"reply_to_user" json:"reply_to_user"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	ContentHTML string             `bson:"content_html,omitempty" json:"content_html"`
+	// ReplyToUser 旧版客户端提交的被回复用户名，新回复使用 ReplyToUserID
+	ReplyToUser    string              `bson:"reply_to_user" json:"reply_to_user"`
+	ReplyToUserID  *primitive.ObjectID `bson:"reply_to_user_id,omitempty" json:"reply_to_user_id,omitempty"`
+	ReplyToReplyID *primitive.ObjectID `bson:"reply_to_reply_id,omitempty" json:"reply_to_reply_id,omitempty"`
+	Status         string              `bson:"status,omitempty" json:"status"`
+	SpamScore      int                 `bson:"spam_score,omitempty" json:"spam_score,omitempty"`
+	SpamReasons    []string            `bson:"spam_reasons,omitempty" json:"spam_reasons,omitempty"`
+	ModeratedBy    *primitive.ObjectID `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time          `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+	EditedAt       *time.Time          `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+	// 软删除：内容被清空，但保留位置以维持楼层结构
+	DeletedAt *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy *primitive.ObjectID `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
+
+// IsDeleted 回复是否已被删除
+func (r *ReplyMessage) IsDeleted() bool {
+	return r.DeletedAt != nil
+}
+
+// GetStatus 获取回复的审核状态
+func (r *ReplyMessage) GetStatus() string {
+	return messageStatusOrApproved(r.Status)
+}
+
+// ReplyTarget 回复对象：新版接口使用用户 ID 和可选的回复 ID，旧版接口只有用户名
+type ReplyTarget struct {
+	UserID     *primitive.ObjectID
+	ReplyID    *primitive.ObjectID
+	LegacyName string
 }
 
 type Message struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
-	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
-	Content   string             `bson:"content" json:"content"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	Replies   []ReplyMessage     `bson:"replies" json:"replies"`
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"_id"`
+	UserID      primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Content     string              `bson:"content" json:"content"`
+	ContentHTML string              `bson:"content_html,omitempty" json:"content_html"`
+	Status      string              `bson:"status,omitempty" json:"status"`
+	SpamScore   int                 `bson:"spam_score,omitempty" json:"spam_score,omitempty"`
+	SpamReasons []string            `bson:"spam_reasons,omitempty" json:"spam_reasons,omitempty"`
+	ModeratedBy *primitive.ObjectID `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time          `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+	EditedAt    *time.Time          `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+	// 软删除：内容被清空，但保留留言以维持其下回复的结构
+	DeletedAt *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy *primitive.ObjectID `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+	Replies   []ReplyMessage      `bson:"replies" json:"replies"`
 }
 
+// GetStatus 获取留言的审核状态
+func (m *Message) GetStatus() string {
+	return messageStatusOrApproved(m.Status)
+}
+
+// IsDeleted 留言是否已被删除
+func (m *Message) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// IsParticipant 判断用户是否是留言作者或已公开回复的作者
+func (m *Message) IsParticipant(userID primitive.ObjectID) bool {
+	if m.UserID == userID {
+		return true
+	}
+	for i := range m.Replies {
+		r := &m.Replies[i]
+		if r.UserID == userID && !r.IsDeleted() && r.GetStatus() == MessageStatusApproved {
+			return true
+		}
+	}
+	return false
+}
+
+// FindReply 按 ID 查找回复
+func (m *Message) FindReply(id primitive.ObjectID) *ReplyMessage {
+	for i := range m.Replies {
+		if m.Replies[i].ID == id {
+			return &m.Replies[i]
+		}
+	}
+	return nil
+}
+
+// MessageWithUser 公开留言列表项，已删除的留言以墓碑形式返回（deleted 为 true，不含内容和作者）
 type MessageWithUser struct {
-	ID        primitive.ObjectID     `bson:"_id" json:"_id"`
-	User      *UserBrief             `bson:"user" json:"user"`
-	Content   string                 `bson:"content" json:"content"`
-	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
-	Replies   []ReplyMessageWithUser `bson:"replies" json:"replies"`
+	ID          primitive.ObjectID     `bson:"_id" json:"_id"`
+	User        *UserBrief             `bson:"user" json:"user"`
+	Content     string                 `bson:"content" json:"content"`
+	ContentHTML string                 `bson:"content_html" json:"content_html"`
+	Deleted     bool                   `bson:"deleted" json:"deleted"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	EditedAt    *time.Time             `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+	Replies     []ReplyMessageWithUser `bson:"replies" json:"replies"`
 }
 
 type ReplyMessageWithUser struct {
-	User        *UserBrief `bson:"user" json:"user"`
-	Content     string     `bson:"content" json:"content"`
-	ReplyToUser string     `bson:"reply_to_user" json:"reply_to_user"`
-	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	User        *UserBrief         `bson:"user" json:"user"`
+	Content     string             `bson:"content" json:"content"`
+	ContentHTML string             `bson:"content_html" json:"content_html"`
+	// ReplyToUser 被回复用户的当前用户名（旧数据为提交时的用户名），兼容旧版客户端
+	ReplyToUser    string              `bson:"reply_to_user" json:"reply_to_user"`
+	ReplyTo        *UserBrief          `bson:"reply_to,omitempty" json:"reply_to,omitempty"`
+	ReplyToReplyID *primitive.ObjectID `bson:"reply_to_reply_id,omitempty" json:"reply_to_reply_id,omitempty"`
+	Deleted        bool                `bson:"deleted" json:"deleted"`
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+	EditedAt       *time.Time          `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+}
+
+// ModerationItem 审核队列中的一条留言或回复
+type ModerationItem struct {
+	// Type 为 message 或 reply
+	Type      string              `bson:"type" json:"type"`
+	MessageID primitive.ObjectID  `bson:"message_id" json:"message_id"`
+	ReplyID   *primitive.ObjectID `bson:"reply_id,omitempty" json:"reply_id,omitempty"`
+	User      *UserBrief          `bson:"user" json:"user"`
+	Content   string              `bson:"content" json:"content"`
+	Status    string              `bson:"status" json:"status"`
+	// SpamScore 和 SpamReasons 为垃圾内容过滤的评分和命中原因，供审核参考
+	SpamScore   int       `bson:"spam_score,omitempty" json:"spam_score"`
+	SpamReasons []string  `bson:"spam_reasons,omitempty" json:"spam_reasons,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// ModerationPage 审核队列分页结果
+type ModerationPage struct {
+	List  []ModerationItem `json:"list"`
+	Total int64            `json:"total"`
+	Skip  int64            `json:"skip"`
+	Limit int64            `json:"limit"`
 }
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PasswordResetToken 密码重置令牌，只能使用一次。与 refresh token 相同，
+// 令牌本身不落库：Selector 用于查找，TokenHash 为 verifier 的带密钥哈希
+type PasswordResetToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Selector  string             `bson:"selector" json:"-"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	// UsedAt 使用或作废的时间，为空表示仍可使用
+	UsedAt *time.Time `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	// CreatedBy 发起重置的管理员，用户通过邮箱自助找回时为空
+	CreatedBy *primitive.ObjectID `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// PasswordResetTicket 发给用户的重置令牌，只在创建时返回一次
+type PasswordResetTicket struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,53 @@
+package model
+
+// ========== 角色 ==========
+
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleAuthor    = "author"
+	RoleUser      = "user"
+)
+
+// ========== 权限 ==========
+
+type Permission string
+
+const (
+	PermArticleCreate   Permission = "article:create"
+	PermArticleEdit     Permission = "article:edit"
+	PermArticleDelete   Permission = "article:delete"
+	PermArticleManage   Permission = "article:manage" // 修改或删除他人的文章，没有该权限时只能操作自己的文章
+	PermMessageWrite    Permission = "message:write"
+	PermMessageModerate Permission = "message:moderate"
+	PermUserManage      Permission = "user:manage"
+)
+
+// rolePermissions 角色权限表，admin 拥有全部权限，不在表中列出
+var rolePermissions = map[string][]Permission{
+	RoleModerator: {PermMessageWrite, PermMessageModerate},
+	RoleAuthor:    {PermMessageWrite, PermArticleCreate, PermArticleEdit},
+	RoleUser:      {PermMessageWrite},
+}
+
+// IsValidRole 判断是否为已定义的角色
+func IsValidRole(role string) bool {
+	if role == RoleAdmin {
+		return true
+	}
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission 判断角色是否拥有指定权限
+func HasPermission(role string, perm Permission) bool {
+	if role == RoleAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
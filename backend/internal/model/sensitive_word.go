@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SensitiveWord 敏感词，命中时按 Weight 计入垃圾内容评分
+type SensitiveWord struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	Word      string             `bson:"word" json:"word"`
+	Weight    int                `bson:"weight" json:"weight"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Series 文章系列，系列内文章按 Article.SeriesOrder 排序
+type Series struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	Title       string             `bson:"title" json:"title"`
+	Slug        string             `bson:"slug" json:"slug"`
+	Description string             `bson:"description" json:"description"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// SeriesSummary 系列列表项，Count 为系列内公开文章数
+type SeriesSummary struct {
+	Series
+	Count int64 `json:"count"`
+}
+
+// SeriesDetail 系列详情，Articles 为按顺序排列的公开文章
+type SeriesDetail struct {
+	Series
+	Articles []ArticleBrief `json:"articles"`
+}
+
+// SeriesUpdate 系列更新字段，nil 表示不修改
+type SeriesUpdate struct {
+	Title       *string
+	Slug        *string
+	Description *string
+}
+
+// SeriesNav 文章在系列中的位置，如“第 1 篇，共 5 篇”
+type SeriesNav struct {
+	ID    primitive.ObjectID `json:"_id"`
+	Title string             `json:"title"`
+	Slug  string             `json:"slug"`
+	Part  int                `json:"part"`
+	Total int                `json:"total"`
+	Prev  *ArticleBrief      `json:"prev"`
+	Next  *ArticleBrief      `json:"next"`
+}
@@ -6,13 +6,74 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// RefreshToken 刷新令牌。同一次登录后轮换出的令牌属于同一个 family，
+// 已轮换的令牌再次出现说明令牌可能被盗用，此时吊销整个 family。
+// 令牌本身不落库：Selector 用于查找，TokenHash 为 verifier 的带密钥哈希
 type RefreshToken struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
 	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
-	Token     string             `bson:"token" json:"token"`
+	FamilyID  primitive.ObjectID `bson:"family_id,omitempty" json:"family_id"`
+	Selector  string             `bson:"selector,omitempty" json:"-"`
+	TokenHash string             `bson:"token_hash,omitempty" json:"-"`
+	// Token 旧版明文令牌，迁移后删除
+	Token     string    `bson:"token,omitempty" json:"-"`
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	Revoked   bool      `bson:"revoked" json:"revoked"`
+	// RotatedAt 因轮换而吊销的时间，为空表示未被轮换（仍有效或因退出登录吊销）
+	RotatedAt *time.Time `bson:"rotated_at,omitempty" json:"rotated_at,omitempty"`
+	// ReuseGraceUsed 已轮换的令牌在宽限期内被重用过一次，之后的重用一律视为泄露
+	ReuseGraceUsed bool `bson:"reuse_grace_used,omitempty" json:"-"`
+	// 设备信息，每次轮换时更新为最近一次使用的设备
+	UserAgent string `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	IP        string `bson:"ip,omitempty" json:"ip,omitempty"`
+	// SessionCreatedAt 本次登录的时间，轮换时沿用
+	SessionCreatedAt *time.Time `bson:"session_created_at,omitempty" json:"session_created_at,omitempty"`
+	// 与该 refresh token 一同签发的 access token，吊销会话时据此将其加入黑名单
+	AccessJTI       string     `bson:"access_jti,omitempty" json:"-"`
+	AccessExpiresAt *time.Time `bson:"access_expires_at,omitempty" json:"-"`
+}
+
+// RevokedAccessToken 已吊销但尚未过期的 access token，过期后由 TTL 索引自动清理
+type RevokedAccessToken struct {
+	JTI       string             `bson:"_id" json:"jti"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
 	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	Revoked   bool               `bson:"revoked" json:"revoked"`
+	RevokedAt time.Time          `bson:"revoked_at" json:"revoked_at"`
+}
+
+// SessionStart 返回令牌所属会话的登录时间，旧数据以令牌创建时间代替
+func (t *RefreshToken) SessionStart() time.Time {
+	if t.SessionCreatedAt == nil {
+		return t.CreatedAt
+	}
+	return *t.SessionCreatedAt
+}
+
+// DeviceInfo 登录或刷新时的客户端信息
+type DeviceInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session 登录会话，即一个 refresh token family
+type Session struct {
+	ID        primitive.ObjectID `json:"_id"`
+	UserAgent string             `json:"user_agent"`
+	IP        string             `json:"ip"`
+	CreatedAt time.Time          `json:"created_at"`
+	// LastUsedAt 最近一次登录或刷新令牌的时间
+	LastUsedAt time.Time `json:"last_used_at"`
+	// Current 是否为发起请求的会话
+	Current bool `json:"current"`
+}
+
+// Family 返回令牌所属 family，旧数据没有 family_id 时以自身 ID 作为 family
+func (t *RefreshToken) Family() primitive.ObjectID {
+	if t.FamilyID.IsZero() {
+		return t.ID
+	}
+	return t.FamilyID
 }
 
 type TokenPair struct {
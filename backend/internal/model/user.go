@@ -15,6 +15,12 @@ type User struct {
 	Avatar       string             `bson:"avatar" json:"avatar"`
 	IsDisabled   bool               `bson:"is_disabled" json:"is_disabled"`
 	IsAdmin      bool               `bson:"is_admin" json:"is_admin"`
+	Role         string             `bson:"role,omitempty" json:"role"`
+	// Email 可选，验证通过前不能用于找回密码；同一邮箱只能被一个账号验证
+	Email         string `bson:"email,omitempty" json:"email,omitempty"`
+	EmailVerified bool   `bson:"email_verified" json:"email_verified"`
+	// Language 邮件语言（zh 或 en）
+	Language string `bson:"language,omitempty" json:"language,omitempty"`
 }
 
 func NewUser(username, password string) *User {
@@ -25,9 +31,21 @@ func NewUser(username, password string) *User {
 		Avatar:       config.AppConfig.GetDefaultAvatarURL(),
 		IsDisabled:   false,
 		IsAdmin:      false,
+		Role:         RoleUser,
 	}
 }
 
+// GetRole 获取用户的有效角色，is_admin 优先，未设置或非法角色按普通用户处理
+func (u *User) GetRole() string {
+	if u.IsAdmin {
+		return RoleAdmin
+	}
+	if IsValidRole(u.Role) {
+		return u.Role
+	}
+	return RoleUser
+}
+
 type UserResponse struct {
 	ID           primitive.ObjectID `json:"_id"`
 	UserName     string             `json:"user_name"`
@@ -35,16 +53,23 @@ type UserResponse struct {
 	Avatar       string             `json:"avatar"`
 	IsDisabled   bool               `json:"is_disabled"`
 	IsAdmin      bool               `json:"is_admin"`
+	Role         string             `json:"role"`
+	// 仅返回给本人
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 func (u *User) ToResponse() *UserResponse {
 	return &UserResponse{
-		ID:           u.ID,
-		UserName:     u.UserName,
-		RegisteredAt: u.RegisteredAt,
-		Avatar:       u.Avatar,
-		IsDisabled:   u.IsDisabled,
-		IsAdmin:      u.IsAdmin,
+		ID:            u.ID,
+		UserName:      u.UserName,
+		RegisteredAt:  u.RegisteredAt,
+		Avatar:        u.Avatar,
+		IsDisabled:    u.IsDisabled,
+		IsAdmin:       u.IsAdmin,
+		Role:          u.GetRole(),
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
 	}
 }
 
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"backend/internal/config"
+	"backend/internal/handler"
+	"backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimiters 各路由组的限流中间件，新旧版路由共用同一组令牌桶
+type rateLimiters struct {
+	loginByIP   gin.HandlerFunc
+	loginByName gin.HandlerFunc
+	register    gin.HandlerFunc
+	captcha     gin.HandlerFunc
+	message     gin.HandlerFunc
+	upload      gin.HandlerFunc
+	// 修改密码按用户限流（需放在 Auth 之后），重置密码按 IP 限流
+	passwordByUser gin.HandlerFunc
+	passwordByIP   gin.HandlerFunc
+	// 触发发送邮件的接口按用户限流，找回密码按目标邮箱限流
+	mailByUser  gin.HandlerFunc
+	mailByEmail gin.HandlerFunc
+}
+
+// newRateLimiters 根据配置创建限流中间件，message 与 upload 按用户限流，需放在 Auth 之后
+func newRateLimiters(store middleware.RateLimitStore, cfg *config.Config) *rateLimiters {
+	policy := func(name string, rule config.RateLimitRule, key middleware.RateLimitKeyFunc) gin.HandlerFunc {
+		return middleware.RateLimit(store, middleware.RateLimitPolicy{
+			Name:   name,
+			Limit:  rule.Limit,
+			Period: rule.Period,
+			Key:    key,
+			Reject: rateLimited,
+		})
+	}
+
+	return &rateLimiters{
+		loginByIP:   policy("login:ip", cfg.RateLimitLogin, middleware.KeyByIP),
+		loginByName: policy("login:user", cfg.RateLimitLogin, middleware.KeyByUsername("user_name")),
+		register:    policy("register", cfg.RateLimitRegister, middleware.KeyByIP),
+		captcha:     policy("captcha", cfg.RateLimitCaptcha, middleware.KeyByIP),
+		message:     policy("message", cfg.RateLimitMessage, middleware.KeyByUser),
+		upload:      policy("upload", cfg.RateLimitUpload, middleware.KeyByUser),
+
+		passwordByUser: policy("password:user", cfg.RateLimitPassword, middleware.KeyByUser),
+		passwordByIP:   policy("password:ip", cfg.RateLimitPassword, middleware.KeyByIP),
+		mailByUser:     policy("mail:user", cfg.RateLimitMail, middleware.KeyByUser),
+		mailByEmail:    policy("mail:email", cfg.RateLimitMail, middleware.KeyByUsername("email")),
+	}
+}
+
+// rateLimited 超限时返回统一格式的 429 响应
+func rateLimited(c *gin.Context) {
+	handler.TooManyRequests(c, "请求过于频繁，请稍后再试")
+}
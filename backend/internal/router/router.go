@@ -1,8 +1,10 @@
 package router
 
 import (
+	"backend/internal/config"
 	"backend/internal/handler"
 	"backend/internal/middleware"
+	"backend/internal/model"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +22,20 @@ func Setup(r *gin.Engine) {
 	messageHandler := handler.NewMessageHandler()
 	visitorHandler := handler.NewVisitorHandler()
 	uploadHandler := handler.NewUploadHandler()
+	userHandler := handler.NewUserHandler()
+	tagHandler := handler.NewTagHandler()
+	categoryHandler := handler.NewCategoryHandler()
+	seriesHandler := handler.NewSeriesHandler()
+	commentHandler := handler.NewCommentHandler()
+	sensitiveWordHandler := handler.NewSensitiveWordHandler()
+	passwordHandler := handler.NewPasswordHandler()
+	emailHandler := handler.NewEmailHandler()
+
+	// 限流（单实例内存存储）
+	limits := newRateLimiters(middleware.NewMemoryRateLimitStore(), config.AppConfig)
+
+	// access token 验证公钥
+	r.GET("/.well-known/jwks.json", authHandler.JWKS)
 
 	// API v1 路由组
 	v1 := r.Group("/api/v1")
@@ -27,25 +43,37 @@ func Setup(r *gin.Engine) {
 		// 认证相关 - RESTful 风格
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/login", authHandler.Login)
+			auth.POST("/login", limits.loginByIP, limits.loginByName, authHandler.Login)
 			auth.POST("/logout", authHandler.Logout)
 			auth.POST("/refresh", authHandler.RefreshToken)
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/captcha", authHandler.GetCaptcha)
-			auth.POST("/captcha/verify", authHandler.CheckCaptcha)
+			auth.POST("/register", limits.register, authHandler.Register)
+			auth.POST("/captcha", limits.captcha, authHandler.GetCaptcha)
+			auth.POST("/captcha/verify", limits.captcha, authHandler.CheckCaptcha)
+			auth.POST("/password/reset", limits.passwordByIP, passwordHandler.Reset)
+			auth.POST("/password/forgot", limits.passwordByIP, limits.mailByEmail, passwordHandler.Forgot)
+			auth.POST("/email/verify", limits.passwordByIP, emailHandler.Verify)
 		}
 
 		// 文章相关 - RESTful 风格
 		articles := v1.Group("/articles")
 		{
-			articles.GET("/:id", articleHandler.GetArticle)        // GET /api/v1/articles/:id
-			articles.GET("", articleHandler.GetShow)               // GET /api/v1/articles
-			articles.GET("/hot", articleHandler.GetHot)            // GET /api/v1/articles/hot
-			articles.GET("/search", articleHandler.Search)         // GET /api/v1/articles/search?q=xxx
-			articles.GET("/info", articleHandler.GetInfo)          // GET /api/v1/articles/info
-			articles.GET("/extend", articleHandler.Extend)         // GET /api/v1/articles/extend
+			articles.GET("/:id", articleHandler.GetArticle)                 // GET /api/v1/articles/:id
+			articles.GET("/by-slug/:slug", articleHandler.GetArticleBySlug) // GET /api/v1/articles/by-slug/:slug
+			articles.GET("", articleHandler.GetShow)                        // GET /api/v1/articles
+			articles.GET("/hot", articleHandler.GetHot)                     // GET /api/v1/articles/hot
+			articles.GET("/search", articleHandler.Search)                  // GET /api/v1/articles/search?q=xxx
+			articles.GET("/info", articleHandler.GetInfo)                   // GET /api/v1/articles/info
+			articles.GET("/extend", articleHandler.Extend)                  // GET /api/v1/articles/extend
+			articles.GET("/:id/comments", commentHandler.List)              // GET /api/v1/articles/:id/comments
 		}
 
+		// 标签、分类与系列
+		v1.GET("/tags", tagHandler.List)                 // GET /api/v1/tags
+		v1.GET("/categories", categoryHandler.Tree)      // GET /api/v1/categories
+		v1.GET("/categories/:slug", categoryHandler.Get) // GET /api/v1/categories/:slug
+		v1.GET("/series", seriesHandler.List)            // GET /api/v1/series
+		v1.GET("/series/:slug", seriesHandler.Get)       // GET /api/v1/series/:slug
+
 		// 留言相关 - RESTful 风格
 		messages := v1.Group("/messages")
 		{
@@ -63,26 +91,89 @@ func Setup(r *gin.Engine) {
 		protected.Use(middleware.Auth())
 		{
 			// 留言提交
-			protected.POST("/messages", messageHandler.Commit)                    // POST /api/v1/messages
-			protected.POST("/messages/:id/replies", messageHandler.ReplyCommit)   // POST /api/v1/messages/:id/replies
+			protected.POST("/messages", limits.message, messageHandler.Commit)                  // POST /api/v1/messages
+			protected.POST("/messages/:id/replies", limits.message, messageHandler.ReplyCommit) // POST /api/v1/messages/:id/replies
+			protected.PATCH("/messages/:id", messageHandler.Update)                             // PATCH /api/v1/messages/:id
+			protected.DELETE("/messages/:id", messageHandler.Delete)                            // DELETE /api/v1/messages/:id
+			protected.PATCH("/messages/:id/replies/:replyId", messageHandler.UpdateReply)       // PATCH /api/v1/messages/:id/replies/:replyId
+			protected.DELETE("/messages/:id/replies/:replyId", messageHandler.DeleteReply)      // DELETE /api/v1/messages/:id/replies/:replyId
+
+			// 文章评论
+			protected.POST("/articles/:id/comments", middleware.RequirePermission(model.PermMessageWrite), limits.message, commentHandler.Create) // POST /api/v1/articles/:id/comments
+			protected.PATCH("/articles/:id/comments/:commentId", commentHandler.Update)                                                           // PATCH /api/v1/articles/:id/comments/:commentId
+			protected.DELETE("/articles/:id/comments/:commentId", commentHandler.Delete)                                                          // DELETE /api/v1/articles/:id/comments/:commentId
+
+			// 登录会话管理
+			protected.GET("/auth/sessions", authHandler.ListSessions)         // GET /api/v1/auth/sessions
+			protected.DELETE("/auth/sessions", authHandler.RevokeAllSessions) // DELETE /api/v1/auth/sessions?keep_current=true
+			protected.DELETE("/auth/sessions/:id", authHandler.RevokeSession) // DELETE /api/v1/auth/sessions/:id
+
+			// 修改密码
+			protected.PUT("/auth/password", limits.passwordByUser, passwordHandler.Change) // PUT /api/v1/auth/password
+
+			// 邮箱设置与验证
+			protected.PUT("/auth/email", limits.mailByUser, emailHandler.Change)                           // PUT /api/v1/auth/email
+			protected.POST("/auth/email/verification", limits.mailByUser, emailHandler.ResendVerification) // POST /api/v1/auth/email/verification
 
 			// 头像上传
-			protected.POST("/upload/avatar", uploadHandler.Avatar) // POST /api/v1/upload/avatar
+			protected.POST("/upload/avatar", limits.upload, uploadHandler.Avatar) // POST /api/v1/upload/avatar
+		}
+
+		// 需要特定角色/权限的路由
+		privileged := v1.Group("")
+		privileged.Use(middleware.Auth())
+		{
+			// 文章管理
+			privileged.POST("/articles", middleware.RequirePermission(model.PermArticleCreate), articleHandler.Create)                 // POST /api/v1/articles
+			privileged.PUT("/articles/:id", middleware.RequirePermission(model.PermArticleEdit), articleHandler.Update)                // PUT /api/v1/articles/:id
+			privileged.PATCH("/articles/:id", middleware.RequirePermission(model.PermArticleEdit), articleHandler.Patch)               // PATCH /api/v1/articles/:id
+			privileged.PATCH("/articles/:id/status", middleware.RequirePermission(model.PermArticleEdit), articleHandler.ChangeStatus) // PATCH /api/v1/articles/:id/status
+			privileged.DELETE("/articles/:id", middleware.RequirePermission(model.PermArticleDelete), articleHandler.Delete)           // DELETE /api/v1/articles/:id
+			privileged.GET("/manage/articles", middleware.RequirePermission(model.PermArticleEdit), articleHandler.ManageList)         // GET /api/v1/manage/articles?status=draft
+			privileged.GET("/manage/articles/:id", middleware.RequirePermission(model.PermArticleEdit), articleHandler.ManageGet)      // GET /api/v1/manage/articles/:id
+
+			// 标签管理
+			privileged.PUT("/tags/:slug", middleware.RequirePermission(model.PermArticleEdit), tagHandler.UpdateDescription) // PUT /api/v1/tags/:slug
+
+			// 分类与系列管理
+			privileged.POST("/categories", middleware.RequirePermission(model.PermArticleEdit), categoryHandler.Create)            // POST /api/v1/categories
+			privileged.PATCH("/categories/:id", middleware.RequirePermission(model.PermArticleEdit), categoryHandler.Update)       // PATCH /api/v1/categories/:id
+			privileged.DELETE("/categories/:id", middleware.RequirePermission(model.PermArticleDelete), categoryHandler.Delete)    // DELETE /api/v1/categories/:id
+			privileged.POST("/series", middleware.RequirePermission(model.PermArticleEdit), seriesHandler.Create)                  // POST /api/v1/series
+			privileged.PATCH("/series/:id", middleware.RequirePermission(model.PermArticleEdit), seriesHandler.Update)             // PATCH /api/v1/series/:id
+			privileged.PUT("/series/:id/articles", middleware.RequirePermission(model.PermArticleEdit), seriesHandler.SetArticles) // PUT /api/v1/series/:id/articles
+			privileged.DELETE("/series/:id", middleware.RequirePermission(model.PermArticleDelete), seriesHandler.Delete)          // DELETE /api/v1/series/:id
+
+			// 留言审核
+			privileged.GET("/moderation/messages", middleware.RequirePermission(model.PermMessageModerate), messageHandler.ModerationQueue)                      // GET /api/v1/moderation/messages
+			privileged.PATCH("/moderation/messages/:id", middleware.RequirePermission(model.PermMessageModerate), messageHandler.Moderate)                       // PATCH /api/v1/moderation/messages/:id
+			privileged.PATCH("/moderation/messages/:id/replies/:replyId", middleware.RequirePermission(model.PermMessageModerate), messageHandler.ModerateReply) // PATCH /api/v1/moderation/messages/:id/replies/:replyId
+
+			// 敏感词管理
+			privileged.GET("/sensitive-words", middleware.RequirePermission(model.PermMessageModerate), sensitiveWordHandler.List)          // GET /api/v1/sensitive-words
+			privileged.POST("/sensitive-words", middleware.RequirePermission(model.PermMessageModerate), sensitiveWordHandler.Create)       // POST /api/v1/sensitive-words
+			privileged.PATCH("/sensitive-words/:id", middleware.RequirePermission(model.PermMessageModerate), sensitiveWordHandler.Update)  // PATCH /api/v1/sensitive-words/:id
+			privileged.DELETE("/sensitive-words/:id", middleware.RequirePermission(model.PermMessageModerate), sensitiveWordHandler.Delete) // DELETE /api/v1/sensitive-words/:id
+
+			// 用户管理
+			privileged.POST("/users/:id/disable", middleware.RequirePermission(model.PermUserManage), userHandler.Disable)               // POST /api/v1/users/:id/disable
+			privileged.POST("/users/:id/enable", middleware.RequirePermission(model.PermUserManage), userHandler.Enable)                 // POST /api/v1/users/:id/enable
+			privileged.POST("/users/:id/password-reset", middleware.RequirePermission(model.PermUserManage), passwordHandler.IssueReset) // POST /api/v1/users/:id/password-reset
 		}
 	}
 
 	// 兼容旧版 API 路由（可选，建议逐步迁移后移除）
-	setupLegacyRoutes(r, authHandler, articleHandler, messageHandler, visitorHandler, uploadHandler)
+	setupLegacyRoutes(r, limits, authHandler, articleHandler, messageHandler, visitorHandler, uploadHandler)
 }
 
 // setupLegacyRoutes 设置旧版兼容路由，便于前端逐步迁移
-func setupLegacyRoutes(r *gin.Engine, authHandler *handler.AuthHandler, articleHandler *handler.ArticleHandler,
+func setupLegacyRoutes(r *gin.Engine, limits *rateLimiters, authHandler *handler.AuthHandler, articleHandler *handler.ArticleHandler,
 	messageHandler *handler.MessageHandler, visitorHandler *handler.VisitorHandler, uploadHandler *handler.UploadHandler) {
 
 	// 公开路由 - 登录相关（旧版）
 	login := r.Group("/login")
 	{
-		login.POST("", authHandler.Login)
+		login.POST("", limits.loginByIP, limits.loginByName, authHandler.Login)
 		login.POST("/logout", authHandler.Logout)
 		login.POST("/refresh", authHandler.RefreshToken)
 	}
@@ -90,9 +181,9 @@ func setupLegacyRoutes(r *gin.Engine, authHandler *handler.AuthHandler, articleH
 	// 公开路由 - 注册相关（旧版）
 	register := r.Group("/register")
 	{
-		register.POST("", authHandler.Register)
-		register.POST("/captcha", authHandler.GetCaptcha)
-		register.POST("/check_captcha", authHandler.CheckCaptcha)
+		register.POST("", limits.register, authHandler.Register)
+		register.POST("/captcha", limits.captcha, authHandler.GetCaptcha)
+		register.POST("/check_captcha", limits.captcha, authHandler.CheckCaptcha)
 	}
 
 	// 公开路由 - 文章相关（旧版）
@@ -116,8 +207,8 @@ func setupLegacyRoutes(r *gin.Engine, authHandler *handler.AuthHandler, articleH
 	auth := r.Group("")
 	auth.Use(middleware.Auth())
 	{
-		auth.POST("/message/commit", messageHandler.CommitLegacy)
-		auth.POST("/message/reply_commit", messageHandler.ReplyCommitLegacy)
-		auth.POST("/upload/avatar", uploadHandler.Avatar)
+		auth.POST("/message/commit", limits.message, messageHandler.CommitLegacy)
+		auth.POST("/message/reply_commit", limits.message, messageHandler.ReplyCommitLegacy)
+		auth.POST("/upload/avatar", limits.upload, uploadHandler.Avatar)
 	}
 }
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	"backend/internal/logger"
+	"backend/internal/model"
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccessTokenDenylist access token 黑名单：内存中保存已吊销且未过期的 jti 供每个请求快速检查，
+// 同时持久化到 MongoDB（TTL 索引按过期时间清理），并定期同步其他实例写入的记录
+type AccessTokenDenylist struct {
+	mu       sync.RWMutex
+	entries  map[string]time.Time
+	dao      *dao.RevokedTokenDAO
+	interval time.Duration
+}
+
+var (
+	accessTokenDenylist     *AccessTokenDenylist
+	accessTokenDenylistOnce sync.Once
+)
+
+// GetAccessTokenDenylist 获取全局黑名单（单例，保证吊销操作对本进程所有请求立即生效）
+func GetAccessTokenDenylist() *AccessTokenDenylist {
+	accessTokenDenylistOnce.Do(func() {
+		accessTokenDenylist = &AccessTokenDenylist{
+			entries:  make(map[string]time.Time),
+			dao:      dao.NewRevokedTokenDAO(),
+			interval: config.AppConfig.AccessTokenDenylistSync,
+		}
+	})
+	return accessTokenDenylist
+}
+
+// Start 加载持久化的黑名单并在后台定期同步，ctx 取消后退出
+func (d *AccessTokenDenylist) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.Sync(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("同步 access token 黑名单失败", logger.Err(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync 从数据库重新加载未过期的记录
+func (d *AccessTokenDenylist) Sync(ctx context.Context) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	tokens, err := d.dao.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]time.Time, len(tokens))
+	for _, t := range tokens {
+		entries[t.JTI] = t.ExpiresAt
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// 保留本进程刚写入、可能尚未被查询到的记录
+	now := time.Now()
+	for jti, expiresAt := range d.entries {
+		if _, ok := entries[jti]; !ok && now.Before(expiresAt) {
+			entries[jti] = expiresAt
+		}
+	}
+	d.entries = entries
+	return nil
+}
+
+// Contains 检查 jti 是否已被吊销
+func (d *AccessTokenDenylist) Contains(jti string) bool {
+	if jti == "" {
+		return false
+	}
+	d.mu.RLock()
+	expiresAt, ok := d.entries[jti]
+	d.mu.RUnlock()
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Revoke 将 access token 加入黑名单，已过期的忽略
+func (d *AccessTokenDenylist) Revoke(ctx context.Context, tokens ...model.RevokedAccessToken) error {
+	now := time.Now()
+	live := make([]model.RevokedAccessToken, 0, len(tokens))
+	for _, t := range tokens {
+		if t.JTI == "" || !now.Before(t.ExpiresAt) {
+			continue
+		}
+		t.RevokedAt = now
+		live = append(live, t)
+	}
+	if len(live) == 0 {
+		return nil
+	}
+
+	// 先更新内存，数据库写入失败时本实例仍然生效
+	d.mu.Lock()
+	for _, t := range live {
+		d.entries[t.JTI] = t.ExpiresAt
+	}
+	d.mu.Unlock()
+
+	return d.dao.Add(ctx, live)
+}
+
+// RevokeIssued 吊销与 refresh token 一同签发、尚未过期的 access token，keepFamily 所属会话除外
+func (d *AccessTokenDenylist) RevokeIssued(ctx context.Context, tokens []model.RefreshToken, keepFamily primitive.ObjectID) error {
+	var revoked []model.RevokedAccessToken
+	for _, t := range tokens {
+		if t.AccessJTI == "" || t.AccessExpiresAt == nil {
+			continue
+		}
+		if !keepFamily.IsZero() && t.Family() == keepFamily {
+			continue
+		}
+		revoked = append(revoked, model.RevokedAccessToken{
+			JTI:       t.AccessJTI,
+			UserID:    t.UserID,
+			ExpiresAt: *t.AccessExpiresAt,
+		})
+	}
+	return d.Revoke(ctx, revoked...)
+}
+
+// revokeFamilyAccess 吊销会话中仍然有效的 access token
+func revokeFamilyAccess(ctx context.Context, tokenDAO *dao.TokenDAO, denylist *AccessTokenDenylist, familyID primitive.ObjectID) error {
+	tokens, err := tokenDAO.FindLiveAccessByFamily(ctx, familyID)
+	if err != nil {
+		return err
+	}
+	return denylist.RevokeIssued(ctx, tokens, primitive.NilObjectID)
+}
+
+// revokeUserAccess 吊销用户仍然有效的 access token，keepFamily 所属会话除外
+func revokeUserAccess(ctx context.Context, tokenDAO *dao.TokenDAO, denylist *AccessTokenDenylist, userID, keepFamily primitive.ObjectID) error {
+	tokens, err := tokenDAO.FindLiveAccessByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return denylist.RevokeIssued(ctx, tokens, keepFamily)
+}
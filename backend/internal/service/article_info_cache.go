@@ -0,0 +1,68 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/model"
+	"sync"
+	"time"
+)
+
+// ArticleInfoCache 站点统计信息缓存，写操作调用 Invalidate 使其立即失效
+type ArticleInfoCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	info      *model.ArticleInfo
+	expiresAt time.Time
+	// version 每次失效递增，避免失效前开始的聚合结果在失效后写回缓存
+	version uint64
+}
+
+var (
+	articleInfoCache     *ArticleInfoCache
+	articleInfoCacheOnce sync.Once
+)
+
+// GetArticleInfoCache 获取全局站点统计缓存（单例，保证各服务的写操作能使同一份缓存失效）
+func GetArticleInfoCache() *ArticleInfoCache {
+	articleInfoCacheOnce.Do(func() {
+		articleInfoCache = NewArticleInfoCache(config.AppConfig.ArticleInfoCacheTTL)
+	})
+	return articleInfoCache
+}
+
+// NewArticleInfoCache 创建站点统计缓存
+func NewArticleInfoCache(ttl time.Duration) *ArticleInfoCache {
+	return &ArticleInfoCache{ttl: ttl}
+}
+
+// Get 获取缓存的统计信息，同时返回当前版本号供 Set 使用；ok 为 false 表示未命中或已过期
+func (c *ArticleInfoCache) Get() (info *model.ArticleInfo, version uint64, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.info == nil || time.Now().After(c.expiresAt) {
+		return nil, c.version, false
+	}
+	return c.info, c.version, true
+}
+
+// Set 写入统计信息，version 与当前版本不一致（期间发生过失效）时忽略
+func (c *ArticleInfoCache) Set(info *model.ArticleInfo, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version {
+		return
+	}
+	c.info = info
+	c.expiresAt = time.Now().Add(c.ttl)
+}
+
+// Invalidate 使缓存失效
+func (c *ArticleInfoCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.info = nil
+	c.version++
+}
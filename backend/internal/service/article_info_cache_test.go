@@ -0,0 +1,108 @@
+package service
+
+import (
+	"backend/internal/model"
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeInfoAggregator 返回 articles 篇文章的统计信息，onAggregate 在聚合过程中调用
+type fakeInfoAggregator struct {
+	articles    int64
+	aggregates  int
+	onAggregate func()
+}
+
+func (s *fakeInfoAggregator) AggregateInfo(ctx context.Context) (*model.ArticleInfo, error) {
+	s.aggregates++
+	if s.onAggregate != nil {
+		s.onAggregate()
+	}
+	return &model.ArticleInfo{TotalCount: s.articles}, nil
+}
+
+// fakeMessageStats 固定 3 条留言、1 条回复
+type fakeMessageStats struct{}
+
+func (fakeMessageStats) CountStats(ctx context.Context) (int64, int64, error) {
+	return 3, 1, nil
+}
+
+// fakeUserCounter 固定 2 个用户
+type fakeUserCounter struct{}
+
+func (fakeUserCounter) Count(ctx context.Context) (int64, error) {
+	return 2, nil
+}
+
+func TestArticleInfoCache_InvalidateBumpsVersion(t *testing.T) {
+	cache := NewArticleInfoCache(time.Hour)
+	_, version, _ := cache.Get()
+	cache.Set(&model.ArticleInfo{TotalCount: 1}, version)
+
+	if info, _, ok := cache.Get(); !ok || info.TotalCount != 1 {
+		t.Fatalf("写入后应命中缓存, 实际 %v, %v", info, ok)
+	}
+
+	cache.Invalidate()
+	_, newVersion, ok := cache.Get()
+	if ok {
+		t.Error("失效后不应命中缓存")
+	}
+	if newVersion != version+1 {
+		t.Errorf("失效后版本号应递增, 期望 %d, 实际 %d", version+1, newVersion)
+	}
+
+	// 失效前读到的版本号写回时被忽略
+	cache.Set(&model.ArticleInfo{TotalCount: 1}, version)
+	if _, _, ok := cache.Get(); ok {
+		t.Error("旧版本的统计信息不应写回缓存")
+	}
+}
+
+func TestLoadArticleInfo(t *testing.T) {
+	ctx := context.Background()
+	cache := NewArticleInfoCache(time.Hour)
+	store := &fakeInfoAggregator{articles: 1}
+
+	info, err := loadArticleInfo(ctx, cache, store, fakeMessageStats{}, fakeUserCounter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.TotalCount != 1 || info.MessageCount != 3 || info.ReplyCount != 1 || info.UserCount != 2 {
+		t.Errorf("统计信息不正确: %+v", info)
+	}
+	if _, err := loadArticleInfo(ctx, cache, store, fakeMessageStats{}, fakeUserCounter{}); err != nil || store.aggregates != 1 {
+		t.Errorf("缓存有效时不应重新聚合, 聚合 %d 次, 错误 %v", store.aggregates, err)
+	}
+
+	// 写操作使缓存失效后重新聚合
+	store.articles = 2
+	cache.Invalidate()
+	if info, _ := loadArticleInfo(ctx, cache, store, fakeMessageStats{}, fakeUserCounter{}); info.TotalCount != 2 || store.aggregates != 2 {
+		t.Errorf("失效后应重新聚合, 实际 %+v, 聚合 %d 次", info, store.aggregates)
+	}
+}
+
+func TestLoadArticleInfo_InvalidatedDuringAggregate(t *testing.T) {
+	ctx := context.Background()
+	cache := NewArticleInfoCache(time.Hour)
+	store := &fakeInfoAggregator{articles: 1}
+	// 聚合过程中发生写操作，聚合结果可能已过时
+	store.onAggregate = func() {
+		store.onAggregate = nil
+		cache.Invalidate()
+	}
+
+	if info, err := loadArticleInfo(ctx, cache, store, fakeMessageStats{}, fakeUserCounter{}); err != nil || info.TotalCount != 1 {
+		t.Fatalf("本次请求应返回聚合结果, 实际 %+v, %v", info, err)
+	}
+	if _, _, ok := cache.Get(); ok {
+		t.Error("失效前开始的聚合结果不应写回缓存")
+	}
+	store.articles = 2
+	if info, _ := loadArticleInfo(ctx, cache, store, fakeMessageStats{}, fakeUserCounter{}); info.TotalCount != 2 {
+		t.Errorf("下一次请求应重新聚合, 实际 %+v", info)
+	}
+}
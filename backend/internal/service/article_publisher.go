@@ -0,0 +1,72 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	"backend/internal/logger"
+	"context"
+	"time"
+)
+
+// ArticlePublisher 定时发布任务：周期性地把到期的定时文章标记为已发布
+type ArticlePublisher struct {
+	articleDAO *dao.ArticleDAO
+	tagDAO     *dao.TagDAO
+	infoCache  *ArticleInfoCache
+	interval   time.Duration
+}
+
+// NewArticlePublisher 创建定时发布任务
+func NewArticlePublisher() *ArticlePublisher {
+	return &ArticlePublisher{
+		articleDAO: dao.NewArticleDAO(),
+		tagDAO:     dao.NewTagDAO(),
+		infoCache:  GetArticleInfoCache(),
+		interval:   config.AppConfig.ArticlePublishInterval,
+	}
+}
+
+// Start 在后台运行定时发布任务，ctx 取消后退出
+func (p *ArticlePublisher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := p.PublishDue(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("定时发布文章失败", logger.Err(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// PublishDue 发布所有已到期的定时文章，使站点统计缓存失效并同步标签计数，返回发布数量
+func (p *ArticlePublisher) PublishDue(ctx context.Context) (int, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	published, err := p.articleDAO.PublishDue(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if len(published) == 0 {
+		return 0, nil
+	}
+
+	var tags []string
+	for _, a := range published {
+		tags = append(tags, a.GetTags()...)
+	}
+	p.infoCache.Invalidate()
+	if err := syncTags(ctx, p.articleDAO, p.tagDAO, tags); err != nil {
+		return 0, err
+	}
+	logger.Info("定时文章已发布", logger.Int("count", len(published)))
+	return len(published), nil
+}
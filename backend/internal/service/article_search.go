@@ -0,0 +1,112 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/highlight"
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// 搜索关键词最大长度（字符）与最多词数
+	maxSearchKeywordsLength = 100
+	maxSearchTerms          = 10
+	// 单页最大条数
+	maxSearchLimit = 50
+	// 摘要片段长度（字符）
+	searchSnippetLength = 120
+	// 每次补建索引处理的最大文章数
+	indexBatchSize = 500
+)
+
+// SearchArticles 全文搜索文章，返回带相关度评分和高亮片段的分页结果。
+// 关键词与文章使用同一个分词器切分，因此中文无需空格分隔也能命中
+func (s *ArticleService) SearchArticles(ctx context.Context, query *model.ArticleSearchQuery) (*model.ArticleSearchResult, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	terms := s.searchTerms(query.Keywords)
+	if len(terms) == 0 {
+		return nil, apperrors.InvalidParamsError("请传入关键词参数")
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return nil, apperrors.InvalidParamsError("开始日期必须早于结束日期")
+	}
+	if query.Skip < 0 {
+		query.Skip = 0
+	}
+	if query.Limit <= 0 || query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	result := &model.ArticleSearchResult{
+		List:  []model.ArticleSearchHit{},
+		Skip:  query.Skip,
+		Limit: query.Limit,
+	}
+
+	hits, total, err := s.termDAO.Search(ctx, terms, query)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	result.Total = total
+	if len(hits) == 0 {
+		return result, nil
+	}
+
+	matcher := highlight.NewMatcher(terms)
+	for i := range hits {
+		hits[i].TitleHighlight = matcher.Mark(hits[i].Title)
+		hits[i].Snippet = matcher.Snippet(hits[i].Content, searchSnippetLength)
+	}
+	result.List = hits
+	return result, nil
+}
+
+// IndexMissing 为尚未建立分词索引的文章补建索引，返回处理数量
+func (s *ArticleService) IndexMissing(ctx context.Context) (int, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	indexed, err := s.termDAO.FindIndexedIDs(ctx)
+	if err != nil {
+		return 0, apperrors.ServerError(err)
+	}
+	articles, err := s.articleDAO.FindForIndex(ctx, indexed, indexBatchSize)
+	if err != nil {
+		return 0, apperrors.ServerError(err)
+	}
+
+	for i := range articles {
+		if err := s.indexArticle(ctx, &articles[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(articles), nil
+}
+
+// searchTerms 切分查询关键词并限制长度和词数
+func (s *ArticleService) searchTerms(keywords string) []string {
+	if utf8.RuneCountInString(keywords) > maxSearchKeywordsLength {
+		keywords = string([]rune(keywords)[:maxSearchKeywordsLength])
+	}
+
+	terms := s.tokenizer.Tokenize(keywords)
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return terms
+}
+
+// indexArticle 为文章建立分词索引，标签并入标题词以获得更高权重
+func (s *ArticleService) indexArticle(ctx context.Context, article *model.Article) error {
+	titleTerms := s.tokenizer.Tokenize(article.Title + " " + strings.Join(article.GetTags(), " "))
+	contentTerms := s.tokenizer.Tokenize(highlight.StripHTML(article.Content))
+	if err := s.termDAO.Upsert(ctx, article.ID, titleTerms, contentTerms); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
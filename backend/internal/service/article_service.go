@@ -4,27 +4,66 @@ import (
 	"backend/internal/dao"
 	apperrors "backend/internal/errors"
 	"backend/internal/model"
+	"backend/pkg/markdown"
+	"backend/pkg/segment"
+	"backend/pkg/slug"
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// slug 冲突时追加数字后缀的最大尝试次数
+const maxSlugAttempts = 20
+
 // ArticleService 文章服务实现
 type ArticleService struct {
-	articleDAO *dao.ArticleDAO
+	articleDAO  *dao.ArticleDAO
+	termDAO     *dao.ArticleTermDAO
+	tagDAO      *dao.TagDAO
+	categoryDAO *dao.CategoryDAO
+	seriesDAO   *dao.SeriesDAO
+	messageDAO  *dao.MessageDAO
+	userDAO     *dao.UserDAO
+	commentDAO  *dao.CommentDAO
+	tokenizer   segment.Tokenizer
+	infoCache   *ArticleInfoCache
 }
 
 // NewArticleService 创建文章服务
 func NewArticleService() *ArticleService {
 	return &ArticleService{
-		articleDAO: dao.NewArticleDAO(),
+		articleDAO:  dao.NewArticleDAO(),
+		termDAO:     dao.NewArticleTermDAO(),
+		tagDAO:      dao.NewTagDAO(),
+		categoryDAO: dao.NewCategoryDAO(),
+		seriesDAO:   dao.NewSeriesDAO(),
+		messageDAO:  dao.NewMessageDAO(),
+		userDAO:     dao.NewUserDAO(),
+		commentDAO:  dao.NewCommentDAO(),
+		tokenizer:   segment.Default(),
+		infoCache:   GetArticleInfoCache(),
 	}
 }
 
-// NewArticleServiceWithDAO 使用指定的 DAO 创建文章服务（用于测试）
-func NewArticleServiceWithDAO(articleDAO *dao.ArticleDAO) *ArticleService {
+// NewArticleServiceWithDAO 使用指定的 DAO 和分词器创建文章服务（用于测试）
+func NewArticleServiceWithDAO(articleDAO *dao.ArticleDAO, termDAO *dao.ArticleTermDAO, tagDAO *dao.TagDAO,
+	categoryDAO *dao.CategoryDAO, seriesDAO *dao.SeriesDAO, messageDAO *dao.MessageDAO, userDAO *dao.UserDAO,
+	commentDAO *dao.CommentDAO, tokenizer segment.Tokenizer, infoCache *ArticleInfoCache) *ArticleService {
 	return &ArticleService{
-		articleDAO: articleDAO,
+		articleDAO:  articleDAO,
+		termDAO:     termDAO,
+		tagDAO:      tagDAO,
+		categoryDAO: categoryDAO,
+		seriesDAO:   seriesDAO,
+		messageDAO:  messageDAO,
+		userDAO:     userDAO,
+		commentDAO:  commentDAO,
+		tokenizer:   tokenizer,
+		infoCache:   infoCache,
 	}
 }
 
@@ -33,13 +72,128 @@ func (s *ArticleService) GetByID(ctx context.Context, id primitive.ObjectID) (*m
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	article, err := s.articleDAO.FindByID(ctx, id)
+	article, err := s.articleDAO.FindPublicByID(ctx, id)
 	if err != nil {
 		return nil, apperrors.WrapMongoError(err, "文章")
 	}
+	if err := ensureRendered(article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// GetManaged 获取任意状态的文章（管理后台编辑使用），只有作者或拥有 article:manage 权限的用户可以查看
+func (s *ArticleService) GetManaged(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	article, err := s.authorizedArticle(ctx, id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureRendered(article); err != nil {
+		return nil, err
+	}
 	return article, nil
 }
 
+// ListManaged 管理后台文章列表，包括草稿、定时和归档文章；没有 article:manage 权限时只返回自己的文章
+func (s *ArticleService) ListManaged(ctx context.Context, userID primitive.ObjectID, role, status string, skip, limit int64) ([]model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	switch status {
+	case "", model.ArticleStatusDraft, model.ArticleStatusScheduled, model.ArticleStatusPublished, model.ArticleStatusArchived:
+	default:
+		return nil, apperrors.InvalidParamsError("无效的文章状态")
+	}
+
+	var authorID *primitive.ObjectID
+	if !model.HasPermission(role, model.PermArticleManage) {
+		authorID = &userID
+	}
+	articles, err := s.articleDAO.FindManaged(ctx, authorID, status, skip, limit)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return articles, nil
+}
+
+// GetDetail 为文章补充分类、系列位置和上一篇/下一篇导航
+func (s *ArticleService) GetDetail(ctx context.Context, article *model.Article) (*model.ArticleDetail, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	detail := &model.ArticleDetail{Article: *article}
+
+	if article.CategoryID != nil {
+		category, err := s.categoryDAO.FindByID(ctx, *article.CategoryID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, apperrors.ServerError(err)
+		}
+		detail.Category = category
+	}
+
+	if article.SeriesID != nil {
+		nav, err := s.seriesNav(ctx, article)
+		if err != nil {
+			return nil, err
+		}
+		detail.Series = nav
+	}
+
+	var err error
+	if detail.Prev, err = s.adjacent(ctx, article, false); err != nil {
+		return nil, err
+	}
+	if detail.Next, err = s.adjacent(ctx, article, true); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// seriesNav 计算文章在系列中的位置，系列已被删除时返回 nil
+func (s *ArticleService) seriesNav(ctx context.Context, article *model.Article) (*model.SeriesNav, error) {
+	series, err := s.seriesDAO.FindByID(ctx, *article.SeriesID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	articles, err := s.articleDAO.FindSeriesArticles(ctx, series.ID)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+
+	nav := &model.SeriesNav{ID: series.ID, Title: series.Title, Slug: series.Slug, Total: len(articles)}
+	for i := range articles {
+		if articles[i].ID != article.ID {
+			continue
+		}
+		nav.Part = i + 1
+		if i > 0 {
+			nav.Prev = &articles[i-1]
+		}
+		if i < len(articles)-1 {
+			nav.Next = &articles[i+1]
+		}
+	}
+	return nav, nil
+}
+
+// adjacent 查找相邻文章，没有时返回 nil
+func (s *ArticleService) adjacent(ctx context.Context, article *model.Article, newer bool) (*model.ArticleBrief, error) {
+	brief, err := s.articleDAO.FindAdjacent(ctx, article, newer)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	return brief, nil
+}
+
 // GetHot 获取热门文章
 func (s *ArticleService) GetHot(ctx context.Context, limit int64) ([]model.Article, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
@@ -53,11 +207,19 @@ func (s *ArticleService) GetHot(ctx context.Context, limit int64) ([]model.Artic
 }
 
 // GetList 获取文章列表
-func (s *ArticleService) GetList(ctx context.Context, tag string, skip, limit int64) ([]model.Article, error) {
+func (s *ArticleService) GetList(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	articles, err := s.articleDAO.FindList(ctx, tag, skip, limit)
+	if filter != nil && filter.Category != "" {
+		ids, err := s.categoryIDs(ctx, filter.Category)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryIDs = ids
+	}
+
+	articles, err := s.articleDAO.FindList(ctx, filter, skip, limit)
 	if err != nil {
 		return nil, apperrors.ServerError(err)
 	}
@@ -77,26 +239,64 @@ func (s *ArticleService) Search(ctx context.Context, keywords string, limit int6
 }
 
 // GetExtend 获取扩展文章
-func (s *ArticleService) GetExtend(ctx context.Context, tag string, limit int64) ([]model.ArticleBrief, error) {
+func (s *ArticleService) GetExtend(ctx context.Context, filter *model.ArticleFilter, limit int64) ([]model.ArticleBrief, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	articles, err := s.articleDAO.FindExtend(ctx, tag, limit)
+	articles, err := s.articleDAO.FindExtend(ctx, filter, limit)
 	if err != nil {
 		return nil, apperrors.ServerError(err)
 	}
 	return articles, nil
 }
 
-// GetInfo 获取文章统计信息
+// GetInfo 获取站点统计信息，优先使用缓存
 func (s *ArticleService) GetInfo(ctx context.Context) (*model.ArticleInfo, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	info, err := s.articleDAO.GetInfo(ctx)
+	return loadArticleInfo(ctx, s.infoCache, s.articleDAO, s.messageDAO, s.userDAO)
+}
+
+// articleInfoAggregator、messageStatsCounter、userCounter 为 loadArticleInfo 依赖的 DAO 方法
+type articleInfoAggregator interface {
+	AggregateInfo(ctx context.Context) (*model.ArticleInfo, error)
+}
+
+type messageStatsCounter interface {
+	CountStats(ctx context.Context) (int64, int64, error)
+}
+
+type userCounter interface {
+	Count(ctx context.Context) (int64, error)
+}
+
+var (
+	_ articleInfoAggregator = (*dao.ArticleDAO)(nil)
+	_ messageStatsCounter   = (*dao.MessageDAO)(nil)
+	_ userCounter           = (*dao.UserDAO)(nil)
+)
+
+// loadArticleInfo 缓存未命中时重新聚合统计信息；聚合期间缓存被写操作失效时结果不写回缓存
+func loadArticleInfo(ctx context.Context, cache *ArticleInfoCache, articles articleInfoAggregator,
+	messages messageStatsCounter, users userCounter) (*model.ArticleInfo, error) {
+	info, version, ok := cache.Get()
+	if ok {
+		return info, nil
+	}
+
+	info, err := articles.AggregateInfo(ctx)
 	if err != nil {
-		return nil, apperrors.WrapMongoError(err, "文章信息")
+		return nil, apperrors.ServerError(err)
 	}
+	if info.MessageCount, info.ReplyCount, err = messages.CountStats(ctx); err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if info.UserCount, err = users.Count(ctx); err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+
+	cache.Set(info, version)
 	return info, nil
 }
 
@@ -108,5 +308,401 @@ func (s *ArticleService) IncrementPageViews(ctx context.Context, id primitive.Ob
 	return s.articleDAO.IncrementPageViews(ctx, id)
 }
 
+// Create 创建文章
+func (s *ArticleService) Create(ctx context.Context, article *model.Article) (*model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	article.ArticleType = strings.TrimSpace(article.ArticleType)
+	article.Title = strings.TrimSpace(article.Title)
+	article.Tags = normalizeTags(article.GetTags())
+	article.CoverImage = strings.TrimSpace(article.CoverImage)
+	article.Slug = strings.TrimSpace(article.Slug)
+	if article.Title == "" || len(article.Tags) == 0 || article.ArticleType == "" {
+		return nil, apperrors.InvalidParamsError("文章标题、类型和标签不能为空")
+	}
+	if len(article.Tags) > maxArticleTags {
+		return nil, apperrors.InvalidParamsError(fmt.Sprintf("文章标签不能超过%d个", maxArticleTags))
+	}
+	article.Tag = article.Tags[0]
+	if strings.TrimSpace(article.Content) == "" {
+		return nil, apperrors.InvalidParamsError("文章内容不能为空")
+	}
+	if err := s.checkCategory(ctx, article.CategoryID); err != nil {
+		return nil, err
+	}
+	doc, err := markdown.Render(article.Content)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	article.ContentHTML = doc.HTML
+	article.TOC = doc.TOC
+	article.ID = primitive.NewObjectID()
+	article.PageViews = 0
+	// 系列归属由系列接口维护
+	article.SeriesID = nil
+	article.SeriesOrder = 0
+
+	if article.Slug != "" {
+		if err := s.checkSlug(ctx, article.Slug, article.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		generated, err := s.uniqueSlug(ctx, article.Title, article.ID)
+		if err != nil {
+			return nil, err
+		}
+		article.Slug = generated
+	}
+
+	now := time.Now()
+	switch article.Status {
+	case "", model.ArticleStatusPublished:
+		article.Status = model.ArticleStatusPublished
+		article.PublishAt = now
+	case model.ArticleStatusDraft:
+		article.PublishAt = time.Time{}
+	case model.ArticleStatusScheduled:
+		if !article.PublishAt.After(now) {
+			return nil, apperrors.InvalidParamsError("定时发布时间必须晚于当前时间")
+		}
+	default:
+		return nil, apperrors.InvalidParamsError("无效的文章状态")
+	}
+
+	created, err := s.articleDAO.Create(ctx, article)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("文章 slug 已被占用")
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	s.infoCache.Invalidate()
+	if err := syncTags(ctx, s.articleDAO, s.tagDAO, created.Tags); err != nil {
+		return nil, err
+	}
+	if err := s.indexArticle(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Update 更新文章，update 中为 nil 的字段保持不变；只有作者或拥有 article:manage 权限的用户可以修改
+func (s *ArticleService) Update(ctx context.Context, id, userID primitive.ObjectID, role string, update *model.ArticleUpdate) (*model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := normalizeArticleUpdate(update); err != nil {
+		return nil, err
+	}
+	if update.CategoryID != nil && !update.CategoryID.IsZero() {
+		if err := s.checkCategory(ctx, update.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if update.Content != nil {
+		doc, err := markdown.Render(*update.Content)
+		if err != nil {
+			return nil, apperrors.ServerError(err)
+		}
+		update.ContentHTML = &doc.HTML
+		update.TOC = doc.TOC
+	}
+
+	current, err := s.authorizedArticle(ctx, id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	oldTags := current.GetTags()
+	if err := s.resolveSlugUpdate(ctx, current, update); err != nil {
+		return nil, err
+	}
+
+	article, err := s.articleDAO.Update(ctx, id, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("文章 slug 已被占用")
+		}
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+	s.infoCache.Invalidate()
+	if update.Tags != nil {
+		// 新旧标签的计数都可能变化
+		if err := syncTags(ctx, s.articleDAO, s.tagDAO, append(oldTags, article.Tags...)); err != nil {
+			return nil, err
+		}
+	}
+	if update.Title != nil || update.Content != nil || update.Tags != nil {
+		if err := s.indexArticle(ctx, article); err != nil {
+			return nil, err
+		}
+	}
+	return article, nil
+}
+
+// resolveSlugUpdate 标题或 slug 变化时重新确定 slug，旧 slug 保留用于重定向
+func (s *ArticleService) resolveSlugUpdate(ctx context.Context, current *model.Article, update *model.ArticleUpdate) error {
+	if update.Title == nil && update.Slug == nil {
+		return nil
+	}
+
+	id := current.ID
+	newSlug := current.Slug
+	switch {
+	case update.Slug != nil && *update.Slug != "":
+		newSlug = *update.Slug
+		if newSlug != current.Slug {
+			if err := s.checkSlug(ctx, newSlug, id); err != nil {
+				return err
+			}
+		}
+	case update.Title != nil && *update.Title != current.Title:
+		var err error
+		if newSlug, err = s.uniqueSlug(ctx, *update.Title, id); err != nil {
+			return err
+		}
+	}
+
+	update.Slug, update.OldSlug = nil, nil
+	if newSlug != current.Slug {
+		update.Slug = &newSlug
+		if current.Slug != "" {
+			update.OldSlug = &current.Slug
+		}
+	}
+	return nil
+}
+
+// GetBySlug 根据 slug 获取文章；命中历史 slug 时返回的文章 Slug 与传入值不同，由调用方处理重定向
+func (s *ArticleService) GetBySlug(ctx context.Context, articleSlug string) (*model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	article, err := s.articleDAO.FindPublicBySlug(ctx, articleSlug)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+	if err := ensureRendered(article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// BackfillSlugs 为没有 slug 的旧文章生成 slug，返回处理数量
+func (s *ArticleService) BackfillSlugs(ctx context.Context) (int, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	articles, err := s.articleDAO.FindWithoutSlug(ctx, 500)
+	if err != nil {
+		return 0, apperrors.ServerError(err)
+	}
+
+	for i, a := range articles {
+		generated, err := s.uniqueSlug(ctx, a.Title, a.ID)
+		if err != nil {
+			return i, err
+		}
+		if err := s.articleDAO.SetSlug(ctx, a.ID, generated); err != nil {
+			return i, apperrors.ServerError(err)
+		}
+	}
+	return len(articles), nil
+}
+
+// ChangeStatus 变更文章状态；scheduled 需要提供晚于当前时间的 publishAt
+func (s *ArticleService) ChangeStatus(ctx context.Context, id, userID primitive.ObjectID, role, status string, publishAt time.Time) (*model.Article, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	article, err := s.authorizedArticle(ctx, id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	from := article.GetStatus()
+	if !model.CanTransitionArticleStatus(from, status) {
+		return nil, apperrors.InvalidParamsError("文章不能从 " + from + " 变更为 " + status)
+	}
+
+	now := time.Now()
+	switch status {
+	case model.ArticleStatusPublished:
+		// 重新发布已归档的文章时保留原发布时间
+		publishAt = article.PublishAt
+		if publishAt.IsZero() || publishAt.After(now) {
+			publishAt = now
+		}
+	case model.ArticleStatusScheduled:
+		if !publishAt.After(now) {
+			return nil, apperrors.InvalidParamsError("定时发布时间必须晚于当前时间")
+		}
+	default:
+		publishAt = time.Time{}
+	}
+
+	updated, err := s.articleDAO.UpdateStatus(ctx, id, status, publishAt)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+	s.infoCache.Invalidate()
+	if err := syncTags(ctx, s.articleDAO, s.tagDAO, updated.GetTags()); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete 删除文章及其分词索引和评论
+func (s *ArticleService) Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	article, err := s.authorizedArticle(ctx, id, userID, role)
+	if err != nil {
+		return err
+	}
+	if err := s.articleDAO.Delete(ctx, id); err != nil {
+		return apperrors.WrapMongoError(err, "文章")
+	}
+	if err := s.termDAO.Delete(ctx, id); err != nil {
+		return apperrors.ServerError(err)
+	}
+	if err := s.commentDAO.DeleteByArticle(ctx, id); err != nil {
+		return apperrors.ServerError(err)
+	}
+	s.infoCache.Invalidate()
+	return syncTags(ctx, s.articleDAO, s.tagDAO, article.GetTags())
+}
+
+// authorizedArticle 获取文章，并校验当前用户是否为作者或拥有管理全部文章的权限
+func (s *ArticleService) authorizedArticle(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error) {
+	article, err := s.articleDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+	if model.HasPermission(role, model.PermArticleManage) {
+		return article, nil
+	}
+	if article.AuthorID == nil || *article.AuthorID != userID {
+		return nil, apperrors.ForbiddenError("只能管理自己的文章")
+	}
+	return article, nil
+}
+
+// checkCategory 校验文章所属分类是否存在
+func (s *ArticleService) checkCategory(ctx context.Context, categoryID *primitive.ObjectID) error {
+	if categoryID == nil {
+		return nil
+	}
+	if _, err := s.categoryDAO.FindByID(ctx, *categoryID); err != nil {
+		return apperrors.WrapMongoError(err, "分类")
+	}
+	return nil
+}
+
+// categoryIDs 将分类 slug 解析为该分类及其全部子分类的 ID
+func (s *ArticleService) categoryIDs(ctx context.Context, categorySlug string) ([]primitive.ObjectID, error) {
+	category, err := s.categoryDAO.FindBySlug(ctx, categorySlug)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "分类")
+	}
+	categories, err := s.categoryDAO.FindAll(ctx)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return categoryWithDescendants(categories, category.ID), nil
+}
+
+// checkSlug 校验手动指定的 slug 格式及唯一性
+func (s *ArticleService) checkSlug(ctx context.Context, articleSlug string, id primitive.ObjectID) error {
+	if !slug.IsValid(articleSlug) {
+		return apperrors.InvalidParamsError("slug 只能包含小写字母、数字和连字符")
+	}
+	taken, err := s.articleDAO.SlugTaken(ctx, articleSlug, id)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if taken {
+		return apperrors.ConflictError("文章 slug 已被占用")
+	}
+	return nil
+}
+
+// uniqueSlug 根据标题生成未被占用的 slug，冲突时追加数字后缀，无法生成时使用文章 ID 兜底
+func (s *ArticleService) uniqueSlug(ctx context.Context, title string, id primitive.ObjectID) (string, error) {
+	idSuffix := id.Hex()[len(id.Hex())-8:]
+	base := slug.Make(title)
+	if base == "" {
+		base = "post-" + idSuffix
+	}
+
+	for i := 1; i <= maxSlugAttempts; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken, err := s.articleDAO.SlugTaken(ctx, candidate, id)
+		if err != nil {
+			return "", apperrors.ServerError(err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return base + "-" + idSuffix, nil
+}
+
+// ensureRendered 为渲染功能上线前保存的文章即时渲染 HTML 和目录（不回写数据库）
+func ensureRendered(article *model.Article) error {
+	if article.ContentHTML != "" || article.Content == "" {
+		return nil
+	}
+	doc, err := markdown.Render(article.Content)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	article.ContentHTML = doc.HTML
+	article.TOC = doc.TOC
+	return nil
+}
+
+// normalizeArticleUpdate 去除首尾空白并校验更新字段
+func normalizeArticleUpdate(update *model.ArticleUpdate) error {
+	if update == nil {
+		return apperrors.InvalidParamsError("没有需要更新的字段")
+	}
+
+	empty := true
+	for _, field := range []*string{update.ArticleType, update.Title, update.Slug, update.CoverImage} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+			empty = false
+		}
+	}
+	if update.Content != nil || update.CategoryID != nil {
+		empty = false
+	}
+	if update.Tags != nil {
+		update.Tags = normalizeTags(update.Tags)
+		empty = false
+	}
+	if empty {
+		return apperrors.InvalidParamsError("没有需要更新的字段")
+	}
+
+	if (update.Title != nil && *update.Title == "") ||
+		(update.ArticleType != nil && *update.ArticleType == "") ||
+		(update.Tags != nil && len(update.Tags) == 0) {
+		return apperrors.InvalidParamsError("文章标题、类型和标签不能为空")
+	}
+	if len(update.Tags) > maxArticleTags {
+		return apperrors.InvalidParamsError(fmt.Sprintf("文章标签不能超过%d个", maxArticleTags))
+	}
+	if update.Content != nil && strings.TrimSpace(*update.Content) == "" {
+		return apperrors.InvalidParamsError("文章内容不能为空")
+	}
+	return nil
+}
+
 // 确保实现接口
 var _ ArticleServiceInterface = (*ArticleService)(nil)
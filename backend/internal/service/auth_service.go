@@ -3,16 +3,19 @@ package service
 import (
 	"backend/internal/config"
 	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/logger"
 	"backend/internal/model"
+	"backend/pkg/jwtkeys"
+	"backend/pkg/securetoken"
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // ========== 错误定义 ==========
@@ -23,46 +26,107 @@ var (
 	ErrInvalidToken       = errors.New("无效的Token")
 	ErrTokenExpired       = errors.New("Token已过期")
 	ErrTokenRevoked       = errors.New("Token已被吊销")
+	ErrTokenReused        = errors.New("Token已被使用，登录状态已失效")
+	ErrUserDisabled       = errors.New("账号已被禁用")
+	ErrCaptchaRequired    = errors.New("需要验证码")
+	ErrCaptchaInvalid     = errors.New("验证码错误")
+	ErrLoginTooFrequent   = errors.New("登录尝试过于频繁")
+	ErrAccountLocked      = errors.New("登录失败次数过多，已被临时锁定")
 )
 
+// LoginError 登录被拒绝时附带的限制信息，Err 为上面定义的错误之一
+type LoginError struct {
+	Err             error
+	RetryAfter      time.Duration
+	CaptchaRequired bool
+}
+
+func (e *LoginError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginError) Unwrap() error {
+	return e.Err
+}
+
 // ========== 类型定义 ==========
 
 type Claims struct {
 	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+	// SessionID 签发该令牌的会话（refresh token family）
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// RegisterInput 注册请求，Email 可选，填写后发送验证邮件；Language 为邮件语言
+type RegisterInput struct {
+	UserName string
+	Password string
+	Email    string
+	Language string
+}
+
+// LoginInput 登录请求，ClientIP 与验证码用于防爆破
+type LoginInput struct {
+	UserName    string
+	Password    string
+	ClientIP    string
+	CaptchaID   string
+	CaptchaCode string
+}
+
 type AuthService struct {
-	userDAO  *dao.UserDAO
-	tokenDAO *dao.TokenDAO
+	userDAO        *dao.UserDAO
+	tokenDAO       *dao.TokenDAO
+	auditDAO       *dao.AuditDAO
+	statusCache    *UserStatusCache
+	infoCache      *ArticleInfoCache
+	loginGuard     *LoginGuard
+	captcha        CaptchaServiceInterface
+	keyring        *jwtkeys.Keyring
+	denylist       *AccessTokenDenylist
+	passwordPolicy *PasswordPolicy
+	email          *EmailService
 }
 
 // ========== 构造函数 ==========
 
 func NewAuthService() *AuthService {
 	return &AuthService{
-		userDAO:  dao.NewUserDAO(),
-		tokenDAO: dao.NewTokenDAO(),
+		userDAO:        dao.NewUserDAO(),
+		tokenDAO:       dao.NewTokenDAO(),
+		auditDAO:       dao.NewAuditDAO(),
+		statusCache:    GetUserStatusCache(),
+		infoCache:      GetArticleInfoCache(),
+		loginGuard:     GetLoginGuard(),
+		captcha:        GetCaptchaService(),
+		keyring:        GetJWTKeyring(),
+		denylist:       GetAccessTokenDenylist(),
+		passwordPolicy: GetPasswordPolicy(),
+		email:          NewEmailService(),
 	}
 }
 
 // ========== Service 方法 ==========
 
 func (s *AuthService) HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(bytes), err
+	return hashPassword(password)
 }
 
 func (s *AuthService) CheckPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	return checkPassword(password, hash)
 }
 
-func (s *AuthService) GenerateAccessToken(userID primitive.ObjectID) (string, error) {
+// GenerateAccessToken 签发 access token，返回的 Claims 中包含用于吊销的 jti
+func (s *AuthService) GenerateAccessToken(userID primitive.ObjectID, role string, sessionID primitive.ObjectID) (string, *Claims, error) {
 	now := time.Now()
 	claims := &Claims{
-		UserID: userID.Hex(),
+		UserID:    userID.Hex(),
+		Role:      role,
+		SessionID: sessionID.Hex(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        primitive.NewObjectID().Hex(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(config.AppConfig.AccessTokenExpire)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -70,25 +134,102 @@ func (s *AuthService) GenerateAccessToken(userID primitive.ObjectID) (string, er
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	// 添加 kid (Key ID) 用于支持未来的密钥轮换
-	token.Header["kid"] = "v1"
-	return token.SignedString([]byte(config.AppConfig.JWTSecret))
+	// 使用密钥环当前的签名密钥，kid 写入头部供验证时选择密钥
+	token, err := s.keyring.Sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+func (s *AuthService) GenerateRefreshToken() (securetoken.Token, error) {
+	return securetoken.Generate()
 }
 
-func (s *AuthService) GenerateRefreshToken() (string, error) {
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
+// hashRefreshToken 计算 verifier 的哈希，数据库中只保存该值
+func (s *AuthService) hashRefreshToken(verifier string) string {
+	return securetoken.Hash([]byte(config.AppConfig.RefreshTokenHashKey), verifier)
+}
+
+// findRefreshToken 按 selector 与哈希查找令牌，未迁移的明文令牌在首次使用时迁移
+func (s *AuthService) findRefreshToken(ctx context.Context, raw string) (*model.RefreshToken, error) {
+	token, err := securetoken.Parse(raw)
+	if err != nil {
+		return nil, apperrors.NotFoundError("Token")
 	}
-	return hex.EncodeToString(bytes), nil
+	hash := s.hashRefreshToken(token.Verifier)
+
+	refreshToken, err := s.tokenDAO.FindByToken(ctx, token.Selector, hash)
+	if err == nil || !apperrors.IsNotFound(err) {
+		return refreshToken, err
+	}
+
+	refreshToken, err = s.tokenDAO.FindLegacyToken(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenDAO.SetHash(ctx, refreshToken.ID, token.Selector, hash); err != nil {
+		return nil, err
+	}
+	return refreshToken, nil
 }
 
-func (s *AuthService) GenerateTokenPair(ctx context.Context, userID primitive.ObjectID) (*model.TokenPair, error) {
+// DropLegacyTokenIndexes 删除旧版 token 字段上的唯一索引。新令牌不再写入 token 字段，
+// 该索引存在时第二次登录就会因 null 重复而失败，必须在开始处理请求前完成
+func (s *AuthService) DropLegacyTokenIndexes(ctx context.Context) error {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
+	return s.tokenDAO.DropLegacyIndexes(ctx)
+}
+
+// MigrateRefreshTokens 将旧版明文存储的 refresh token 迁移为哈希，客户端持有的令牌不受影响
+func (s *AuthService) MigrateRefreshTokens(ctx context.Context) (int, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	tokens, err := s.tokenDAO.FindLegacyTokens(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, t := range tokens {
+		token, err := securetoken.Parse(t.Token)
+		if err != nil {
+			// 无法解析的令牌不可能再被使用，直接吊销
+			if err := s.tokenDAO.RevokeFamily(ctx, t.Family()); err != nil {
+				return count, err
+			}
+			continue
+		}
+		if err := s.tokenDAO.SetHash(ctx, t.ID, token.Selector, s.hashRefreshToken(token.Verifier)); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// GenerateTokenPair 登录时签发令牌对，refresh token 开启一个新的 family（会话）
+func (s *AuthService) GenerateTokenPair(ctx context.Context, userID primitive.ObjectID, device model.DeviceInfo) (*model.TokenPair, error) {
+	return s.issueTokenPair(ctx, userID, primitive.NewObjectID(), time.Now(), device)
+}
+
+// issueTokenPair 签发令牌对，refresh token 归入 familyID
+func (s *AuthService) issueTokenPair(ctx context.Context, userID, familyID primitive.ObjectID, sessionCreatedAt time.Time, device model.DeviceInfo) (*model.TokenPair, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	// 每次签发都从数据库读取角色，保证角色变更在下次刷新时生效
+	user, err := s.userDAO.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled {
+		return nil, ErrUserDisabled
+	}
 
-	accessToken, err := s.GenerateAccessToken(userID)
+	accessToken, accessClaims, err := s.GenerateAccessToken(userID, user.GetRole(), familyID)
 	if err != nil {
 		return nil, err
 	}
@@ -98,22 +239,31 @@ func (s *AuthService) GenerateTokenPair(ctx context.Context, userID primitive.Ob
 		return nil, err
 	}
 
-	expiresAt := time.Now().Add(config.AppConfig.RefreshTokenExpire)
-	if err = s.tokenDAO.Create(ctx, userID, refreshToken, expiresAt); err != nil {
+	if err = s.tokenDAO.Create(ctx, &model.RefreshToken{
+		UserID:           userID,
+		FamilyID:         familyID,
+		Selector:         refreshToken.Selector,
+		TokenHash:        s.hashRefreshToken(refreshToken.Verifier),
+		ExpiresAt:        time.Now().Add(config.AppConfig.RefreshTokenExpire),
+		UserAgent:        truncateUserAgent(device.UserAgent),
+		IP:               device.IP,
+		SessionCreatedAt: &sessionCreatedAt,
+		AccessJTI:        accessClaims.ID,
+		AccessExpiresAt:  &accessClaims.ExpiresAt.Time,
+	}); err != nil {
 		return nil, err
 	}
 
 	return &model.TokenPair{
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: refreshToken.String(),
 		ExpiresIn:    int64(config.AppConfig.AccessTokenExpire.Seconds()),
 	}, nil
 }
 
 func (s *AuthService) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.AppConfig.JWTSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keyring.Keyfunc,
+		jwt.WithValidMethods(s.keyring.Algorithms()))
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -129,16 +279,21 @@ func (s *AuthService) ValidateAccessToken(tokenString string) (*Claims, error) {
 	return nil, ErrInvalidToken
 }
 
-func (s *AuthService) RefreshTokenPair(ctx context.Context, refreshTokenStr string) (*model.TokenPair, error) {
+// RefreshTokenPair 轮换 refresh token。已轮换的令牌被再次使用时视为泄露，吊销整个 family；
+// 宽限期内重用上一个令牌除外，见 refreshRotated
+func (s *AuthService) RefreshTokenPair(ctx context.Context, refreshTokenStr string, device model.DeviceInfo) (*model.TokenPair, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	refreshToken, err := s.tokenDAO.FindByToken(ctx, refreshTokenStr)
+	refreshToken, err := s.findRefreshToken(ctx, refreshTokenStr)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
 	if refreshToken.Revoked {
+		if refreshToken.RotatedAt != nil {
+			return s.refreshRotated(ctx, refreshToken, device)
+		}
 		return nil, ErrTokenRevoked
 	}
 
@@ -146,58 +301,390 @@ func (s *AuthService) RefreshTokenPair(ctx context.Context, refreshTokenStr stri
 		return nil, ErrTokenExpired
 	}
 
-	// 吊销旧的 refresh token（检查错误）
-	if err := s.tokenDAO.Revoke(ctx, refreshTokenStr); err != nil {
+	user, err := s.userDAO.FindByID(ctx, refreshToken.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if user.IsDisabled {
+		return nil, ErrUserDisabled
+	}
+
+	// 标记旧令牌已轮换；并发请求中只有一个能成功，其余按最新状态处理
+	if err := s.tokenDAO.Rotate(ctx, refreshToken.ID); err != nil {
+		if !apperrors.IsNotFound(err) {
+			return nil, err
+		}
+		if refreshToken, err = s.findRefreshToken(ctx, refreshTokenStr); err != nil {
+			return nil, ErrInvalidToken
+		}
+		if refreshToken.RotatedAt == nil {
+			return nil, ErrTokenRevoked
+		}
+		return s.refreshRotated(ctx, refreshToken, device)
+	}
+
+	// 在同一 family 内生成新的 token 对
+	return s.issueTokenPair(ctx, refreshToken.UserID, refreshToken.Family(), refreshToken.SessionStart(), device)
+}
+
+// refreshRotated 处理已轮换令牌的再次使用：宽限期内首次重用上一个令牌时视为同一客户端并发刷新（如多个标签页），
+// 在同一 family 内签发新令牌；否则视为泄露
+func (s *AuthService) refreshRotated(ctx context.Context, refreshToken *model.RefreshToken, device model.DeviceInfo) (*model.TokenPair, error) {
+	ok, err := claimReuseGrace(ctx, s.tokenDAO, refreshToken, config.AppConfig.RefreshReuseGrace, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.tokenReused(ctx, refreshToken)
+	}
+	return s.issueTokenPair(ctx, refreshToken.UserID, refreshToken.Family(), refreshToken.SessionStart(), device)
+}
+
+// refreshGraceStore 宽限期重用依赖的令牌存储，由 dao.TokenDAO 实现
+type refreshGraceStore interface {
+	FindFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) ([]model.RefreshToken, error)
+	ClaimReuseGrace(ctx context.Context, id primitive.ObjectID, rotatedAfter time.Time) error
+	SupersedeFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) error
+}
+
+var _ refreshGraceStore = (*dao.TokenDAO)(nil)
+
+// claimReuseGrace 判断已轮换的令牌能否按宽限期重用：令牌未过期、在 grace 内轮换、是当前令牌的上一个令牌，
+// 且尚未用过宽限期。返回 true 时其后签发的令牌已被作废，调用方签发的新令牌是该 family 唯一有效的令牌
+func claimReuseGrace(ctx context.Context, store refreshGraceStore, refreshToken *model.RefreshToken, grace time.Duration, now time.Time) (bool, error) {
+	if grace <= 0 || refreshToken.RotatedAt == nil || now.After(refreshToken.ExpiresAt) {
+		return false, nil
+	}
+	rotatedAt := *refreshToken.RotatedAt
+	if now.Sub(rotatedAt) > grace {
+		return false, nil
+	}
+
+	successors, err := store.FindFamilyCreatedSince(ctx, refreshToken.Family(), rotatedAt)
+	if err != nil {
+		return false, err
+	}
+	if !isPreviousToken(successors) {
+		return false, nil
+	}
+	if err := store.ClaimReuseGrace(ctx, refreshToken.ID, now.Add(-grace)); err != nil {
+		if apperrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := store.SupersedeFamilyCreatedSince(ctx, refreshToken.Family(), rotatedAt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isPreviousToken 被轮换令牌之后签发的令牌都仍然有效，即它是当前令牌的上一个令牌
+func isPreviousToken(successors []model.RefreshToken) bool {
+	if len(successors) == 0 {
+		return false
+	}
+	for _, t := range successors {
+		if t.Revoked {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenReused 吊销被重用令牌所在的 family 并记录审计日志
+func (s *AuthService) tokenReused(ctx context.Context, refreshToken *model.RefreshToken) error {
+	if err := s.revokeFamily(ctx, refreshToken.Family()); err != nil {
+		return err
+	}
+	userID := refreshToken.UserID
+	s.audit(ctx, &model.AuditLog{
+		Event:  model.AuditRefreshTokenReuse,
+		UserID: &userID,
+		Detail: "已轮换的 refresh token 被再次使用，已吊销 family " + refreshToken.Family().Hex(),
+	})
+	return ErrTokenReused
+}
+
+// ListSessions 列出用户当前有效的会话，currentSessionID 对应的会话标记为当前会话
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID primitive.ObjectID) ([]model.Session, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	tokens, err := s.tokenDAO.FindActiveByUserID(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
 
-	// 生成新的 token 对
-	return s.GenerateTokenPair(ctx, refreshToken.UserID)
+	sessions := make([]model.Session, 0, len(tokens))
+	for _, t := range tokens {
+		family := t.Family()
+		sessions = append(sessions, model.Session{
+			ID:         family,
+			UserAgent:  t.UserAgent,
+			IP:         t.IP,
+			CreatedAt:  t.SessionStart(),
+			LastUsedAt: t.CreatedAt,
+			Current:    !currentSessionID.IsZero() && family == currentSessionID,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession 结束用户的指定会话
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID primitive.ObjectID) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.tokenDAO.RevokeUserFamily(ctx, userID, sessionID); err != nil {
+		return apperrors.WrapMongoError(err, "会话")
+	}
+	return revokeFamilyAccess(ctx, s.tokenDAO, s.denylist, sessionID)
 }
 
-func (s *AuthService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+// RevokeAllSessions 结束用户的全部会话，keepSessionID 非空时保留该会话
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID, keepSessionID primitive.ObjectID) error {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	return s.tokenDAO.Revoke(ctx, refreshToken)
+	var err error
+	if keepSessionID.IsZero() {
+		err = s.tokenDAO.RevokeAllByUserID(ctx, userID)
+	} else {
+		err = s.tokenDAO.RevokeOthersByUserID(ctx, userID, keepSessionID)
+	}
+	if err != nil {
+		return err
+	}
+	return revokeUserAccess(ctx, s.tokenDAO, s.denylist, userID, keepSessionID)
 }
 
-func (s *AuthService) Register(ctx context.Context, username, password string) (*model.User, error) {
+// RevokeRefreshToken 退出登录，吊销该令牌所在 family（即本次登录）的全部令牌
+func (s *AuthService) RevokeRefreshToken(ctx context.Context, refreshTokenStr string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	refreshToken, err := s.findRefreshToken(ctx, refreshTokenStr)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return s.revokeFamily(ctx, refreshToken.Family())
+}
+
+// RevokeAccessToken 将 access token 加入黑名单，无效或已过期的令牌直接忽略
+func (s *AuthService) RevokeAccessToken(ctx context.Context, accessToken string) error {
+	claims, err := s.ValidateAccessToken(accessToken)
+	if err != nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	userID, _ := primitive.ObjectIDFromHex(claims.UserID)
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	return s.denylist.Revoke(ctx, model.RevokedAccessToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+}
+
+// IsAccessTokenRevoked 检查 access token 是否已被吊销（仅查询内存）
+func (s *AuthService) IsAccessTokenRevoked(jti string) bool {
+	return s.denylist.Contains(jti)
+}
+
+// revokeFamily 吊销会话的 refresh token 及其签发的 access token
+func (s *AuthService) revokeFamily(ctx context.Context, familyID primitive.ObjectID) error {
+	if err := s.tokenDAO.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return revokeFamilyAccess(ctx, s.tokenDAO, s.denylist, familyID)
+}
+
+// Register 注册用户，密码需满足强度策略；填写邮箱时发送验证邮件
+func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*model.User, error) {
+	if err := s.passwordPolicy.Validate(input.Password, input.UserName); err != nil {
+		return nil, err
+	}
+	var email string
+	if input.Email != "" {
+		normalized, err := NormalizeEmail(input.Email)
+		if err != nil {
+			return nil, err
+		}
+		email = normalized
+	}
+
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	exists, err := s.userDAO.ExistsByUsername(ctx, username)
+	exists, err := s.userDAO.ExistsByUsername(ctx, input.UserName)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
 		return nil, ErrUserExists
 	}
+	if email != "" {
+		if err := s.email.checkEmailAvailable(ctx, email, primitive.NilObjectID); err != nil {
+			return nil, err
+		}
+	}
 
-	hashedPwd, err := s.HashPassword(password)
+	hashedPwd, err := s.HashPassword(input.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	user := model.NewUser(username, hashedPwd)
-	return s.userDAO.Create(ctx, user)
+	newUser := model.NewUser(input.UserName, hashedPwd)
+	newUser.Email = email
+	newUser.Language = NormalizeLanguage(input.Language)
+	user, err := s.userDAO.Create(ctx, newUser)
+	if err != nil {
+		return nil, err
+	}
+	s.infoCache.Invalidate()
+
+	// 验证邮件发送失败不影响注册，用户可以稍后重新发送
+	if email != "" {
+		if err := s.email.sendVerification(ctx, user); err != nil {
+			logger.Error("发送邮箱验证邮件失败", logger.String("user_name", user.UserName), logger.Err(err))
+		}
+	}
+	return user, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, username, password string) (*model.User, error) {
+// Login 校验用户名密码。失败次数超过阈值后要求验证码并递增等待，继续失败则临时锁定用户名或 IP
+func (s *AuthService) Login(ctx context.Context, input LoginInput) (*model.User, error) {
+	status, attempt := s.loginGuard.Check(input.UserName, input.ClientIP)
+	if status.Locked {
+		return nil, &LoginError{Err: ErrAccountLocked, RetryAfter: status.RetryAfter, CaptchaRequired: true}
+	}
+	if status.RetryAfter > 0 {
+		return nil, &LoginError{Err: ErrLoginTooFrequent, RetryAfter: status.RetryAfter, CaptchaRequired: true}
+	}
+	if status.CaptchaRequired {
+		if input.CaptchaID == "" || input.CaptchaCode == "" {
+			s.loginGuard.Release(attempt)
+			return nil, &LoginError{Err: ErrCaptchaRequired, CaptchaRequired: true}
+		}
+		if !s.captcha.Verify(input.CaptchaID, input.CaptchaCode) {
+			s.loginGuard.Release(attempt)
+			return nil, &LoginError{Err: ErrCaptchaInvalid, CaptchaRequired: true}
+		}
+	}
+
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	user, err := s.userDAO.FindByUsername(ctx, username)
+	user, err := s.userDAO.FindByUsername(ctx, input.UserName)
 	if err != nil {
-		return nil, ErrInvalidCredentials
+		if apperrors.IsNotFound(err) {
+			return nil, s.loginFailed(ctx, input, attempt, nil)
+		}
+		s.loginGuard.Release(attempt)
+		return nil, err
+	}
+
+	if !s.CheckPassword(input.Password, user.Password) {
+		return nil, s.loginFailed(ctx, input, attempt, &user.ID)
 	}
 
-	if !s.CheckPassword(password, user.Password) {
-		return nil, ErrInvalidCredentials
+	s.loginGuard.Succeed(attempt)
+	if user.IsDisabled {
+		return nil, ErrUserDisabled
 	}
 
 	return user, nil
 }
 
+// loginFailed 记录失败次数，触发锁定时写入审计日志
+func (s *AuthService) loginFailed(ctx context.Context, input LoginInput, attempt *LoginAttempt, userID *primitive.ObjectID) error {
+	failure := s.loginGuard.Fail(attempt)
+
+	if failure.LockedUser {
+		s.audit(ctx, &model.AuditLog{
+			Event:    model.AuditLoginLocked,
+			UserID:   userID,
+			UserName: input.UserName,
+			IP:       input.ClientIP,
+			Detail:   fmt.Sprintf("用户名连续登录失败，锁定 %s", failure.RetryAfter.Round(time.Second)),
+		})
+	}
+	if failure.LockedIP {
+		s.audit(ctx, &model.AuditLog{
+			Event:    model.AuditLoginLocked,
+			UserName: input.UserName,
+			IP:       input.ClientIP,
+			Detail:   fmt.Sprintf("IP 连续登录失败，锁定 %s", failure.RetryAfter.Round(time.Second)),
+		})
+	}
+
+	if failure.Locked {
+		return &LoginError{Err: ErrAccountLocked, RetryAfter: failure.RetryAfter, CaptchaRequired: true}
+	}
+	return &LoginError{Err: ErrInvalidCredentials, RetryAfter: failure.RetryAfter, CaptchaRequired: failure.CaptchaRequired}
+}
+
+// audit 写入审计日志，失败只记录日志不影响主流程
+func (s *AuthService) audit(ctx context.Context, entry *model.AuditLog) {
+	recordAudit(ctx, s.auditDAO, entry)
+}
+
+// recordAudit 记录安全审计事件并写入审计日志集合
+func recordAudit(ctx context.Context, auditDAO *dao.AuditDAO, entry *model.AuditLog) {
+	logger.Warn("安全审计事件",
+		logger.String("event", entry.Event),
+		logger.String("user_name", entry.UserName),
+		logger.String("ip", entry.IP),
+		logger.String("detail", entry.Detail),
+	)
+	if err := auditDAO.Create(ctx, entry); err != nil {
+		logger.Error("写入审计日志失败", logger.Err(err))
+	}
+}
+
+// CheckUserStatus 检查用户是否可用（存在且未被禁用）并返回其当前角色，结果会被短暂缓存，
+// 角色变更在缓存过期后生效，不必等待 access token 过期
+func (s *AuthService) CheckUserStatus(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	status, ok := s.statusCache.Get(userID)
+	if !ok {
+		ctx, cancel := dao.WithDefaultTimeout(ctx)
+		defer cancel()
+
+		user, err := s.userDAO.FindByID(ctx, userID)
+		if err != nil {
+			return "", apperrors.WrapMongoError(err, "用户")
+		}
+		status = UserStatus{Disabled: user.IsDisabled, Role: user.GetRole()}
+		s.statusCache.Set(userID, status)
+	}
+
+	if status.Disabled {
+		return "", ErrUserDisabled
+	}
+	return status.Role, nil
+}
+
+// JWKS 返回用于验证 access token 的公钥集合
+func (s *AuthService) JWKS() jwtkeys.JWKS {
+	return s.keyring.JWKS()
+}
+
+// 会话列表中保存的 User-Agent 最大长度
+const maxUserAgentLength = 256
+
+func truncateUserAgent(ua string) string {
+	if len(ua) <= maxUserAgentLength {
+		return ua
+	}
+	// 截断可能切开多字节字符，去掉末尾不完整的部分
+	return strings.ToValidUTF8(ua[:maxUserAgentLength], "")
+}
+
 // 确保实现接口
 var _ AuthServiceInterface = (*AuthService)(nil)
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"backend/internal/model"
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeRefreshGraceStore 内存中的单个 family，按 TokenDAO 的过滤条件实现 refreshGraceStore
+type fakeRefreshGraceStore struct {
+	tokens []*model.RefreshToken
+}
+
+func (s *fakeRefreshGraceStore) FindFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) ([]model.RefreshToken, error) {
+	var tokens []model.RefreshToken
+	for _, t := range s.tokens {
+		if t.Family() == familyID && !t.CreatedAt.Before(since) {
+			tokens = append(tokens, *t)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *fakeRefreshGraceStore) ClaimReuseGrace(ctx context.Context, id primitive.ObjectID, rotatedAfter time.Time) error {
+	for _, t := range s.tokens {
+		if t.ID == id && t.RotatedAt != nil && !t.RotatedAt.Before(rotatedAfter) && !t.ReuseGraceUsed {
+			t.ReuseGraceUsed = true
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (s *fakeRefreshGraceStore) SupersedeFamilyCreatedSince(ctx context.Context, familyID primitive.ObjectID, since time.Time) error {
+	now := time.Now()
+	for _, t := range s.tokens {
+		if t.Family() == familyID && !t.CreatedAt.Before(since) && !t.Revoked {
+			t.Revoked, t.RotatedAt, t.ReuseGraceUsed = true, &now, true
+		}
+	}
+	return nil
+}
+
+// rotate 模拟一次正常刷新：标记 t 已轮换并签发下一个令牌
+func (s *fakeRefreshGraceStore) rotate(t *model.RefreshToken, at time.Time) *model.RefreshToken {
+	t.Revoked, t.RotatedAt = true, &at
+	return s.issue(t.Family(), at)
+}
+
+func (s *fakeRefreshGraceStore) issue(familyID primitive.ObjectID, at time.Time) *model.RefreshToken {
+	t := &model.RefreshToken{ID: primitive.NewObjectID(), FamilyID: familyID, CreatedAt: at, ExpiresAt: at.Add(time.Hour)}
+	s.tokens = append(s.tokens, t)
+	return t
+}
+
+func (s *fakeRefreshGraceStore) live() int {
+	n := 0
+	for _, t := range s.tokens {
+		if !t.Revoked {
+			n++
+		}
+	}
+	return n
+}
+
+func TestClaimReuseGrace_ReplayLeavesOneChain(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+	store := &fakeRefreshGraceStore{}
+	first := store.issue(primitive.NewObjectID(), start)
+	first.FamilyID = first.ID
+	second := store.rotate(first, start.Add(time.Second))
+
+	// 宽限期内重用上一个令牌：作废其后的令牌，由调用方签发新令牌
+	now := start.Add(5 * time.Second)
+	ok, err := claimReuseGrace(ctx, store, first, 30*time.Second, now)
+	if err != nil || !ok {
+		t.Fatalf("宽限期内首次重用应允许, 实际 %v, %v", ok, err)
+	}
+	if store.live() != 0 {
+		t.Fatalf("之后签发的令牌应被作废, 仍有 %d 个有效令牌", store.live())
+	}
+	store.issue(first.Family(), now)
+
+	// 同一令牌再次重用，以及被作废的令牌再次使用，都不再享有宽限期
+	if ok, _ := claimReuseGrace(ctx, store, first, 30*time.Second, now); ok {
+		t.Error("同一令牌只能使用一次宽限期")
+	}
+	if ok, _ := claimReuseGrace(ctx, store, second, 30*time.Second, now); ok {
+		t.Error("被作废的令牌不应享有宽限期")
+	}
+	if store.live() != 1 {
+		t.Errorf("family 中应只有一个有效令牌, 实际 %d", store.live())
+	}
+}
+
+func TestClaimReuseGrace_Rejected(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		grace time.Duration
+		setup func(store *fakeRefreshGraceStore, first *model.RefreshToken) time.Time
+	}{
+		{"未开启宽限期", 0, func(store *fakeRefreshGraceStore, first *model.RefreshToken) time.Time {
+			store.rotate(first, start)
+			return start.Add(time.Second)
+		}},
+		{"超出宽限期", 30 * time.Second, func(store *fakeRefreshGraceStore, first *model.RefreshToken) time.Time {
+			store.rotate(first, start)
+			return start.Add(time.Minute)
+		}},
+		{"令牌已过期", 30 * time.Second, func(store *fakeRefreshGraceStore, first *model.RefreshToken) time.Time {
+			first.ExpiresAt = start
+			store.rotate(first, start)
+			return start.Add(time.Second)
+		}},
+		{"不是上一个令牌", 30 * time.Second, func(store *fakeRefreshGraceStore, first *model.RefreshToken) time.Time {
+			second := store.rotate(first, start)
+			store.rotate(second, start.Add(time.Second))
+			return start.Add(2 * time.Second)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeRefreshGraceStore{}
+			first := store.issue(primitive.NewObjectID(), start.Add(-time.Second))
+			now := tt.setup(store, first)
+			live := store.live()
+
+			ok, err := claimReuseGrace(ctx, store, first, tt.grace, now)
+			if err != nil || ok {
+				t.Errorf("应按重用处理, 实际 %v, %v", ok, err)
+			}
+			if store.live() != live {
+				t.Error("拒绝时不应作废其他令牌")
+			}
+		})
+	}
+}
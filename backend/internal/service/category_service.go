@@ -0,0 +1,257 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/slug"
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CategoryService 分类服务实现
+type CategoryService struct {
+	categoryDAO *dao.CategoryDAO
+	articleDAO  *dao.ArticleDAO
+}
+
+// NewCategoryService 创建分类服务
+func NewCategoryService() *CategoryService {
+	return &CategoryService{
+		categoryDAO: dao.NewCategoryDAO(),
+		articleDAO:  dao.NewArticleDAO(),
+	}
+}
+
+// NewCategoryServiceWithDAO 使用指定的 DAO 创建分类服务（用于测试）
+func NewCategoryServiceWithDAO(categoryDAO *dao.CategoryDAO, articleDAO *dao.ArticleDAO) *CategoryService {
+	return &CategoryService{
+		categoryDAO: categoryDAO,
+		articleDAO:  articleDAO,
+	}
+}
+
+// Tree 获取分类树，每个节点的文章数包含其子分类
+func (s *CategoryService) Tree(ctx context.Context) ([]model.CategoryNode, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	categories, err := s.categoryDAO.FindAll(ctx)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	counts, err := s.articleDAO.CountPublicGroupBy(ctx, "category_id")
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return buildCategoryTree(categories, nil, counts), nil
+}
+
+// GetBySlug 根据 slug 获取分类
+func (s *CategoryService) GetBySlug(ctx context.Context, categorySlug string) (*model.Category, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	category, err := s.categoryDAO.FindBySlug(ctx, categorySlug)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "分类")
+	}
+	return category, nil
+}
+
+// Create 创建分类，未指定 slug 时根据名称生成
+func (s *CategoryService) Create(ctx context.Context, category *model.Category) (*model.Category, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	category.Name = strings.TrimSpace(category.Name)
+	category.Slug = strings.TrimSpace(category.Slug)
+	category.Description = strings.TrimSpace(category.Description)
+	if category.Name == "" {
+		return nil, apperrors.InvalidParamsError("分类名称不能为空")
+	}
+	category.ID = primitive.NewObjectID()
+
+	if category.ParentID != nil {
+		if _, err := s.categoryDAO.FindByID(ctx, *category.ParentID); err != nil {
+			return nil, apperrors.WrapMongoError(err, "上级分类")
+		}
+	}
+	if err := s.resolveSlug(ctx, &category.Slug, category.Name, category.ID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.categoryDAO.Create(ctx, category)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("分类 slug 已被占用")
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	return created, nil
+}
+
+// Update 修改分类，移动上级分类时不能移动到自身或其子分类下
+func (s *CategoryService) Update(ctx context.Context, id primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	for _, field := range []*string{update.Name, update.Slug, update.Description} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+	if update.Name != nil && *update.Name == "" {
+		return nil, apperrors.InvalidParamsError("分类名称不能为空")
+	}
+
+	current, err := s.categoryDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "分类")
+	}
+
+	if update.ParentID != nil && !update.ParentID.IsZero() {
+		categories, err := s.categoryDAO.FindAll(ctx)
+		if err != nil {
+			return nil, apperrors.ServerError(err)
+		}
+		if !containsCategory(categories, *update.ParentID) {
+			return nil, apperrors.NotFoundError("上级分类")
+		}
+		for _, descendant := range categoryWithDescendants(categories, id) {
+			if descendant == *update.ParentID {
+				return nil, apperrors.InvalidParamsError("不能将分类移动到自身或其子分类下")
+			}
+		}
+	}
+
+	if update.Slug != nil && *update.Slug != current.Slug {
+		// slug 留空时按修改后的名称重新生成
+		name := current.Name
+		if update.Name != nil {
+			name = *update.Name
+		}
+		if err := s.resolveSlug(ctx, update.Slug, name, id); err != nil {
+			return nil, err
+		}
+	}
+
+	category, err := s.categoryDAO.Update(ctx, id, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("分类 slug 已被占用")
+		}
+		return nil, apperrors.WrapMongoError(err, "分类")
+	}
+	return category, nil
+}
+
+// Delete 删除分类，仍有子分类或文章时拒绝删除
+func (s *CategoryService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	children, err := s.categoryDAO.CountChildren(ctx, id)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if children > 0 {
+		return apperrors.ConflictError("请先删除或移动子分类")
+	}
+	articles, err := s.articleDAO.CountByCategory(ctx, id)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if articles > 0 {
+		return apperrors.ConflictError("该分类下仍有文章")
+	}
+
+	if err := s.categoryDAO.Delete(ctx, id); err != nil {
+		return apperrors.WrapMongoError(err, "分类")
+	}
+	return nil
+}
+
+// resolveSlug 校验手动指定的 slug，为空时根据名称生成
+func (s *CategoryService) resolveSlug(ctx context.Context, categorySlug *string, name string, id primitive.ObjectID) error {
+	taken := func(ctx context.Context, candidate string) (bool, error) {
+		return s.categoryDAO.SlugTaken(ctx, candidate, id)
+	}
+
+	if *categorySlug == "" {
+		generated, err := uniqueNameSlug(ctx, name, "category", id, taken)
+		if err != nil {
+			return err
+		}
+		*categorySlug = generated
+		return nil
+	}
+
+	if !slug.IsValid(*categorySlug) {
+		return apperrors.InvalidParamsError("slug 只能包含小写字母、数字和连字符")
+	}
+	exists, err := taken(ctx, *categorySlug)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if exists {
+		return apperrors.ConflictError("分类 slug 已被占用")
+	}
+	return nil
+}
+
+// buildCategoryTree 构造 parentID 下的分类子树，并把子分类的文章数累加到上级
+func buildCategoryTree(categories []model.Category, parentID *primitive.ObjectID, counts map[primitive.ObjectID]int64) []model.CategoryNode {
+	nodes := []model.CategoryNode{}
+	for _, c := range categories {
+		if !sameParent(c.ParentID, parentID) {
+			continue
+		}
+		id := c.ID
+		node := model.CategoryNode{
+			Category: c,
+			Count:    counts[c.ID],
+			Children: buildCategoryTree(categories, &id, counts),
+		}
+		for _, child := range node.Children {
+			node.Count += child.Count
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// categoryWithDescendants 返回 rootID 及其全部子孙分类的 ID
+func categoryWithDescendants(categories []model.Category, rootID primitive.ObjectID) []primitive.ObjectID {
+	ids := []primitive.ObjectID{rootID}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range categories {
+			if c.ParentID != nil && *c.ParentID == ids[i] {
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func containsCategory(categories []model.Category, id primitive.ObjectID) bool {
+	for _, c := range categories {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sameParent(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// 确保实现接口
+var _ CategoryServiceInterface = (*CategoryService)(nil)
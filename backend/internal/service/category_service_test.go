@@ -0,0 +1,61 @@
+package service
+
+import (
+	"backend/internal/model"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	root := primitive.NewObjectID()
+	child := primitive.NewObjectID()
+	grandchild := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	categories := []model.Category{
+		{ID: root, Slug: "root"},
+		{ID: child, Slug: "child", ParentID: &root},
+		{ID: grandchild, Slug: "grandchild", ParentID: &child},
+		{ID: other, Slug: "other"},
+	}
+	counts := map[primitive.ObjectID]int64{root: 1, child: 2, grandchild: 4}
+
+	tree := buildCategoryTree(categories, nil, counts)
+
+	if len(tree) != 2 {
+		t.Fatalf("期望 2 个顶层分类, 实际 %d", len(tree))
+	}
+	if tree[0].Slug != "root" || tree[0].Count != 7 {
+		t.Errorf("上级分类的文章数应包含子孙分类, 实际 %s=%d", tree[0].Slug, tree[0].Count)
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Count != 6 {
+		t.Errorf("子分类文章数不正确: %+v", tree[0].Children)
+	}
+	if tree[1].Slug != "other" || tree[1].Count != 0 || tree[1].Children == nil {
+		t.Errorf("没有文章的分类应返回 0 和空的 children: %+v", tree[1])
+	}
+}
+
+func TestCategoryWithDescendants(t *testing.T) {
+	root := primitive.NewObjectID()
+	child := primitive.NewObjectID()
+	grandchild := primitive.NewObjectID()
+	sibling := primitive.NewObjectID()
+	categories := []model.Category{
+		{ID: root},
+		{ID: child, ParentID: &root},
+		{ID: grandchild, ParentID: &child},
+		{ID: sibling},
+	}
+
+	ids := categoryWithDescendants(categories, root)
+	want := map[primitive.ObjectID]bool{root: true, child: true, grandchild: true}
+	if len(ids) != len(want) {
+		t.Fatalf("期望 %d 个分类, 实际 %d", len(want), len(ids))
+	}
+	for _, id := range ids {
+		if !want[id] {
+			t.Errorf("不应包含分类 %s", id.Hex())
+		}
+	}
+}
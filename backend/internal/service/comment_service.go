@@ -0,0 +1,244 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// commentStore 评论存储，由 dao.CommentDAO 实现
+type commentStore interface {
+	Create(ctx context.Context, comment *model.Comment) (*model.Comment, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.Comment, error)
+	FindThread(ctx context.Context, rootID primitive.ObjectID) ([]model.Comment, error)
+	UpdateContent(ctx context.Context, id primitive.ObjectID, content, contentHTML string) (*model.Comment, error)
+	DeleteMany(ctx context.Context, ids []primitive.ObjectID) error
+	CountByArticle(ctx context.Context, articleID primitive.ObjectID) (int64, error)
+	CountRoots(ctx context.Context, articleID primitive.ObjectID) (int64, error)
+	FindRootsWithUser(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) ([]model.CommentWithUser, error)
+	FindRepliesWithUser(ctx context.Context, rootIDs []primitive.ObjectID) ([]model.CommentWithUser, error)
+}
+
+// commentArticleStore 评论服务用到的文章存储，由 dao.ArticleDAO 实现
+type commentArticleStore interface {
+	FindPublicByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error)
+	AddComment(ctx context.Context, articleID, commentID primitive.ObjectID) error
+	RemoveComments(ctx context.Context, articleID primitive.ObjectID, commentIDs []primitive.ObjectID) error
+	SetCommentCount(ctx context.Context, articleID primitive.ObjectID, count int64) error
+}
+
+// CommentService 文章评论服务实现
+type CommentService struct {
+	commentDAO commentStore
+	articleDAO commentArticleStore
+}
+
+// NewCommentService 创建评论服务
+func NewCommentService() *CommentService {
+	return &CommentService{
+		commentDAO: dao.NewCommentDAO(),
+		articleDAO: dao.NewArticleDAO(),
+	}
+}
+
+// NewCommentServiceWithDAO 使用指定的 DAO 创建评论服务（用于测试）
+func NewCommentServiceWithDAO(commentDAO commentStore, articleDAO commentArticleStore) *CommentService {
+	return &CommentService{
+		commentDAO: commentDAO,
+		articleDAO: articleDAO,
+	}
+}
+
+// List 分页获取公开文章的顶层评论，每条顶层评论附带其全部回复
+func (s *CommentService) List(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) (*model.CommentPage, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if _, err := s.articleDAO.FindPublicByID(ctx, articleID); err != nil {
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+
+	total, err := s.commentDAO.CountRoots(ctx, articleID)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	roots, err := s.commentDAO.FindRootsWithUser(ctx, articleID, skip, limit)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+
+	if len(roots) > 0 {
+		rootIDs := make([]primitive.ObjectID, 0, len(roots))
+		index := make(map[primitive.ObjectID]int, len(roots))
+		for i, root := range roots {
+			rootIDs = append(rootIDs, root.ID)
+			index[root.ID] = i
+		}
+
+		replies, err := s.commentDAO.FindRepliesWithUser(ctx, rootIDs)
+		if err != nil {
+			return nil, apperrors.ServerError(err)
+		}
+		for _, reply := range replies {
+			if i, ok := index[*reply.RootID]; ok {
+				roots[i].Replies = append(roots[i].Replies, reply)
+			}
+		}
+	}
+
+	return &model.CommentPage{List: roots, Total: total, Skip: skip, Limit: limit}, nil
+}
+
+// Create 发表评论，parentID 不为空时回复该评论
+func (s *CommentService) Create(ctx context.Context, articleID, userID primitive.ObjectID, parentID *primitive.ObjectID, content string) (*model.Comment, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, apperrors.InvalidParamsError("评论内容不能为空")
+	}
+	if _, err := s.articleDAO.FindPublicByID(ctx, articleID); err != nil {
+		return nil, apperrors.WrapMongoError(err, "文章")
+	}
+
+	contentHTML, err := renderUserContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{ArticleID: articleID, UserID: userID, Content: content, ContentHTML: contentHTML}
+	if parentID != nil {
+		parent, err := s.commentDAO.FindByID(ctx, *parentID)
+		if err != nil {
+			return nil, apperrors.WrapMongoError(err, "被回复的评论")
+		}
+		if parent.ArticleID != articleID {
+			return nil, apperrors.InvalidParamsError("被回复的评论不属于该文章")
+		}
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.RootID = &rootID
+		comment.ParentID = &parent.ID
+	}
+
+	created, err := s.commentDAO.Create(ctx, comment)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if err := s.articleDAO.AddComment(ctx, articleID, created.ID); err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if err := s.syncCommentCount(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Update 修改评论内容，仅评论作者或拥有审核权限的用户可操作
+func (s *CommentService) Update(ctx context.Context, articleID, id, userID primitive.ObjectID, role, content string) (*model.Comment, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, apperrors.InvalidParamsError("评论内容不能为空")
+	}
+	if _, err := s.authorizedComment(ctx, articleID, id, userID, role); err != nil {
+		return nil, err
+	}
+
+	contentHTML, err := renderUserContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentDAO.UpdateContent(ctx, id, content, contentHTML)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "评论")
+	}
+	return comment, nil
+}
+
+// Delete 删除评论及其下的全部回复，仅评论作者或拥有审核权限的用户可操作
+func (s *CommentService) Delete(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	comment, err := s.authorizedComment(ctx, articleID, id, userID, role)
+	if err != nil {
+		return err
+	}
+
+	rootID := comment.ID
+	if comment.RootID != nil {
+		rootID = *comment.RootID
+	}
+	thread, err := s.commentDAO.FindThread(ctx, rootID)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	ids := commentWithDescendants(thread, comment.ID)
+
+	if err := s.commentDAO.DeleteMany(ctx, ids); err != nil {
+		return apperrors.ServerError(err)
+	}
+	if err := s.articleDAO.RemoveComments(ctx, articleID, ids); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return s.syncCommentCount(ctx, articleID)
+}
+
+// syncCommentCount 按评论集合重新统计文章评论数，评论与文章分两次写入，
+// 重新统计可以纠正之前失败或并发删除造成的偏差
+func (s *CommentService) syncCommentCount(ctx context.Context, articleID primitive.ObjectID) error {
+	count, err := s.commentDAO.CountByArticle(ctx, articleID)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if err := s.articleDAO.SetCommentCount(ctx, articleID, count); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
+
+// authorizedComment 获取属于该文章的评论，并校验当前用户是否为作者或拥有审核权限
+func (s *CommentService) authorizedComment(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) (*model.Comment, error) {
+	comment, err := s.commentDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "评论")
+	}
+	if comment.ArticleID != articleID {
+		return nil, apperrors.NotFoundError("评论")
+	}
+	if comment.UserID != userID && !model.HasPermission(role, model.PermMessageModerate) {
+		return nil, apperrors.ForbiddenError("只能修改或删除自己的评论")
+	}
+	return comment, nil
+}
+
+// commentWithDescendants 返回 id 及楼层内直接或间接回复它的评论 ID
+func commentWithDescendants(thread []model.Comment, id primitive.ObjectID) []primitive.ObjectID {
+	ids := []primitive.ObjectID{id}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range thread {
+			if c.ParentID != nil && *c.ParentID == ids[i] {
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// 确保实现接口
+var (
+	_ CommentServiceInterface = (*CommentService)(nil)
+	_ commentStore            = (*dao.CommentDAO)(nil)
+	_ commentArticleStore     = (*dao.ArticleDAO)(nil)
+)
@@ -0,0 +1,281 @@
+package service
+
+import (
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeCommentStore 内存评论存储
+type fakeCommentStore struct {
+	comments map[primitive.ObjectID]*model.Comment
+}
+
+// fakeCommentArticleStore 内存文章存储，addCommentErr 模拟写入评论后更新文章失败
+type fakeCommentArticleStore struct {
+	articles      map[primitive.ObjectID]*model.Article
+	addCommentErr error
+}
+
+func (s *fakeCommentStore) Create(ctx context.Context, comment *model.Comment) (*model.Comment, error) {
+	comment.ID = primitive.NewObjectID()
+	s.comments[comment.ID] = comment
+	return comment, nil
+}
+
+func (s *fakeCommentStore) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Comment, error) {
+	if c, ok := s.comments[id]; ok {
+		return c, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *fakeCommentStore) FindThread(ctx context.Context, rootID primitive.ObjectID) ([]model.Comment, error) {
+	var thread []model.Comment
+	for _, c := range s.comments {
+		if c.ID == rootID || c.RootID != nil && *c.RootID == rootID {
+			thread = append(thread, *c)
+		}
+	}
+	return thread, nil
+}
+
+func (s *fakeCommentStore) UpdateContent(ctx context.Context, id primitive.ObjectID, content, contentHTML string) (*model.Comment, error) {
+	c, ok := s.comments[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	c.Content, c.ContentHTML = content, contentHTML
+	return c, nil
+}
+
+func (s *fakeCommentStore) DeleteMany(ctx context.Context, ids []primitive.ObjectID) error {
+	for _, id := range ids {
+		delete(s.comments, id)
+	}
+	return nil
+}
+
+func (s *fakeCommentStore) CountByArticle(ctx context.Context, articleID primitive.ObjectID) (int64, error) {
+	var n int64
+	for _, c := range s.comments {
+		if c.ArticleID == articleID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *fakeCommentStore) CountRoots(ctx context.Context, articleID primitive.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+func (s *fakeCommentStore) FindRootsWithUser(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) ([]model.CommentWithUser, error) {
+	return nil, nil
+}
+
+func (s *fakeCommentStore) FindRepliesWithUser(ctx context.Context, rootIDs []primitive.ObjectID) ([]model.CommentWithUser, error) {
+	return nil, nil
+}
+
+func (s *fakeCommentArticleStore) FindPublicByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error) {
+	if a, ok := s.articles[id]; ok {
+		return a, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *fakeCommentArticleStore) AddComment(ctx context.Context, articleID, commentID primitive.ObjectID) error {
+	if s.addCommentErr != nil {
+		return s.addCommentErr
+	}
+	a := s.articles[articleID]
+	a.Comments = append(a.Comments, commentID)
+	return nil
+}
+
+func (s *fakeCommentArticleStore) RemoveComments(ctx context.Context, articleID primitive.ObjectID, commentIDs []primitive.ObjectID) error {
+	return nil
+}
+
+func (s *fakeCommentArticleStore) SetCommentCount(ctx context.Context, articleID primitive.ObjectID, count int64) error {
+	s.articles[articleID].CommentCount = int(count)
+	return nil
+}
+
+func newTestCommentService() (*CommentService, *fakeCommentStore, *fakeCommentArticleStore, primitive.ObjectID) {
+	comments := &fakeCommentStore{comments: make(map[primitive.ObjectID]*model.Comment)}
+	articles := &fakeCommentArticleStore{articles: make(map[primitive.ObjectID]*model.Article)}
+	articleID := primitive.NewObjectID()
+	articles.articles[articleID] = &model.Article{ID: articleID}
+	return NewCommentServiceWithDAO(comments, articles), comments, articles, articleID
+}
+
+func TestCommentService_Create_ReplyRoot(t *testing.T) {
+	svc, _, articles, articleID := newTestCommentService()
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	root, err := svc.Create(ctx, articleID, userID, nil, "楼主")
+	if err != nil {
+		t.Fatalf("创建评论失败: %v", err)
+	}
+	if root.RootID != nil || root.ParentID != nil {
+		t.Errorf("顶层评论不应有 root_id 和 parent_id: %+v", root)
+	}
+
+	reply, err := svc.Create(ctx, articleID, userID, &root.ID, "回复楼主")
+	if err != nil {
+		t.Fatalf("回复失败: %v", err)
+	}
+	nested, err := svc.Create(ctx, articleID, userID, &reply.ID, "回复回复")
+	if err != nil {
+		t.Fatalf("回复失败: %v", err)
+	}
+
+	if *reply.RootID != root.ID || *reply.ParentID != root.ID {
+		t.Errorf("回复顶层评论时 root 与 parent 都应为顶层评论: %+v", reply)
+	}
+	if *nested.RootID != root.ID || *nested.ParentID != reply.ID {
+		t.Errorf("回复楼中回复时 root 应为顶层评论, parent 为被回复的评论: %+v", nested)
+	}
+	if got := articles.articles[articleID].CommentCount; got != 3 {
+		t.Errorf("期望评论数 3, 实际 %d", got)
+	}
+}
+
+func TestCommentService_Create_ParentFromOtherArticle(t *testing.T) {
+	svc, _, articles, articleID := newTestCommentService()
+	otherID := primitive.NewObjectID()
+	articles.articles[otherID] = &model.Article{ID: otherID}
+
+	parent, err := svc.Create(context.Background(), otherID, primitive.NewObjectID(), nil, "其他文章")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Create(context.Background(), articleID, primitive.NewObjectID(), &parent.ID, "跨文章回复")
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInvalidParams {
+		t.Errorf("期望参数错误, 实际 %v", err)
+	}
+}
+
+func TestCommentService_CountRecoversFromFailedWrite(t *testing.T) {
+	svc, _, articles, articleID := newTestCommentService()
+	ctx := context.Background()
+
+	// 评论已写入但更新文章失败，计数暂时落后
+	articles.addCommentErr = errors.New("write failed")
+	if _, err := svc.Create(ctx, articleID, primitive.NewObjectID(), nil, "第一条"); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	articles.addCommentErr = nil
+
+	if _, err := svc.Create(ctx, articleID, primitive.NewObjectID(), nil, "第二条"); err != nil {
+		t.Fatal(err)
+	}
+	if got := articles.articles[articleID].CommentCount; got != 2 {
+		t.Errorf("下一次写入应按评论集合纠正计数, 期望 2, 实际 %d", got)
+	}
+}
+
+func TestCommentService_Delete_RemovesDescendants(t *testing.T) {
+	svc, comments, articles, articleID := newTestCommentService()
+	ctx := context.Background()
+	author := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+
+	root, _ := svc.Create(ctx, articleID, other, nil, "楼主")
+	reply, _ := svc.Create(ctx, articleID, author, &root.ID, "回复")
+	nested, _ := svc.Create(ctx, articleID, other, &reply.ID, "回复回复")
+	sibling, _ := svc.Create(ctx, articleID, other, &root.ID, "另一条回复")
+
+	if err := svc.Delete(ctx, articleID, reply.ID, author, model.RoleUser); err != nil {
+		t.Fatalf("删除失败: %v", err)
+	}
+	for _, id := range []primitive.ObjectID{reply.ID, nested.ID} {
+		if _, ok := comments.comments[id]; ok {
+			t.Errorf("评论 %s 及其回复应被删除", id.Hex())
+		}
+	}
+	for _, id := range []primitive.ObjectID{root.ID, sibling.ID} {
+		if _, ok := comments.comments[id]; !ok {
+			t.Errorf("评论 %s 不应被删除", id.Hex())
+		}
+	}
+	if got := articles.articles[articleID].CommentCount; got != 2 {
+		t.Errorf("期望评论数 2, 实际 %d", got)
+	}
+}
+
+func TestCommentService_AuthorizedComment(t *testing.T) {
+	svc, _, _, articleID := newTestCommentService()
+	ctx := context.Background()
+	author := primitive.NewObjectID()
+	comment, _ := svc.Create(ctx, articleID, author, nil, "原文")
+
+	tests := []struct {
+		name      string
+		articleID primitive.ObjectID
+		userID    primitive.ObjectID
+		role      string
+		wantCode  int
+	}{
+		{"作者本人", articleID, author, model.RoleUser, apperrors.CodeSuccess},
+		{"审核员", articleID, primitive.NewObjectID(), model.RoleModerator, apperrors.CodeSuccess},
+		{"其他用户", articleID, primitive.NewObjectID(), model.RoleUser, apperrors.CodeForbidden},
+		{"不属于该文章", primitive.NewObjectID(), author, model.RoleUser, apperrors.CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.authorizedComment(ctx, tt.articleID, comment.ID, tt.userID, tt.role)
+			if tt.wantCode == apperrors.CodeSuccess {
+				if err != nil {
+					t.Errorf("期望允许, 实际 %v", err)
+				}
+				return
+			}
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Errorf("期望错误码 %d, 实际 %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestCommentWithDescendants(t *testing.T) {
+	root := primitive.NewObjectID()
+	a := primitive.NewObjectID()
+	b := primitive.NewObjectID()
+	c := primitive.NewObjectID()
+	d := primitive.NewObjectID()
+	// root <- a <- b <- c，root <- d
+	thread := []model.Comment{
+		{ID: c, RootID: &root, ParentID: &b},
+		{ID: root},
+		{ID: b, RootID: &root, ParentID: &a},
+		{ID: a, RootID: &root, ParentID: &root},
+		{ID: d, RootID: &root, ParentID: &root},
+	}
+
+	got := commentWithDescendants(thread, a)
+	want := map[primitive.ObjectID]bool{a: true, b: true, c: true}
+	if len(got) != len(want) {
+		t.Fatalf("期望 %d 条, 实际 %d 条", len(want), len(got))
+	}
+	for _, id := range got {
+		if !want[id] {
+			t.Errorf("不应包含 %s", id.Hex())
+		}
+	}
+
+	if got := commentWithDescendants(thread, root); len(got) != len(thread) {
+		t.Errorf("删除顶层评论应包含整个楼层, 实际 %d 条", len(got))
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/securetoken"
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrEmailInvalid         = errors.New("邮箱格式不正确")
+	ErrEmailTaken           = errors.New("该邮箱已被其他账号使用")
+	ErrEmailNotSet          = errors.New("尚未设置邮箱")
+	ErrEmailAlreadyVerified = errors.New("邮箱已验证")
+	ErrVerifyTokenInvalid   = errors.New("验证链接无效或已过期")
+)
+
+// 邮箱地址最大长度（RFC 5321）
+const maxEmailLength = 254
+
+// emailUserStore 邮箱服务用到的用户存储，由 dao.UserDAO 实现
+type emailUserStore interface {
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.User, error)
+	VerifiedEmailExists(ctx context.Context, email string, excludeID primitive.ObjectID) (bool, error)
+	UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error
+	MarkEmailVerified(ctx context.Context, id primitive.ObjectID, email string) error
+}
+
+// emailVerificationStore 邮箱验证令牌存储，由 dao.EmailVerificationDAO 实现
+type emailVerificationStore interface {
+	Create(ctx context.Context, token *model.EmailVerificationToken) error
+	FindByToken(ctx context.Context, selector, tokenHash string) (*model.EmailVerificationToken, error)
+	Consume(ctx context.Context, id primitive.ObjectID) error
+	InvalidateByUserID(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// EmailService 邮箱设置与验证
+type EmailService struct {
+	userDAO   emailUserStore
+	verifyDAO emailVerificationStore
+	outbox    *MailOutbox
+}
+
+// NewEmailService 创建邮箱服务
+func NewEmailService() *EmailService {
+	return &EmailService{
+		userDAO:   dao.NewUserDAO(),
+		verifyDAO: dao.NewEmailVerificationDAO(),
+		outbox:    GetMailOutbox(),
+	}
+}
+
+// NewEmailServiceWithDAO 使用指定的 DAO 和发件箱创建邮箱服务（用于测试）
+func NewEmailServiceWithDAO(userDAO emailUserStore, verifyDAO emailVerificationStore, outbox *MailOutbox) *EmailService {
+	return &EmailService{
+		userDAO:   userDAO,
+		verifyDAO: verifyDAO,
+		outbox:    outbox,
+	}
+}
+
+// ChangeEmail 设置或修改邮箱，新邮箱需要重新验证
+func (s *EmailService) ChangeEmail(ctx context.Context, userID primitive.ObjectID, email string) error {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	user, err := s.userDAO.FindByID(ctx, userID)
+	if err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	if user.Email == email && user.EmailVerified {
+		return nil
+	}
+	if err := s.checkEmailAvailable(ctx, email, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.userDAO.UpdateEmail(ctx, user.ID, email); err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	user.Email, user.EmailVerified = email, false
+	return s.sendVerification(ctx, user)
+}
+
+// ResendVerification 重新发送验证邮件，之前的验证链接失效
+func (s *EmailService) ResendVerification(ctx context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	user, err := s.userDAO.FindByID(ctx, userID)
+	if err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	if user.Email == "" {
+		return ErrEmailNotSet
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	return s.sendVerification(ctx, user)
+}
+
+// VerifyEmail 使用验证令牌确认邮箱
+func (s *EmailService) VerifyEmail(ctx context.Context, rawToken string) error {
+	token, err := securetoken.Parse(rawToken)
+	if err != nil {
+		return ErrVerifyTokenInvalid
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	verification, err := s.verifyDAO.FindByToken(ctx, token.Selector, hashOneTimeToken(token.Verifier))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrVerifyTokenInvalid
+		}
+		return apperrors.ServerError(err)
+	}
+	if verification.UsedAt != nil || time.Now().After(verification.ExpiresAt) {
+		return ErrVerifyTokenInvalid
+	}
+	if err := s.checkEmailAvailable(ctx, verification.Email, verification.UserID); err != nil {
+		return err
+	}
+
+	// 先原子地消费令牌再标记邮箱，并发请求中只有一个能继续
+	if err := s.verifyDAO.Consume(ctx, verification.ID); err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrVerifyTokenInvalid
+		}
+		return apperrors.ServerError(err)
+	}
+	// 用户在发送验证邮件后修改了邮箱，旧链接不再有效
+	if err := s.userDAO.MarkEmailVerified(ctx, verification.UserID, verification.Email); err != nil {
+		switch {
+		case apperrors.IsNotFound(err):
+			return ErrVerifyTokenInvalid
+		case mongo.IsDuplicateKeyError(err):
+			return ErrEmailTaken
+		}
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
+
+// checkEmailAvailable 邮箱是否已被其他账号验证
+func (s *EmailService) checkEmailAvailable(ctx context.Context, email string, userID primitive.ObjectID) error {
+	taken, err := s.userDAO.VerifiedEmailExists(ctx, email, userID)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if taken {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+// sendVerification 作废旧令牌，生成新令牌并写入发件箱
+func (s *EmailService) sendVerification(ctx context.Context, user *model.User) error {
+	if err := s.verifyDAO.InvalidateByUserID(ctx, user.ID); err != nil {
+		return apperrors.ServerError(err)
+	}
+
+	token, err := securetoken.Generate()
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	expire := config.AppConfig.EmailVerifyExpire
+	if err := s.verifyDAO.Create(ctx, &model.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Selector:  token.Selector,
+		TokenHash: hashOneTimeToken(token.Verifier),
+		ExpiresAt: time.Now().Add(expire),
+	}); err != nil {
+		return apperrors.ServerError(err)
+	}
+
+	return s.outbox.Enqueue(ctx, user.Email, MailVerifyEmail, user.Language, map[string]string{
+		"UserName":  user.UserName,
+		"Link":      mailLink("/verify-email", token.String()),
+		"ExpiresIn": formatExpiry(expire, user.Language),
+	})
+}
+
+// NormalizeEmail 去除空白并转为小写，格式不正确时返回 ErrEmailInvalid
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || len(email) > maxEmailLength {
+		return "", ErrEmailInvalid
+	}
+	// 只接受纯地址，不接受 "Name <addr>" 形式
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrEmailInvalid
+	}
+	return email, nil
+}
+
+// 确保实现接口
+var (
+	_ EmailServiceInterface  = (*EmailService)(nil)
+	_ emailUserStore         = (*dao.UserDAO)(nil)
+	_ emailVerificationStore = (*dao.EmailVerificationDAO)(nil)
+)
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/model"
+	"backend/pkg/securetoken"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// callLog 记录跨存储的调用顺序
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+// fakeEmailUserStore 内存用户存储，emailTaken 模拟邮箱已被其他账号验证，markErr 模拟标记失败
+type fakeEmailUserStore struct {
+	mu         sync.Mutex
+	users      map[primitive.ObjectID]*model.User
+	emailTaken bool
+	markErr    error
+	log        *callLog
+}
+
+func (s *fakeEmailUserStore) FindByID(ctx context.Context, id primitive.ObjectID) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.users[id]; ok {
+		return u, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *fakeEmailUserStore) VerifiedEmailExists(ctx context.Context, email string, excludeID primitive.ObjectID) (bool, error) {
+	return s.emailTaken, nil
+}
+
+func (s *fakeEmailUserStore) UpdateEmail(ctx context.Context, id primitive.ObjectID, email string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[id].Email, s.users[id].EmailVerified = email, false
+	return nil
+}
+
+func (s *fakeEmailUserStore) MarkEmailVerified(ctx context.Context, id primitive.ObjectID, email string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log.add("mark")
+	if s.markErr != nil {
+		return s.markErr
+	}
+	u, ok := s.users[id]
+	if !ok || u.Email != email {
+		return mongo.ErrNoDocuments
+	}
+	u.EmailVerified = true
+	return nil
+}
+
+// fakeEmailVerificationStore 内存验证令牌存储
+type fakeEmailVerificationStore struct {
+	mu     sync.Mutex
+	tokens map[primitive.ObjectID]*model.EmailVerificationToken
+	log    *callLog
+}
+
+func (s *fakeEmailVerificationStore) Create(ctx context.Context, token *model.EmailVerificationToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token.ID = primitive.NewObjectID()
+	s.tokens[token.ID] = token
+	return nil
+}
+
+func (s *fakeEmailVerificationStore) FindByToken(ctx context.Context, selector, tokenHash string) (*model.EmailVerificationToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tokens {
+		if t.Selector == selector && t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *fakeEmailVerificationStore) Consume(ctx context.Context, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log.add("consume")
+	t, ok := s.tokens[id]
+	if !ok || t.UsedAt != nil || !time.Now().Before(t.ExpiresAt) {
+		return mongo.ErrNoDocuments
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return nil
+}
+
+func (s *fakeEmailVerificationStore) InvalidateByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	return nil
+}
+
+// issue 为用户生成一个验证令牌，返回原始令牌
+func (s *fakeEmailVerificationStore) issue(t *testing.T, user *model.User, expiresAt time.Time) string {
+	t.Helper()
+	token, err := securetoken.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Create(context.Background(), &model.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Selector:  token.Selector,
+		TokenHash: hashOneTimeToken(token.Verifier),
+		ExpiresAt: expiresAt,
+	})
+	return token.String()
+}
+
+// emailTestEnv 邮箱服务及其依赖的内存存储
+type emailTestEnv struct {
+	svc    *EmailService
+	users  *fakeEmailUserStore
+	tokens *fakeEmailVerificationStore
+	log    *callLog
+	user   *model.User
+}
+
+func newTestEmailService(t *testing.T) *emailTestEnv {
+	t.Helper()
+	config.AppConfig = &config.Config{RefreshTokenHashKey: "test-hash-key"}
+
+	log := &callLog{}
+	user := &model.User{ID: primitive.NewObjectID(), UserName: "alice", Email: "alice@example.com"}
+	users := &fakeEmailUserStore{users: map[primitive.ObjectID]*model.User{user.ID: user}, log: log}
+	tokens := &fakeEmailVerificationStore{tokens: make(map[primitive.ObjectID]*model.EmailVerificationToken), log: log}
+	return &emailTestEnv{
+		svc:    NewEmailServiceWithDAO(users, tokens, nil),
+		users:  users,
+		tokens: tokens,
+		log:    log,
+		user:   user,
+	}
+}
+
+func TestEmailService_VerifyEmail(t *testing.T) {
+	env := newTestEmailService(t)
+	svc, user := env.svc, env.user
+	raw := env.tokens.issue(t, user, time.Now().Add(time.Hour))
+
+	if err := svc.VerifyEmail(context.Background(), raw); err != nil {
+		t.Fatalf("验证失败: %v", err)
+	}
+	if !user.EmailVerified {
+		t.Error("邮箱应标记为已验证")
+	}
+	if calls := env.log.calls; len(calls) != 2 || calls[0] != "consume" || calls[1] != "mark" {
+		t.Errorf("应先消费令牌再标记邮箱, 实际调用顺序 %v", calls)
+	}
+
+	if err := svc.VerifyEmail(context.Background(), raw); !errors.Is(err, ErrVerifyTokenInvalid) {
+		t.Errorf("令牌只能使用一次, 期望 ErrVerifyTokenInvalid, 实际 %v", err)
+	}
+}
+
+func TestEmailService_VerifyEmail_Concurrent(t *testing.T) {
+	env := newTestEmailService(t)
+	svc, user := env.svc, env.user
+	raw := env.tokens.issue(t, user, time.Now().Add(time.Hour))
+
+	const n = 8
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- svc.VerifyEmail(context.Background(), raw)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, ErrVerifyTokenInvalid) {
+			t.Errorf("期望 ErrVerifyTokenInvalid, 实际 %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("并发使用同一令牌只能成功一次, 实际成功 %d 次", succeeded)
+	}
+	marks := 0
+	for _, call := range env.log.calls {
+		if call == "mark" {
+			marks++
+		}
+	}
+	if marks != 1 {
+		t.Errorf("只有消费成功的请求才能标记邮箱, 实际标记 %d 次", marks)
+	}
+}
+
+func TestEmailService_VerifyEmail_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, env *emailTestEnv) string
+		want  error
+	}{
+		{"格式错误", func(t *testing.T, env *emailTestEnv) string {
+			return "not-a-token"
+		}, ErrVerifyTokenInvalid},
+		{"已过期", func(t *testing.T, env *emailTestEnv) string {
+			return env.tokens.issue(t, env.user, time.Now().Add(-time.Minute))
+		}, ErrVerifyTokenInvalid},
+		{"发送后修改了邮箱", func(t *testing.T, env *emailTestEnv) string {
+			raw := env.tokens.issue(t, env.user, time.Now().Add(time.Hour))
+			env.user.Email = "other@example.com"
+			return raw
+		}, ErrVerifyTokenInvalid},
+		{"邮箱已被其他账号验证", func(t *testing.T, env *emailTestEnv) string {
+			env.users.emailTaken = true
+			return env.tokens.issue(t, env.user, time.Now().Add(time.Hour))
+		}, ErrEmailTaken},
+		{"标记时唯一索引冲突", func(t *testing.T, env *emailTestEnv) string {
+			env.users.markErr = mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+			return env.tokens.issue(t, env.user, time.Now().Add(time.Hour))
+		}, ErrEmailTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEmailService(t)
+			raw := tt.setup(t, env)
+
+			if err := env.svc.VerifyEmail(context.Background(), raw); !errors.Is(err, tt.want) {
+				t.Errorf("期望 %v, 实际 %v", tt.want, err)
+			}
+			if env.user.EmailVerified {
+				t.Error("邮箱不应标记为已验证")
+			}
+		})
+	}
+}
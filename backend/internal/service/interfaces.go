@@ -2,38 +2,104 @@ package service
 
 import (
 	"backend/internal/model"
+	"backend/pkg/jwtkeys"
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // AuthServiceInterface 认证服务接口
 type AuthServiceInterface interface {
-	Login(ctx context.Context, username, password string) (*model.User, error)
-	Register(ctx context.Context, username, password string) (*model.User, error)
-	GenerateTokenPair(ctx context.Context, userID primitive.ObjectID) (*model.TokenPair, error)
+	Login(ctx context.Context, input LoginInput) (*model.User, error)
+	Register(ctx context.Context, input RegisterInput) (*model.User, error)
+	GenerateTokenPair(ctx context.Context, userID primitive.ObjectID, device model.DeviceInfo) (*model.TokenPair, error)
 	ValidateAccessToken(tokenString string) (*Claims, error)
-	RefreshTokenPair(ctx context.Context, refreshTokenStr string) (*model.TokenPair, error)
+	RefreshTokenPair(ctx context.Context, refreshTokenStr string, device model.DeviceInfo) (*model.TokenPair, error)
 	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeAccessToken(ctx context.Context, accessToken string) error
+	IsAccessTokenRevoked(jti string) bool
+	ListSessions(ctx context.Context, userID, currentSessionID primitive.ObjectID) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID primitive.ObjectID) error
+	RevokeAllSessions(ctx context.Context, userID, keepSessionID primitive.ObjectID) error
+	JWKS() jwtkeys.JWKS
+	CheckUserStatus(ctx context.Context, userID primitive.ObjectID) (string, error)
 }
 
 // ArticleServiceInterface 文章服务接口
 type ArticleServiceInterface interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*model.Article, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Article, error)
+	GetDetail(ctx context.Context, article *model.Article) (*model.ArticleDetail, error)
 	GetHot(ctx context.Context, limit int64) ([]model.Article, error)
-	GetList(ctx context.Context, tag string, skip, limit int64) ([]model.Article, error)
+	GetList(ctx context.Context, filter *model.ArticleFilter, skip, limit int64) ([]model.Article, error)
 	Search(ctx context.Context, keywords string, limit int64) ([]model.ArticleBrief, error)
-	GetExtend(ctx context.Context, tag string, limit int64) ([]model.ArticleBrief, error)
+	SearchArticles(ctx context.Context, query *model.ArticleSearchQuery) (*model.ArticleSearchResult, error)
+	GetExtend(ctx context.Context, filter *model.ArticleFilter, limit int64) ([]model.ArticleBrief, error)
 	GetInfo(ctx context.Context) (*model.ArticleInfo, error)
 	IncrementPageViews(ctx context.Context, id primitive.ObjectID) error
+	GetManaged(ctx context.Context, id, userID primitive.ObjectID, role string) (*model.Article, error)
+	ListManaged(ctx context.Context, userID primitive.ObjectID, role, status string, skip, limit int64) ([]model.Article, error)
+	Create(ctx context.Context, article *model.Article) (*model.Article, error)
+	Update(ctx context.Context, id, userID primitive.ObjectID, role string, update *model.ArticleUpdate) (*model.Article, error)
+	Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error
+	ChangeStatus(ctx context.Context, id, userID primitive.ObjectID, role, status string, publishAt time.Time) (*model.Article, error)
+}
+
+// TagServiceInterface 标签服务接口
+type TagServiceInterface interface {
+	List(ctx context.Context) ([]model.Tag, error)
+	UpdateDescription(ctx context.Context, slug, description string) (*model.Tag, error)
+}
+
+// CategoryServiceInterface 分类服务接口
+type CategoryServiceInterface interface {
+	Tree(ctx context.Context) ([]model.CategoryNode, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Category, error)
+	Create(ctx context.Context, category *model.Category) (*model.Category, error)
+	Update(ctx context.Context, id primitive.ObjectID, update *model.CategoryUpdate) (*model.Category, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// SeriesServiceInterface 系列服务接口
+type SeriesServiceInterface interface {
+	List(ctx context.Context) ([]model.SeriesSummary, error)
+	GetBySlug(ctx context.Context, slug string) (*model.SeriesDetail, error)
+	Create(ctx context.Context, series *model.Series) (*model.Series, error)
+	Update(ctx context.Context, id primitive.ObjectID, update *model.SeriesUpdate) (*model.Series, error)
+	SetArticles(ctx context.Context, id primitive.ObjectID, articleIDs []primitive.ObjectID) (*model.SeriesDetail, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// CommentServiceInterface 文章评论服务接口
+type CommentServiceInterface interface {
+	List(ctx context.Context, articleID primitive.ObjectID, skip, limit int64) (*model.CommentPage, error)
+	Create(ctx context.Context, articleID, userID primitive.ObjectID, parentID *primitive.ObjectID, content string) (*model.Comment, error)
+	Update(ctx context.Context, articleID, id, userID primitive.ObjectID, role, content string) (*model.Comment, error)
+	Delete(ctx context.Context, articleID, id, userID primitive.ObjectID, role string) error
 }
 
 // MessageServiceInterface 留言服务接口
 type MessageServiceInterface interface {
-	Create(ctx context.Context, userID primitive.ObjectID, content string) error
+	Create(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*model.Message, error)
-	AddReply(ctx context.Context, parentID, userID primitive.ObjectID, content, replyToUser string) error
+	AddReply(ctx context.Context, parentID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error)
 	GetListWithUser(ctx context.Context, skip, limit int64) ([]model.MessageWithUser, error)
+	Update(ctx context.Context, id, userID primitive.ObjectID, role, content string) (*model.Message, error)
+	Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error
+	UpdateReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role, content string) (*model.ReplyMessage, error)
+	DeleteReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role string) error
+	ModerationQueue(ctx context.Context, status string, skip, limit int64) (*model.ModerationPage, error)
+	Moderate(ctx context.Context, id, moderatorID primitive.ObjectID, status string) error
+	ModerateReply(ctx context.Context, id, replyID, moderatorID primitive.ObjectID, status string) error
+}
+
+// SensitiveWordServiceInterface 敏感词服务接口
+type SensitiveWordServiceInterface interface {
+	List(ctx context.Context) ([]model.SensitiveWord, error)
+	Create(ctx context.Context, word string, weight int, userID primitive.ObjectID) (*model.SensitiveWord, error)
+	UpdateWeight(ctx context.Context, id primitive.ObjectID, weight int) (*model.SensitiveWord, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
 }
 
 // VisitorServiceInterface 访客服务接口
@@ -46,6 +112,22 @@ type VisitorServiceInterface interface {
 type UserServiceInterface interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*model.User, error)
 	UpdateAvatar(ctx context.Context, id primitive.ObjectID, avatarURL string) error
+	SetDisabled(ctx context.Context, id primitive.ObjectID, disabled bool) error
+}
+
+// PasswordServiceInterface 密码修改与重置服务接口
+type PasswordServiceInterface interface {
+	ChangePassword(ctx context.Context, userID, sessionID primitive.ObjectID, oldPassword, newPassword string) error
+	CreateResetToken(ctx context.Context, userID, issuerID primitive.ObjectID) (*model.PasswordResetTicket, error)
+	ResetPassword(ctx context.Context, rawToken, newPassword, clientIP string) error
+	RequestReset(ctx context.Context, email, clientIP string) error
+}
+
+// EmailServiceInterface 邮箱设置与验证服务接口
+type EmailServiceInterface interface {
+	ChangeEmail(ctx context.Context, userID primitive.ObjectID, email string) error
+	ResendVerification(ctx context.Context, userID primitive.ObjectID) error
+	VerifyEmail(ctx context.Context, rawToken string) error
 }
 
 // CaptchaServiceInterface 验证码服务接口
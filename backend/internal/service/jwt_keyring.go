@@ -0,0 +1,82 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/logger"
+	"backend/pkg/jwtkeys"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 未配置密钥环时旧版 HS256 密钥的 kid，与历史签发的令牌一致
+const legacyJWTKeyID = "v1"
+
+var (
+	jwtKeyring     *jwtkeys.Keyring
+	jwtKeyringOnce sync.Once
+)
+
+// GetJWTKeyring 获取全局 JWT 密钥环（单例），配置错误时终止进程，应在启动时调用一次
+func GetJWTKeyring() *jwtkeys.Keyring {
+	jwtKeyringOnce.Do(func() {
+		keyring, err := LoadJWTKeyring(config.AppConfig)
+		if err != nil {
+			logger.Fatal("加载 JWT 密钥失败", logger.Err(err))
+		}
+		jwtKeyring = keyring
+	})
+	return jwtKeyring
+}
+
+// LoadJWTKeyring 按配置加载密钥环，未配置时退化为 JWTSecret 单密钥
+func LoadJWTKeyring(cfg *config.Config) (*jwtkeys.Keyring, error) {
+	specs, err := jwtKeySpecs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		secret := []byte(cfg.JWTSecret)
+		return jwtkeys.New([]*jwtkeys.Key{{
+			ID:        legacyJWTKeyID,
+			Method:    jwt.SigningMethodHS256,
+			SignKey:   secret,
+			VerifyKey: secret,
+		}}, cfg.JWTKeyOverlap)
+	}
+	return jwtkeys.Load(specs, cfg.JWTKeyOverlap)
+}
+
+func jwtKeySpecs(cfg *config.Config) ([]jwtkeys.KeySpec, error) {
+	var data []byte
+	baseDir := ""
+	switch {
+	case cfg.JWTKeysFile != "":
+		content, err := os.ReadFile(cfg.JWTKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+		baseDir = filepath.Dir(cfg.JWTKeysFile)
+	case cfg.JWTKeys != "":
+		data = []byte(cfg.JWTKeys)
+	default:
+		return nil, nil
+	}
+
+	var specs []jwtkeys.KeySpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("解析 JWT 密钥配置失败: %w", err)
+	}
+	// 密钥文件的相对路径相对于配置文件所在目录
+	for i := range specs {
+		if specs[i].File != "" && baseDir != "" && !filepath.IsAbs(specs[i].File) {
+			specs[i].File = filepath.Join(baseDir, specs[i].File)
+		}
+	}
+	return specs, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"backend/internal/config"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoginGuardPolicy 登录防爆破策略
+type LoginGuardPolicy struct {
+	// Window 两次失败间隔超过该时长后计数清零
+	Window time.Duration
+	// CaptchaAfter 用户名或 IP 失败达到该次数后要求验证码，并开始递增等待
+	CaptchaAfter int
+	// 递增等待：第 CaptchaAfter 次失败后等待 DelayBase，之后每次翻倍，最长 DelayMax
+	DelayBase time.Duration
+	DelayMax  time.Duration
+	// LockAfter 同一用户名失败达到该次数后锁定 LockDuration
+	LockAfter int
+	// IPLockAfter 同一 IP 失败达到该次数后锁定 LockDuration（一个 IP 可能尝试多个用户名，阈值应更高）
+	IPLockAfter  int
+	LockDuration time.Duration
+}
+
+// DefaultLoginGuardPolicy 从配置读取登录防爆破策略
+func DefaultLoginGuardPolicy() LoginGuardPolicy {
+	cfg := config.AppConfig
+	return LoginGuardPolicy{
+		Window:       cfg.LoginFailureWindow,
+		CaptchaAfter: cfg.LoginCaptchaAfter,
+		DelayBase:    cfg.LoginDelayBase,
+		DelayMax:     cfg.LoginDelayMax,
+		LockAfter:    cfg.LoginLockAfter,
+		IPLockAfter:  cfg.LoginIPLockAfter,
+		LockDuration: cfg.LoginLockDuration,
+	}
+}
+
+// LoginStatus 登录尝试的限制状态
+type LoginStatus struct {
+	// Locked 用户名或 IP 处于锁定中
+	Locked bool
+	// RetryAfter 锁定或递增等待的剩余时间
+	RetryAfter      time.Duration
+	CaptchaRequired bool
+}
+
+// LoginFailure 记录一次失败后的状态，LockedUser/LockedIP 表示本次失败触发了锁定
+type LoginFailure struct {
+	LoginStatus
+	LockedUser bool
+	LockedIP   bool
+}
+
+// LoginGuard 按用户名和 IP 统计登录失败次数（进程内存储）
+type LoginGuard struct {
+	mu        sync.Mutex
+	policy    LoginGuardPolicy
+	entries   map[string]*loginAttempts
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type loginAttempts struct {
+	failures int
+	// pending 已通过 Check 但尚未得出结果的尝试，reservedAt 为最近一次预留时间
+	pending     int
+	reservedAt  time.Time
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// LoginAttempt Check 预留的一次登录尝试，必须以 Fail、Succeed 或 Release 之一结束
+type LoginAttempt struct {
+	username string
+	ip       string
+}
+
+var (
+	loginGuard     *LoginGuard
+	loginGuardOnce sync.Once
+)
+
+// GetLoginGuard 获取全局登录防护（单例，保证所有 AuthService 共享失败计数）
+func GetLoginGuard() *LoginGuard {
+	loginGuardOnce.Do(func() {
+		loginGuard = NewLoginGuard(DefaultLoginGuardPolicy())
+	})
+	return loginGuard
+}
+
+// NewLoginGuard 创建登录防护
+func NewLoginGuard(policy LoginGuardPolicy) *LoginGuard {
+	return &LoginGuard{
+		policy:  policy,
+		entries: make(map[string]*loginAttempts),
+		now:     time.Now,
+	}
+}
+
+// Check 在校验密码前检查是否允许本次尝试，允许时原子地预留一次尝试。
+// 预留的尝试与失败次数一起计入阈值，达到验证码或锁定阈值后同一用户名/IP 同时只能有一个尝试，
+// 避免并发请求在任何失败被记录之前全部通过检查
+func (g *LoginGuard) Check(username, ip string) (LoginStatus, *LoginAttempt) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	keys := g.keys(username, ip)
+	var status LoginStatus
+	for _, key := range keys {
+		if a := g.get(key, now); a != nil {
+			g.merge(&status, a, g.lockLimit(key), now)
+		}
+	}
+	if status.Locked || status.RetryAfter > 0 {
+		return status, nil
+	}
+
+	for _, key := range keys {
+		a := g.get(key, now)
+		if a == nil {
+			a = &loginAttempts{last: now}
+			g.entries[key] = a
+		}
+		a.pending++
+		a.reservedAt = now
+	}
+	return status, &LoginAttempt{username: username, ip: ip}
+}
+
+// Fail 记录一次失败，达到阈值时锁定
+func (g *LoginGuard) Fail(attempt *LoginAttempt) LoginFailure {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	var result LoginFailure
+	for _, key := range g.keys(attempt.username, attempt.ip) {
+		a := g.get(key, now)
+		if a == nil {
+			a = &loginAttempts{}
+			g.entries[key] = a
+		}
+		a.pending = max(a.pending-1, 0)
+		a.failures++
+		a.last = now
+
+		limit := g.lockLimit(key)
+		if limit > 0 && a.failures >= limit && !now.Before(a.lockedUntil) {
+			a.lockedUntil = now.Add(g.policy.LockDuration)
+			if strings.HasPrefix(key, "ip:") {
+				result.LockedIP = true
+			} else {
+				result.LockedUser = true
+			}
+		}
+		g.merge(&result.LoginStatus, a, limit, now)
+	}
+	return result
+}
+
+// Succeed 登录成功后清除该用户名的失败记录（IP 计数保留，避免攻击者用自己的账号重置）
+func (g *LoginGuard) Succeed(attempt *LoginAttempt) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.release(attempt)
+	delete(g.entries, "user:"+normalizeLoginName(attempt.username))
+}
+
+// Release 释放未得出结果的尝试（如验证码错误、数据库出错），不计入失败次数
+func (g *LoginGuard) Release(attempt *LoginAttempt) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.release(attempt)
+}
+
+func (g *LoginGuard) release(attempt *LoginAttempt) {
+	for _, key := range g.keys(attempt.username, attempt.ip) {
+		if a, ok := g.entries[key]; ok {
+			a.pending = max(a.pending-1, 0)
+		}
+	}
+}
+
+// lockLimit 用户名与 IP 使用不同的锁定阈值
+func (g *LoginGuard) lockLimit(key string) int {
+	if strings.HasPrefix(key, "ip:") {
+		return g.policy.IPLockAfter
+	}
+	return g.policy.LockAfter
+}
+
+func (g *LoginGuard) keys(username, ip string) []string {
+	keys := []string{"user:" + normalizeLoginName(username)}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// get 返回仍然有效的失败记录，锁定到期后保留验证码要求重新计数
+func (g *LoginGuard) get(key string, now time.Time) *loginAttempts {
+	a, ok := g.entries[key]
+	if !ok {
+		return nil
+	}
+	if !a.lockedUntil.IsZero() && !now.Before(a.lockedUntil) {
+		a.lockedUntil = time.Time{}
+		a.failures = g.policy.CaptchaAfter
+		a.last = now
+	}
+	// 预留后长时间没有结果（如请求中途异常退出），视为已释放，避免永久阻塞
+	if a.pending > 0 && now.Sub(a.reservedAt) > g.policy.Window {
+		a.pending = 0
+	}
+	if a.lockedUntil.IsZero() && a.pending == 0 && now.Sub(a.last) > g.policy.Window {
+		delete(g.entries, key)
+		return nil
+	}
+	return a
+}
+
+// merge 合并一条记录的限制状态，进行中的尝试按失败计入阈值
+func (g *LoginGuard) merge(status *LoginStatus, a *loginAttempts, lockLimit int, now time.Time) {
+	if now.Before(a.lockedUntil) {
+		status.Locked = true
+		status.CaptchaRequired = true
+		status.RetryAfter = max(status.RetryAfter, a.lockedUntil.Sub(now))
+		return
+	}
+
+	attempts := a.failures + a.pending
+	captcha := g.policy.CaptchaAfter > 0 && attempts >= g.policy.CaptchaAfter
+	if captcha {
+		status.CaptchaRequired = true
+	}
+	// 达到阈值后已有尝试在进行时，等待其结果，避免并发绕过递增等待和锁定
+	if a.pending > 0 && (captcha || lockLimit > 0 && attempts >= lockLimit) {
+		status.RetryAfter = max(status.RetryAfter, g.delay(attempts), time.Second)
+		return
+	}
+	if captcha && a.failures >= g.policy.CaptchaAfter {
+		if wait := g.delay(a.failures) - now.Sub(a.last); wait > 0 {
+			status.RetryAfter = max(status.RetryAfter, wait)
+		}
+	}
+}
+
+// delay 第 n 次失败后需要等待的时长
+func (g *LoginGuard) delay(failures int) time.Duration {
+	if g.policy.DelayBase <= 0 {
+		return 0
+	}
+	d := g.policy.DelayBase
+	for i := g.policy.CaptchaAfter; i < failures && d < g.policy.DelayMax; i++ {
+		d *= 2
+	}
+	if g.policy.DelayMax > 0 && d > g.policy.DelayMax {
+		d = g.policy.DelayMax
+	}
+	return d
+}
+
+// sweep 清理过期记录，避免按 IP 计数时 map 无限增长
+func (g *LoginGuard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < time.Minute {
+		return
+	}
+	g.lastSweep = now
+	for key := range g.entries {
+		g.get(key, now)
+	}
+}
+
+func normalizeLoginName(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLoginGuard() (*LoginGuard, *time.Time) {
+	now := time.Now()
+	g := NewLoginGuard(LoginGuardPolicy{
+		Window:       15 * time.Minute,
+		CaptchaAfter: 3,
+		DelayBase:    time.Second,
+		DelayMax:     8 * time.Second,
+		LockAfter:    5,
+		IPLockAfter:  10,
+		LockDuration: 15 * time.Minute,
+	})
+	g.now = func() time.Time { return now }
+	return g, &now
+}
+
+// fail 等待递增延迟结束后记录一次失败
+func fail(t *testing.T, g *LoginGuard, now *time.Time, username, ip string) LoginFailure {
+	t.Helper()
+	*now = now.Add(g.policy.DelayMax)
+	status, attempt := g.Check(username, ip)
+	if attempt == nil {
+		t.Fatalf("%s@%s 的尝试被拒绝: %+v", username, ip, status)
+	}
+	return g.Fail(attempt)
+}
+
+func TestLoginGuard_Lock(t *testing.T) {
+	tests := []struct {
+		name string
+		// attempt 返回第 i 次尝试使用的用户名和 IP
+		attempt  func(i int) (string, string)
+		limit    int
+		lockedIP bool
+	}{
+		{"同一用户名从不同 IP 尝试", func(i int) (string, string) {
+			return "alice", fmt.Sprintf("10.0.0.%d", i)
+		}, 5, false},
+		{"同一 IP 尝试不同用户名", func(i int) (string, string) {
+			return fmt.Sprintf("user%d", i), "10.0.0.1"
+		}, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, now := newTestLoginGuard()
+			for i := 1; i < tt.limit; i++ {
+				username, ip := tt.attempt(i)
+				if result := fail(t, g, now, username, ip); result.LockedUser || result.LockedIP {
+					t.Fatalf("第 %d 次失败不应锁定", i)
+				}
+			}
+
+			username, ip := tt.attempt(tt.limit)
+			result := fail(t, g, now, username, ip)
+			if result.LockedIP != tt.lockedIP || result.LockedUser == tt.lockedIP || !result.Locked {
+				t.Fatalf("第 %d 次失败应锁定, 实际 %+v", tt.limit, result)
+			}
+			username, ip = tt.attempt(tt.limit + 1)
+			if tt.lockedIP {
+				ip = "10.0.0.1"
+			} else {
+				username = "alice"
+			}
+			if status, attempt := g.Check(username, ip); attempt != nil || !status.Locked || status.RetryAfter != 15*time.Minute {
+				t.Errorf("锁定期间应拒绝尝试, 实际 %+v", status)
+			}
+
+			// 锁定到期后计数回到验证码阈值，仍需验证码但不会立即再次锁定
+			*now = now.Add(15 * time.Minute)
+			if status, _ := g.Check(username, ip); status.Locked || !status.CaptchaRequired || status.RetryAfter != time.Second {
+				t.Errorf("锁定到期后应按验证码阈值重新计算等待, 实际 %+v", status)
+			}
+			if result = fail(t, g, now, username, ip); result.Locked {
+				t.Errorf("锁定到期后的下一次失败不应立即再次锁定, 实际 %+v", result)
+			}
+		})
+	}
+}
+
+func TestLoginGuard_Delay(t *testing.T) {
+	g, _ := newTestLoginGuard()
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{5, 4 * time.Second},
+		{6, 8 * time.Second},
+		{9, 8 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := g.delay(tt.failures); got != tt.want {
+			t.Errorf("第 %d 次失败后期望等待 %v, 实际 %v", tt.failures, tt.want, got)
+		}
+	}
+
+	g, now := newTestLoginGuard()
+	for range 3 {
+		fail(t, g, now, "alice", "")
+	}
+	if status, attempt := g.Check("alice", ""); attempt != nil || status.RetryAfter != time.Second || !status.CaptchaRequired {
+		t.Errorf("达到验证码阈值后应等待 1s, 实际 %+v", status)
+	}
+}
+
+func TestLoginGuard_ParallelCheckAfterThreshold(t *testing.T) {
+	g, now := newTestLoginGuard()
+	for range 3 {
+		fail(t, g, now, "alice", "10.0.0.1")
+	}
+	*now = now.Add(time.Minute)
+
+	const n = 8
+	attempts := make(chan *LoginAttempt, n)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, attempt := g.Check("alice", "10.0.0.1")
+			attempts <- attempt
+		}()
+	}
+	wg.Wait()
+	close(attempts)
+
+	var reserved *LoginAttempt
+	for attempt := range attempts {
+		if attempt == nil {
+			continue
+		}
+		if reserved != nil {
+			t.Fatal("达到阈值后同时只能预留一次尝试")
+		}
+		reserved = attempt
+	}
+	if reserved == nil {
+		t.Fatal("应有一次尝试被允许")
+	}
+
+	// 预留的尝试长时间没有结果时视为已释放
+	*now = now.Add(16 * time.Minute)
+	if _, attempt := g.Check("alice", "10.0.0.1"); attempt == nil {
+		t.Error("未结束的预留超过窗口后不应继续阻塞")
+	}
+}
+
+func TestLoginGuard_ReleaseNotCounted(t *testing.T) {
+	g, _ := newTestLoginGuard()
+	for i := range 10 {
+		status, attempt := g.Check("alice", "10.0.0.1")
+		if attempt == nil || status.CaptchaRequired {
+			t.Fatalf("第 %d 次尝试: 释放的尝试不应计入失败, 实际 %+v", i+1, status)
+		}
+		g.Release(attempt)
+	}
+}
+
+func TestLoginGuard_SucceedKeepsIPCounter(t *testing.T) {
+	g, now := newTestLoginGuard()
+	for range 3 {
+		fail(t, g, now, "alice", "10.0.0.1")
+	}
+	*now = now.Add(time.Minute)
+	_, attempt := g.Check("alice", "10.0.0.1")
+	g.Succeed(attempt)
+
+	if status, attempt := g.Check("alice", "10.0.0.2"); attempt == nil || status.CaptchaRequired {
+		t.Errorf("登录成功后应清除用户名计数, 实际 %+v", status)
+	}
+	if status, _ := g.Check("bob", "10.0.0.1"); !status.CaptchaRequired {
+		t.Errorf("登录成功不应清除 IP 计数, 实际 %+v", status)
+	}
+}
+
+func TestLoginGuard_Sweep(t *testing.T) {
+	g, now := newTestLoginGuard()
+	fail(t, g, now, "alice", "10.0.0.1")
+
+	*now = now.Add(16 * time.Minute)
+	fail(t, g, now, "bob", "10.0.0.2")
+	if _, ok := g.entries["user:alice"]; ok {
+		t.Error("超过窗口的记录应被清理")
+	}
+	if _, ok := g.entries["ip:10.0.0.1"]; ok {
+		t.Error("超过窗口的 IP 记录应被清理")
+	}
+	if len(g.entries) != 2 {
+		t.Errorf("期望保留 2 条记录, 实际 %d", len(g.entries))
+	}
+}
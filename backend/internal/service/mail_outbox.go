@@ -0,0 +1,203 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/logger"
+	"backend/internal/model"
+	"backend/pkg/mailer"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// 投递中的邮件在租约期内不会被其他实例再次领取
+	mailClaimLease = 5 * time.Minute
+	// 单次投递的超时时间
+	mailSendTimeout = time.Minute
+	// 重试间隔从 mailRetryBase 开始翻倍，最长 mailRetryMax
+	mailRetryBase = time.Minute
+	mailRetryMax  = 6 * time.Hour
+	// 每轮最多投递的邮件数，避免长时间占用
+	mailBatchSize = 50
+)
+
+// mailOutboxStore 发件箱存储，由 dao.MailOutboxDAO 实现
+type mailOutboxStore interface {
+	Create(ctx context.Context, mail *model.OutboxMail) error
+	ClaimDue(ctx context.Context, lease time.Duration) (*model.OutboxMail, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID) error
+	MarkRetry(ctx context.Context, id primitive.ObjectID, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string) error
+}
+
+var _ mailOutboxStore = (*dao.MailOutboxDAO)(nil)
+
+// MailOutbox 发件箱：业务代码调用 Enqueue 渲染并保存邮件，后台任务负责投递与重试
+type MailOutbox struct {
+	dao         mailOutboxStore
+	mailer      mailer.Mailer
+	interval    time.Duration
+	maxAttempts int
+}
+
+var (
+	mailOutbox     *MailOutbox
+	mailOutboxOnce sync.Once
+)
+
+// GetMailOutbox 获取全局发件箱（单例），邮件配置错误时终止进程，应在启动时调用一次
+func GetMailOutbox() *MailOutbox {
+	mailOutboxOnce.Do(func() {
+		m, err := NewMailer(config.AppConfig)
+		if err != nil {
+			logger.Fatal("初始化邮件发送失败", logger.Err(err))
+		}
+		mailOutbox = &MailOutbox{
+			dao:         dao.NewMailOutboxDAO(),
+			mailer:      m,
+			interval:    config.AppConfig.MailOutboxInterval,
+			maxAttempts: max(config.AppConfig.MailMaxAttempts, 1),
+		}
+	})
+	return mailOutbox
+}
+
+// NewMailer 按 MAIL_DRIVER 创建邮件发送器。file 与 log 会把邮件中的一次性链接写到磁盘或标准输出，
+// release 模式下不允许使用
+func NewMailer(cfg *config.Config) (mailer.Mailer, error) {
+	if cfg.GinMode == "release" && cfg.MailDriver != "smtp" {
+		return nil, fmt.Errorf("release 模式下 MAIL_DRIVER 必须为 smtp，当前为 %q", cfg.MailDriver)
+	}
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+			Security: cfg.SMTPSecurity,
+			Timeout:  mailSendTimeout,
+		})
+	case "file":
+		return mailer.NewFileMailer(cfg.MailFileDir, cfg.MailFrom), nil
+	case "log":
+		return mailer.NewLogMailer(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("未知的 MAIL_DRIVER: %q", cfg.MailDriver)
+	}
+}
+
+// Enqueue 按用户语言渲染模板并写入发件箱，邮件在后台异步发送
+func (o *MailOutbox) Enqueue(ctx context.Context, to, templateName, lang string, data any) error {
+	templates, ok := mailTemplates[templateName]
+	if !ok {
+		return apperrors.ServerError(fmt.Errorf("邮件模板 %s 不存在", templateName))
+	}
+	msg, err := templates[NormalizeLanguage(lang)].Render(to, data)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+
+	if err := o.dao.Create(ctx, &model.OutboxMail{
+		To:       msg.To,
+		Subject:  msg.Subject,
+		Body:     msg.Body,
+		Template: templateName,
+	}); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
+
+// Start 启动后台投递任务，ctx 取消后退出
+func (o *MailOutbox) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := o.Deliver(ctx); err != nil && ctx.Err() == nil {
+					logger.Error("投递邮件失败", logger.Err(err))
+				}
+			}
+		}
+	}()
+}
+
+// Deliver 投递到期的邮件，返回成功发送的数量
+func (o *MailOutbox) Deliver(ctx context.Context) (int, error) {
+	sent := 0
+	for range mailBatchSize {
+		claimCtx, cancel := dao.WithDefaultTimeout(ctx)
+		mail, err := o.dao.ClaimDue(claimCtx, mailClaimLease)
+		cancel()
+		if err != nil {
+			if apperrors.IsNotFound(err) {
+				return sent, nil
+			}
+			return sent, err
+		}
+
+		if o.send(ctx, mail) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send 发送单封邮件并记录结果，失败时按指数退避安排重试
+func (o *MailOutbox) send(ctx context.Context, mail *model.OutboxMail) bool {
+	sendCtx, cancel := context.WithTimeout(ctx, mailSendTimeout)
+	sendErr := o.mailer.Send(sendCtx, &mailer.Message{To: mail.To, Subject: mail.Subject, Body: mail.Body})
+	cancel()
+
+	ctx, cancel = dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if sendErr == nil {
+		if err := o.dao.MarkSent(ctx, mail.ID); err != nil {
+			logger.Error("更新邮件状态失败", logger.String("mail_id", mail.ID.Hex()), logger.Err(err))
+		}
+		return true
+	}
+
+	var err error
+	if mail.Attempts >= o.maxAttempts {
+		logger.Error("邮件投递失败，已放弃",
+			logger.String("mail_id", mail.ID.Hex()),
+			logger.String("template", mail.Template),
+			logger.Int("attempts", mail.Attempts),
+			logger.Err(sendErr),
+		)
+		err = o.dao.MarkFailed(ctx, mail.ID, sendErr.Error())
+	} else {
+		delay := mailRetryDelay(mail.Attempts)
+		logger.Warn("邮件投递失败，稍后重试",
+			logger.String("mail_id", mail.ID.Hex()),
+			logger.Int("attempts", mail.Attempts),
+			logger.Err(sendErr),
+		)
+		err = o.dao.MarkRetry(ctx, mail.ID, time.Now().Add(delay), sendErr.Error())
+	}
+	if err != nil {
+		logger.Error("更新邮件状态失败", logger.String("mail_id", mail.ID.Hex()), logger.Err(err))
+	}
+	return false
+}
+
+// mailRetryDelay 第 attempts 次投递失败后的重试间隔
+func mailRetryDelay(attempts int) time.Duration {
+	return min(mailRetryBase<<min(max(attempts-1, 0), 16), mailRetryMax)
+}
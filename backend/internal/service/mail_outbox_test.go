@@ -0,0 +1,151 @@
+package service
+
+import (
+	"backend/internal/logger"
+	"backend/internal/model"
+	"backend/pkg/mailer"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeOutboxStore 内存发件箱，ClaimDue 依次返回 queue 中的邮件
+type fakeOutboxStore struct {
+	queue  []*model.OutboxMail
+	sent   []primitive.ObjectID
+	failed []primitive.ObjectID
+	retry  map[primitive.ObjectID]time.Time
+}
+
+func (s *fakeOutboxStore) Create(ctx context.Context, mail *model.OutboxMail) error {
+	mail.ID = primitive.NewObjectID()
+	s.queue = append(s.queue, mail)
+	return nil
+}
+
+func (s *fakeOutboxStore) ClaimDue(ctx context.Context, lease time.Duration) (*model.OutboxMail, error) {
+	if len(s.queue) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	mail := s.queue[0]
+	s.queue = s.queue[1:]
+	mail.Attempts++
+	return mail, nil
+}
+
+func (s *fakeOutboxStore) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	s.sent = append(s.sent, id)
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkRetry(ctx context.Context, id primitive.ObjectID, nextAttemptAt time.Time, lastError string) error {
+	if s.retry == nil {
+		s.retry = make(map[primitive.ObjectID]time.Time)
+	}
+	s.retry[id] = nextAttemptAt
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string) error {
+	s.failed = append(s.failed, id)
+	return nil
+}
+
+// fakeMailer 对 failTo 中的收件人返回错误
+type fakeMailer struct {
+	failTo    map[string]bool
+	delivered []string
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	if m.failTo[msg.To] {
+		return errors.New("smtp unavailable")
+	}
+	m.delivered = append(m.delivered, msg.To)
+	return nil
+}
+
+func TestMailRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, mailRetryMax},
+		{100, mailRetryMax},
+	}
+	for _, tt := range tests {
+		if got := mailRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("mailRetryDelay(%d) = %v, 期望 %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestMailOutbox_Deliver(t *testing.T) {
+	logger.Init("test")
+
+	ok := &model.OutboxMail{ID: primitive.NewObjectID(), To: "ok@example.com"}
+	retry := &model.OutboxMail{ID: primitive.NewObjectID(), To: "down@example.com", Attempts: 2}
+	giveUp := &model.OutboxMail{ID: primitive.NewObjectID(), To: "down@example.com", Attempts: 4}
+	store := &fakeOutboxStore{queue: []*model.OutboxMail{ok, retry, giveUp}}
+	m := &fakeMailer{failTo: map[string]bool{"down@example.com": true}}
+	o := &MailOutbox{dao: store, mailer: m, maxAttempts: 5}
+
+	before := time.Now()
+	sent, err := o.Deliver(context.Background())
+	if err != nil {
+		t.Fatalf("Deliver 返回错误: %v", err)
+	}
+	if sent != 1 || len(m.delivered) != 1 {
+		t.Fatalf("期望发送 1 封, 实际 %d", sent)
+	}
+	if len(store.sent) != 1 || store.sent[0] != ok.ID {
+		t.Errorf("成功的邮件应标记为已发送: %v", store.sent)
+	}
+
+	// 第 3 次失败后按指数退避等待 4 分钟
+	next, ok2 := store.retry[retry.ID]
+	if !ok2 {
+		t.Fatal("未达到上限的邮件应安排重试")
+	}
+	if wait := next.Sub(before); wait < 4*time.Minute || wait > 4*time.Minute+time.Second {
+		t.Errorf("期望约 4 分钟后重试, 实际 %v", wait)
+	}
+
+	if len(store.failed) != 1 || store.failed[0] != giveUp.ID {
+		t.Errorf("达到最大次数的邮件应标记为失败: %v", store.failed)
+	}
+	if _, ok := store.retry[giveUp.ID]; ok {
+		t.Error("达到最大次数的邮件不应再重试")
+	}
+}
+
+func TestMailOutbox_Enqueue(t *testing.T) {
+	store := &fakeOutboxStore{}
+	o := &MailOutbox{dao: store}
+
+	err := o.Enqueue(context.Background(), "user@example.com", MailVerifyEmail, "zh", map[string]string{
+		"UserName": "alice", "Link": "https://example.com/verify-email?token=x", "ExpiresIn": "1 小时",
+	})
+	if err != nil {
+		t.Fatalf("Enqueue 返回错误: %v", err)
+	}
+	if len(store.queue) != 1 {
+		t.Fatalf("期望写入 1 封邮件, 实际 %d", len(store.queue))
+	}
+	if mail := store.queue[0]; mail.To != "user@example.com" || mail.Template != MailVerifyEmail || mail.Body == "" {
+		t.Errorf("写入的邮件不正确: %+v", mail)
+	}
+
+	if err := o.Enqueue(context.Background(), "user@example.com", "missing", "zh", nil); err == nil {
+		t.Error("不存在的模板应返回错误")
+	}
+}
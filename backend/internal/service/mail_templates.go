@@ -0,0 +1,110 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/pkg/mailer"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 邮件语言
+const (
+	LanguageZH = "zh"
+	LanguageEN = "en"
+)
+
+// 邮件模板名称
+const (
+	MailVerifyEmail   = "verify_email"
+	MailPasswordReset = "password_reset"
+)
+
+// mailTemplates 内置邮件模板，按模板名称和语言索引
+var mailTemplates = map[string]map[string]*mailer.Template{
+	MailVerifyEmail: {
+		LanguageZH: mailer.MustParseTemplate(MailVerifyEmail+"."+LanguageZH,
+			"请验证你的邮箱",
+			`{{.UserName}}，你好：
+
+请点击下面的链接验证你的邮箱地址：
+
+{{.Link}}
+
+链接在 {{.ExpiresIn}} 内有效，只能使用一次。如果这不是你的操作，请忽略本邮件。
+`),
+		LanguageEN: mailer.MustParseTemplate(MailVerifyEmail+"."+LanguageEN,
+			"Verify your email address",
+			`Hi {{.UserName}},
+
+Please confirm your email address by opening the link below:
+
+{{.Link}}
+
+The link is valid for {{.ExpiresIn}} and can be used only once. If you did not request this, you can ignore this email.
+`),
+	},
+	MailPasswordReset: {
+		LanguageZH: mailer.MustParseTemplate(MailPasswordReset+"."+LanguageZH,
+			"重置你的密码",
+			`{{.UserName}}，你好：
+
+我们收到了重置你账号密码的请求，请点击下面的链接设置新密码：
+
+{{.Link}}
+
+链接在 {{.ExpiresIn}} 内有效，只能使用一次。重置后所有设备都需要重新登录。
+如果这不是你的操作，请忽略本邮件，你的密码不会改变。
+`),
+		LanguageEN: mailer.MustParseTemplate(MailPasswordReset+"."+LanguageEN,
+			"Reset your password",
+			`Hi {{.UserName}},
+
+We received a request to reset the password of your account. Open the link below to choose a new password:
+
+{{.Link}}
+
+The link is valid for {{.ExpiresIn}} and can be used only once. All devices will be signed out after the reset.
+If you did not request this, you can ignore this email and your password will stay the same.
+`),
+	},
+}
+
+// NormalizeLanguage 将 Accept-Language 或用户设置转换为支持的邮件语言，默认中文
+func NormalizeLanguage(lang string) string {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(lang)), LanguageEN) {
+		return LanguageEN
+	}
+	return LanguageZH
+}
+
+// mailLink 生成邮件中带令牌的前端链接
+func mailLink(path, token string) string {
+	return config.AppConfig.MailLinkBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// formatExpiry 以邮件语言描述有效期，如 "24 小时" / "24 hours"
+func formatExpiry(d time.Duration, lang string) string {
+	en := NormalizeLanguage(lang) == LanguageEN
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		if en {
+			return plural(int(d/time.Hour), "hour")
+		}
+		return fmt.Sprintf("%d 小时", d/time.Hour)
+	default:
+		minutes := max(int(d/time.Minute), 1)
+		if en {
+			return plural(minutes, "minute")
+		}
+		return fmt.Sprintf("%d 分钟", minutes)
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -1,45 +1,114 @@
 package service
 
 import (
+	"backend/internal/config"
 	"backend/internal/dao"
 	apperrors "backend/internal/errors"
 	"backend/internal/model"
+	"backend/pkg/markdown"
 	"context"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MessagePolicy 留言自动审核与编辑规则
+type MessagePolicy struct {
+	// Mode 为 config.MessageModerationOff / FirstPost / All
+	Mode string
+	// TrustedRoles 发言自动通过的角色
+	TrustedRoles []string
+	// TrustAfter first_post 模式下累计通过多少条后自动信任
+	TrustAfter int64
+	// EditWindow 作者可修改留言的时间窗口，0 表示不限制
+	EditWindow time.Duration
+}
+
+// DefaultMessagePolicy 从配置读取留言规则
+func DefaultMessagePolicy() MessagePolicy {
+	return MessagePolicy{
+		Mode:         config.AppConfig.MessageModeration,
+		TrustedRoles: config.AppConfig.MessageTrustedRoles,
+		TrustAfter:   config.AppConfig.MessageTrustAfter,
+		EditWindow:   config.AppConfig.MessageEditWindow,
+	}
+}
+
+func (p MessagePolicy) isTrustedRole(role string) bool {
+	for _, r := range p.TrustedRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // MessageService 留言服务实现
 type MessageService struct {
 	messageDAO *dao.MessageDAO
+	infoCache  *ArticleInfoCache
+	policy     MessagePolicy
+	spam       *SpamFilterChain
 }
 
 // NewMessageService 创建留言服务
 func NewMessageService() *MessageService {
+	messageDAO := dao.NewMessageDAO()
 	return &MessageService{
-		messageDAO: dao.NewMessageDAO(),
+		messageDAO: messageDAO,
+		infoCache:  GetArticleInfoCache(),
+		policy:     DefaultMessagePolicy(),
+		spam:       NewDefaultSpamFilterChain(messageDAO),
 	}
 }
 
 // NewMessageServiceWithDAO 使用指定的 DAO 创建留言服务（用于测试）
-func NewMessageServiceWithDAO(messageDAO *dao.MessageDAO) *MessageService {
+func NewMessageServiceWithDAO(messageDAO *dao.MessageDAO, infoCache *ArticleInfoCache, policy MessagePolicy, spam *SpamFilterChain) *MessageService {
 	return &MessageService{
 		messageDAO: messageDAO,
+		infoCache:  infoCache,
+		policy:     policy,
+		spam:       spam,
 	}
 }
 
-// Create 创建留言
-func (s *MessageService) Create(ctx context.Context, userID primitive.ObjectID, content string) error {
+// Create 创建留言，先经过垃圾内容过滤，再根据审核规则决定直接通过还是进入审核队列
+func (s *MessageService) Create(ctx context.Context, userID primitive.ObjectID, role, content string) (*model.Message, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	if err := s.messageDAO.Create(ctx, userID, content); err != nil {
-		return apperrors.ServerError(err)
+	content, err := normalizeMessageContent(content)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	status, verdict, err := s.submissionStatus(ctx, userID, role, content)
+	if err != nil {
+		return nil, err
+	}
+	contentHTML, err := renderUserContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.messageDAO.Create(ctx, &model.Message{
+		UserID:      userID,
+		Content:     content,
+		ContentHTML: contentHTML,
+		Status:      status,
+		SpamScore:   verdict.Score,
+		SpamReasons: verdict.Reasons,
+	})
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if status == model.MessageStatusApproved {
+		s.infoCache.Invalidate()
+	}
+	return msg, nil
 }
 
-// GetByID 根据 ID 获取留言
+// GetByID 根据 ID 获取留言，未通过审核的留言视为不存在
 func (s *MessageService) GetByID(ctx context.Context, id primitive.ObjectID) (*model.Message, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
@@ -48,21 +117,58 @@ func (s *MessageService) GetByID(ctx context.Context, id primitive.ObjectID) (*m
 	if err != nil {
 		return nil, apperrors.WrapMongoError(err, "留言")
 	}
+	if msg.GetStatus() != model.MessageStatusApproved || msg.IsDeleted() {
+		return nil, apperrors.NotFoundError("留言")
+	}
 	return msg, nil
 }
 
-// AddReply 添加回复
-func (s *MessageService) AddReply(ctx context.Context, parentID, userID primitive.ObjectID, content, replyToUser string) error {
+// AddReply 添加回复，只能回复已通过审核的留言，回复对象必须是该留言的参与者
+func (s *MessageService) AddReply(ctx context.Context, parentID, userID primitive.ObjectID, role, content string, target model.ReplyTarget) (*model.ReplyMessage, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
 
-	if err := s.messageDAO.AddReplyMessage(ctx, parentID, userID, content, replyToUser); err != nil {
-		return apperrors.ServerError(err)
+	parent, err := s.messageDAO.FindByID(ctx, parentID)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "留言")
 	}
-	return nil
+	if parent.GetStatus() != model.MessageStatusApproved || parent.IsDeleted() {
+		return nil, apperrors.NotFoundError("留言")
+	}
+
+	content, err = normalizeMessageContent(content)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := resolveReplyTarget(parent, target)
+	if err != nil {
+		return nil, err
+	}
+
+	status, verdict, err := s.submissionStatus(ctx, userID, role, content)
+	if err != nil {
+		return nil, err
+	}
+	if reply.ContentHTML, err = renderUserContent(content); err != nil {
+		return nil, err
+	}
+	reply.UserID = userID
+	reply.Content = content
+	reply.Status = status
+	reply.SpamScore = verdict.Score
+	reply.SpamReasons = verdict.Reasons
+
+	reply, err = s.messageDAO.AddReplyMessage(ctx, parentID, *reply)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "留言")
+	}
+	if status == model.MessageStatusApproved {
+		s.infoCache.Invalidate()
+	}
+	return reply, nil
 }
 
-// GetListWithUser 获取带用户信息的留言列表
+// GetListWithUser 获取带用户信息的留言列表（仅包含已通过审核的留言和回复）
 func (s *MessageService) GetListWithUser(ctx context.Context, skip, limit int64) ([]model.MessageWithUser, error) {
 	ctx, cancel := dao.WithDefaultTimeout(ctx)
 	defer cancel()
@@ -71,8 +177,356 @@ func (s *MessageService) GetListWithUser(ctx context.Context, skip, limit int64)
 	if err != nil {
 		return nil, apperrors.ServerError(err)
 	}
+
+	// 渲染功能上线前保存的留言没有 content_html，即时渲染
+	for i := range messages {
+		msg := &messages[i]
+		if msg.ContentHTML == "" && msg.Content != "" {
+			if msg.ContentHTML, err = renderUserContent(msg.Content); err != nil {
+				return nil, err
+			}
+		}
+		for j := range msg.Replies {
+			reply := &msg.Replies[j]
+			if reply.ContentHTML == "" && reply.Content != "" {
+				if reply.ContentHTML, err = renderUserContent(reply.Content); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 	return messages, nil
 }
 
+// Update 修改留言，作者需在编辑窗口内，审核员不受限制
+func (s *MessageService) Update(ctx context.Context, id, userID primitive.ObjectID, role, content string) (*model.Message, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	content, err := normalizeMessageContent(content)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.messageDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "留言")
+	}
+	if msg.IsDeleted() {
+		return nil, apperrors.NotFoundError("留言")
+	}
+
+	status, err := s.editStatus(ctx, msg.UserID, msg.CreatedAt, msg.GetStatus(), userID, role, content)
+	if err != nil {
+		return nil, err
+	}
+	contentHTML, err := renderUserContent(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageDAO.UpdateContent(ctx, id, content, contentHTML, status); err != nil {
+		return nil, apperrors.WrapMongoError(err, "留言")
+	}
+	s.infoCache.Invalidate()
+
+	now := time.Now()
+	msg.Content = content
+	msg.ContentHTML = contentHTML
+	msg.Status = status
+	msg.EditedAt = &now
+	return msg, nil
+}
+
+// Delete 删除留言，只清空内容并保留墓碑，其下的回复不受影响
+func (s *MessageService) Delete(ctx context.Context, id, userID primitive.ObjectID, role string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	msg, err := s.messageDAO.FindByID(ctx, id)
+	if err != nil {
+		return apperrors.WrapMongoError(err, "留言")
+	}
+	if msg.IsDeleted() {
+		return apperrors.NotFoundError("留言")
+	}
+	if err := authorizeMessageAuthor(msg.UserID, userID, role); err != nil {
+		return err
+	}
+
+	if err := s.messageDAO.SoftDelete(ctx, id, userID); err != nil {
+		return apperrors.WrapMongoError(err, "留言")
+	}
+	s.infoCache.Invalidate()
+	return nil
+}
+
+// UpdateReply 修改回复，规则与 Update 相同
+func (s *MessageService) UpdateReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role, content string) (*model.ReplyMessage, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	content, err := normalizeMessageContent(content)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s.findReply(ctx, id, replyID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.editStatus(ctx, reply.UserID, reply.CreatedAt, reply.GetStatus(), userID, role, content)
+	if err != nil {
+		return nil, err
+	}
+	contentHTML, err := renderUserContent(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.messageDAO.UpdateReplyContent(ctx, id, replyID, content, contentHTML, status); err != nil {
+		return nil, apperrors.WrapMongoError(err, "回复")
+	}
+	s.infoCache.Invalidate()
+
+	now := time.Now()
+	reply.Content = content
+	reply.ContentHTML = contentHTML
+	reply.Status = status
+	reply.EditedAt = &now
+	return reply, nil
+}
+
+// DeleteReply 删除回复，保留墓碑
+func (s *MessageService) DeleteReply(ctx context.Context, id, replyID, userID primitive.ObjectID, role string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	reply, err := s.findReply(ctx, id, replyID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeMessageAuthor(reply.UserID, userID, role); err != nil {
+		return err
+	}
+
+	if err := s.messageDAO.SoftDeleteReply(ctx, id, replyID, userID); err != nil {
+		return apperrors.WrapMongoError(err, "回复")
+	}
+	s.infoCache.Invalidate()
+	return nil
+}
+
+// BackfillReplyIDs 为旧数据中的回复补充 ID
+func (s *MessageService) BackfillReplyIDs(ctx context.Context) (int, error) {
+	n, err := s.messageDAO.BackfillReplyIDs(ctx)
+	if err != nil {
+		return n, apperrors.ServerError(err)
+	}
+	return n, nil
+}
+
+// ModerationQueue 按状态列出审核队列，status 为空时列出待审核内容
+func (s *MessageService) ModerationQueue(ctx context.Context, status string, skip, limit int64) (*model.ModerationPage, error) {
+	if status == "" {
+		status = model.MessageStatusPending
+	}
+	if !model.IsValidMessageStatus(status) {
+		return nil, apperrors.InvalidParamsError("无效的审核状态")
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	list, total, err := s.messageDAO.FindModerationQueue(ctx, status, skip, limit)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return &model.ModerationPage{List: list, Total: total, Skip: skip, Limit: limit}, nil
+}
+
+// Moderate 修改留言的审核状态
+func (s *MessageService) Moderate(ctx context.Context, id, moderatorID primitive.ObjectID, status string) error {
+	if !model.IsValidMessageStatus(status) {
+		return apperrors.InvalidParamsError("无效的审核状态")
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.messageDAO.SetStatus(ctx, id, status, moderatorID); err != nil {
+		return apperrors.WrapMongoError(err, "留言")
+	}
+	s.infoCache.Invalidate()
+	return nil
+}
+
+// ModerateReply 修改回复的审核状态
+func (s *MessageService) ModerateReply(ctx context.Context, id, replyID, moderatorID primitive.ObjectID, status string) error {
+	if !model.IsValidMessageStatus(status) {
+		return apperrors.InvalidParamsError("无效的审核状态")
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.messageDAO.SetReplyStatus(ctx, id, replyID, status, moderatorID); err != nil {
+		return apperrors.WrapMongoError(err, "回复")
+	}
+	s.infoCache.Invalidate()
+	return nil
+}
+
+// resolveReplyTarget 校验回复对象并填充到新回复中：
+// 指定回复 ID 时以该回复的作者为回复对象；只指定用户 ID 时该用户须参与过此留言；
+// 都未指定时默认回复留言作者。旧版接口只提交用户名，按原样保存
+func resolveReplyTarget(parent *model.Message, target model.ReplyTarget) (*model.ReplyMessage, error) {
+	reply := &model.ReplyMessage{}
+
+	switch {
+	case target.ReplyID != nil:
+		r := parent.FindReply(*target.ReplyID)
+		if r == nil || r.IsDeleted() || r.GetStatus() != model.MessageStatusApproved {
+			return nil, apperrors.NotFoundError("被回复的回复")
+		}
+		if target.UserID != nil && *target.UserID != r.UserID {
+			return nil, apperrors.InvalidParamsError("回复对象与被回复的回复不一致")
+		}
+		targetUserID := r.UserID
+		reply.ReplyToUserID = &targetUserID
+		reply.ReplyToReplyID = target.ReplyID
+	case target.UserID != nil:
+		if !parent.IsParticipant(*target.UserID) {
+			return nil, apperrors.InvalidParamsError("回复对象不在该留言中")
+		}
+		reply.ReplyToUserID = target.UserID
+	case target.LegacyName != "":
+		reply.ReplyToUser = target.LegacyName
+	default:
+		authorID := parent.UserID
+		reply.ReplyToUserID = &authorID
+	}
+	return reply, nil
+}
+
+// findReply 查找未删除的回复
+func (s *MessageService) findReply(ctx context.Context, id, replyID primitive.ObjectID) (*model.ReplyMessage, error) {
+	msg, err := s.messageDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "留言")
+	}
+	reply := msg.FindReply(replyID)
+	if reply == nil || reply.IsDeleted() {
+		return nil, apperrors.NotFoundError("回复")
+	}
+	return reply, nil
+}
+
+// editStatus 校验编辑权限并返回编辑后的审核状态：
+// 审核员修改不改变状态；作者修改的内容同样经过垃圾内容过滤，已通过的内容按审核规则重新判定
+func (s *MessageService) editStatus(ctx context.Context, authorID primitive.ObjectID, createdAt time.Time, status string, userID primitive.ObjectID, role, content string) (string, error) {
+	if model.HasPermission(role, model.PermMessageModerate) {
+		return status, nil
+	}
+	if err := authorizeMessageAuthor(authorID, userID, role); err != nil {
+		return "", err
+	}
+	if s.policy.EditWindow > 0 && time.Since(createdAt) > s.policy.EditWindow {
+		return "", apperrors.ForbiddenError("已超过可修改的时间")
+	}
+
+	verdict, err := s.checkSpam(ctx, &SpamInput{UserID: userID, Content: content, Edit: true}, role)
+	if err != nil {
+		return "", err
+	}
+	if verdict.Action == SpamActionHold && status != model.MessageStatusRejected && status != model.MessageStatusSpam {
+		return model.MessageStatusPending, nil
+	}
+
+	switch status {
+	case model.MessageStatusApproved:
+		return s.initialStatus(ctx, userID, role)
+	case model.MessageStatusPending:
+		return status, nil
+	default:
+		return "", apperrors.ForbiddenError("未通过审核的内容不能修改")
+	}
+}
+
+// renderUserContent 渲染用户提交的内容并按严格白名单过滤
+func renderUserContent(content string) (string, error) {
+	rendered, err := markdown.RenderMessage(content)
+	if err != nil {
+		return "", apperrors.ServerError(err)
+	}
+	return rendered, nil
+}
+
+// submissionStatus 对新发言执行垃圾内容过滤并决定初始审核状态，过滤链判定为 hold 时进入审核队列
+func (s *MessageService) submissionStatus(ctx context.Context, userID primitive.ObjectID, role, content string) (string, *SpamVerdict, error) {
+	verdict, err := s.checkSpam(ctx, &SpamInput{UserID: userID, Content: content}, role)
+	if err != nil {
+		return "", nil, err
+	}
+	if verdict.Action == SpamActionHold {
+		return model.MessageStatusPending, verdict, nil
+	}
+
+	status, err := s.initialStatus(ctx, userID, role)
+	if err != nil {
+		return "", nil, err
+	}
+	return status, verdict, nil
+}
+
+// checkSpam 执行垃圾内容过滤链，拥有审核权限的用户不受限制；判定为 reject 时返回错误
+func (s *MessageService) checkSpam(ctx context.Context, input *SpamInput, role string) (*SpamVerdict, error) {
+	if model.HasPermission(role, model.PermMessageModerate) {
+		return &SpamVerdict{Action: SpamActionAccept}, nil
+	}
+
+	verdict, err := s.spam.Check(ctx, input)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if verdict.Action == SpamActionReject {
+		return nil, apperrors.InvalidParamsError("发布失败：" + strings.Join(verdict.Reasons, "，"))
+	}
+	return verdict, nil
+}
+
+// normalizeMessageContent 去除首尾空白，内容不能为空
+func normalizeMessageContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", apperrors.InvalidParamsError("内容不能为空")
+	}
+	return content, nil
+}
+
+// authorizeMessageAuthor 只有作者本人或审核员可以修改、删除留言
+func authorizeMessageAuthor(authorID, userID primitive.ObjectID, role string) error {
+	if authorID != userID && !model.HasPermission(role, model.PermMessageModerate) {
+		return apperrors.ForbiddenError("只能修改或删除自己的留言")
+	}
+	return nil
+}
+
+// initialStatus 根据审核规则决定新发言的初始状态
+func (s *MessageService) initialStatus(ctx context.Context, userID primitive.ObjectID, role string) (string, error) {
+	if s.policy.Mode == config.MessageModerationOff || s.policy.isTrustedRole(role) {
+		return model.MessageStatusApproved, nil
+	}
+	if s.policy.Mode == config.MessageModerationAll {
+		return model.MessageStatusPending, nil
+	}
+
+	approved, err := s.messageDAO.CountApprovedByUser(ctx, userID)
+	if err != nil {
+		return "", apperrors.ServerError(err)
+	}
+	if approved >= s.policy.TrustAfter {
+		return model.MessageStatusApproved, nil
+	}
+	return model.MessageStatusPending, nil
+}
+
 // 确保实现接口
 var _ MessageServiceInterface = (*MessageService)(nil)
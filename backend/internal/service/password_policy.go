@@ -0,0 +1,144 @@
+package service
+
+import (
+	"backend/internal/config"
+	apperrors "backend/internal/errors"
+	"backend/internal/logger"
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bcrypt 只使用密码的前 72 字节
+const bcryptMaxPasswordLength = 72
+
+// 内置常见弱密码，按小写比较
+var commonPasswords = []string{
+	"123456", "1234567", "12345678", "123456789", "1234567890", "123123", "111111", "000000",
+	"666666", "888888", "112233", "121212", "654321", "987654321", "147258369", "5201314",
+	"password", "password1", "password123", "passw0rd", "p@ssw0rd", "p@ssword", "admin", "admin123",
+	"admin@123", "root", "root123", "qwerty", "qwerty123", "qwertyuiop", "asdfgh", "asdfghjkl",
+	"zxcvbnm", "1q2w3e4r", "1qaz2wsx", "qazwsx", "abc123", "abcd1234", "a123456", "aa123456",
+	"iloveyou", "welcome", "welcome1", "letmein", "monkey", "dragon", "football", "sunshine",
+	"princess", "superman", "test1234", "changeme", "woaini", "woaini1314",
+}
+
+// PasswordPolicy 密码强度策略，长度按字符计算，另外受 bcrypt 的 72 字节限制
+type PasswordPolicy struct {
+	MinLength  int
+	MaxLength  int
+	MinClasses int
+	blocklist  map[string]struct{}
+}
+
+var (
+	passwordPolicy     *PasswordPolicy
+	passwordPolicyOnce sync.Once
+)
+
+// GetPasswordPolicy 获取全局密码策略（单例），黑名单文件读取失败时只使用内置列表
+func GetPasswordPolicy() *PasswordPolicy {
+	passwordPolicyOnce.Do(func() {
+		cfg := config.AppConfig
+		var extra []string
+		if cfg.PasswordBlocklistFile != "" {
+			words, err := readPasswordBlocklist(cfg.PasswordBlocklistFile)
+			if err != nil {
+				logger.Error("读取弱密码黑名单失败", logger.String("file", cfg.PasswordBlocklistFile), logger.Err(err))
+			}
+			extra = words
+		}
+		passwordPolicy = NewPasswordPolicy(cfg.PasswordMinLength, cfg.PasswordMaxLength, cfg.PasswordMinClasses, extra)
+	})
+	return passwordPolicy
+}
+
+// NewPasswordPolicy 创建密码策略，参数越界时修正到合法范围，blocklist 追加在内置列表之后
+func NewPasswordPolicy(minLength, maxLength, minClasses int, blocklist []string) *PasswordPolicy {
+	if maxLength <= 0 || maxLength > bcryptMaxPasswordLength {
+		maxLength = bcryptMaxPasswordLength
+	}
+	minLength = min(max(minLength, 1), maxLength)
+	minClasses = min(max(minClasses, 1), 4)
+
+	words := make(map[string]struct{}, len(commonPasswords)+len(blocklist))
+	for _, list := range [][]string{commonPasswords, blocklist} {
+		for _, w := range list {
+			if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+				words[w] = struct{}{}
+			}
+		}
+	}
+
+	return &PasswordPolicy{
+		MinLength:  minLength,
+		MaxLength:  maxLength,
+		MinClasses: minClasses,
+		blocklist:  words,
+	}
+}
+
+// Validate 校验密码强度，不满足时返回带原因的参数错误
+func (p *PasswordPolicy) Validate(password, username string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength || n > p.MaxLength {
+		return apperrors.InvalidParamsError(fmt.Sprintf("密码长度应为 %d-%d 个字符", p.MinLength, p.MaxLength))
+	}
+	if len(password) > bcryptMaxPasswordLength {
+		return apperrors.InvalidParamsError(fmt.Sprintf("密码不能超过 %d 字节（中文等字符每个占 3 字节）", bcryptMaxPasswordLength))
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case !unicode.IsPrint(r) || unicode.IsSpace(r):
+			return apperrors.InvalidParamsError("密码不能包含空白或不可见字符")
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < p.MinClasses {
+		return apperrors.InvalidParamsError(fmt.Sprintf("密码需包含小写字母、大写字母、数字、符号中的至少 %d 种", p.MinClasses))
+	}
+
+	lowered := strings.ToLower(password)
+	if _, ok := p.blocklist[lowered]; ok {
+		return apperrors.InvalidParamsError("密码过于常见，请更换")
+	}
+	if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		return apperrors.InvalidParamsError("密码不能包含用户名")
+	}
+	return nil
+}
+
+func readPasswordBlocklist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words, scanner.Err()
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	apperrors "backend/internal/errors"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := NewPasswordPolicy(8, 72, 2, []string{" Custom-Pass1 "})
+
+	tests := []struct {
+		name     string
+		password string
+		username string
+		wantErr  string
+	}{
+		{"满足要求", "abcdefg1", "alice", ""},
+		{"过短", "Ab1", "", "长度"},
+		{"只有一类字符", "abcdefgh", "", "至少 2 种"},
+		{"中文按字符计算长度", "密码很安全Ab12", "", ""},
+		{"中文计为符号类", "中文密码中文密码", "", "至少 2 种"},
+		{"超过 bcrypt 字节限制", strings.Repeat("密", 24) + "a", "", "字节"},
+		{"包含空白", "abc def12", "", "空白"},
+		{"内置弱密码不区分大小写", "Password123", "", "常见"},
+		{"自定义黑名单", "custom-pass1", "", "常见"},
+		{"包含用户名", "xAlice2024", "alice", "用户名"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.username)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("期望通过, 实际 %v", err)
+				}
+				return
+			}
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInvalidParams || !strings.Contains(appErr.Message, tt.wantErr) {
+				t.Errorf("期望包含 %q 的参数错误, 实际 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewPasswordPolicy_Clamp(t *testing.T) {
+	tests := []struct {
+		name                          string
+		minLength, maxLength, classes int
+		want                          PasswordPolicy
+	}{
+		{"超出范围", 0, 100, 9, PasswordPolicy{MinLength: 1, MaxLength: 72, MinClasses: 4}},
+		{"最小长度大于最大长度", 100, 10, 0, PasswordPolicy{MinLength: 10, MaxLength: 10, MinClasses: 1}},
+		{"未配置最大长度", 8, 0, 2, PasswordPolicy{MinLength: 8, MaxLength: 72, MinClasses: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPasswordPolicy(tt.minLength, tt.maxLength, tt.classes, nil)
+			if got.MinLength != tt.want.MinLength || got.MaxLength != tt.want.MaxLength || got.MinClasses != tt.want.MinClasses {
+				t.Errorf("期望 %d/%d/%d, 实际 %d/%d/%d", tt.want.MinLength, tt.want.MaxLength, tt.want.MinClasses,
+					got.MinLength, got.MaxLength, got.MinClasses)
+			}
+		})
+	}
+}
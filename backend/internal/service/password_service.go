@@ -0,0 +1,268 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/securetoken"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordMismatch  = errors.New("原密码错误")
+	ErrPasswordUnchanged = errors.New("新密码不能与原密码相同")
+	ErrResetTokenInvalid = errors.New("重置令牌无效或已过期")
+)
+
+// PasswordService 密码修改与重置
+type PasswordService struct {
+	userDAO  *dao.UserDAO
+	tokenDAO *dao.TokenDAO
+	resetDAO *dao.PasswordResetDAO
+	auditDAO *dao.AuditDAO
+	denylist *AccessTokenDenylist
+	policy   *PasswordPolicy
+	outbox   *MailOutbox
+}
+
+// NewPasswordService 创建密码服务
+func NewPasswordService() *PasswordService {
+	return &PasswordService{
+		userDAO:  dao.NewUserDAO(),
+		tokenDAO: dao.NewTokenDAO(),
+		resetDAO: dao.NewPasswordResetDAO(),
+		auditDAO: dao.NewAuditDAO(),
+		denylist: GetAccessTokenDenylist(),
+		policy:   GetPasswordPolicy(),
+		outbox:   GetMailOutbox(),
+	}
+}
+
+// ChangePassword 校验原密码后修改密码，并吊销除当前会话外的所有登录状态
+func (s *PasswordService) ChangePassword(ctx context.Context, userID, sessionID primitive.ObjectID, oldPassword, newPassword string) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	user, err := s.userDAO.FindByID(ctx, userID)
+	if err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	if !checkPassword(oldPassword, user.Password) {
+		return ErrPasswordMismatch
+	}
+	if oldPassword == newPassword {
+		return ErrPasswordUnchanged
+	}
+	if err := s.policy.Validate(newPassword, user.UserName); err != nil {
+		return err
+	}
+
+	if err := s.setPassword(ctx, user.ID, newPassword, sessionID); err != nil {
+		return err
+	}
+	recordAudit(ctx, s.auditDAO, &model.AuditLog{
+		Event:    model.AuditPasswordChanged,
+		UserID:   &user.ID,
+		UserName: user.UserName,
+	})
+	return nil
+}
+
+// CreateResetToken 为用户生成一次性重置令牌（管理员发起），之前未使用的令牌同时作废
+func (s *PasswordService) CreateResetToken(ctx context.Context, userID, issuerID primitive.ObjectID) (*model.PasswordResetTicket, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	user, err := s.userDAO.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "用户")
+	}
+	ticket, err := s.issueResetToken(ctx, user, &issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditDAO, &model.AuditLog{
+		Event:    model.AuditPasswordResetSent,
+		UserID:   &user.ID,
+		UserName: user.UserName,
+		Detail:   fmt.Sprintf("由管理员 %s 发起", issuerID.Hex()),
+	})
+	return ticket, nil
+}
+
+// RequestReset 向已验证的邮箱发送重置链接。为避免泄露邮箱是否注册，
+// 邮箱不存在、未验证或账号被禁用时同样返回成功
+func (s *PasswordService) RequestReset(ctx context.Context, email, clientIP string) error {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	user, err := s.userDAO.FindByVerifiedEmail(ctx, email)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil
+		}
+		return apperrors.ServerError(err)
+	}
+	if user.IsDisabled {
+		return nil
+	}
+
+	ticket, err := s.issueResetToken(ctx, user, nil)
+	if err != nil {
+		return err
+	}
+	expire := config.AppConfig.PasswordResetExpire
+	if err := s.outbox.Enqueue(ctx, user.Email, MailPasswordReset, user.Language, map[string]string{
+		"UserName":  user.UserName,
+		"Link":      mailLink("/reset-password", ticket.Token),
+		"ExpiresIn": formatExpiry(expire, user.Language),
+	}); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditDAO, &model.AuditLog{
+		Event:    model.AuditPasswordResetSent,
+		UserID:   &user.ID,
+		UserName: user.UserName,
+		IP:       clientIP,
+		Detail:   "通过邮箱找回密码",
+	})
+	return nil
+}
+
+// issueResetToken 生成新的重置令牌，issuerID 为空表示用户自助找回。
+// 管理员发起时作废之前未使用的令牌；自助找回不作废已有令牌，
+// 否则任何人都能通过找回接口让用户手中（包括管理员发放）的重置链接失效
+func (s *PasswordService) issueResetToken(ctx context.Context, user *model.User, issuerID *primitive.ObjectID) (*model.PasswordResetTicket, error) {
+	if issuerID != nil {
+		if err := s.resetDAO.InvalidateByUserID(ctx, user.ID); err != nil {
+			return nil, apperrors.ServerError(err)
+		}
+	}
+
+	token, err := securetoken.Generate()
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	expiresAt := time.Now().Add(config.AppConfig.PasswordResetExpire)
+	if err := s.resetDAO.Create(ctx, &model.PasswordResetToken{
+		UserID:    user.ID,
+		Selector:  token.Selector,
+		TokenHash: hashOneTimeToken(token.Verifier),
+		ExpiresAt: expiresAt,
+		CreatedBy: issuerID,
+	}); err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return &model.PasswordResetTicket{Token: token.String(), ExpiresAt: expiresAt}, nil
+}
+
+// ResetPassword 使用重置令牌设置新密码，成功后令牌失效并吊销该用户所有登录状态
+func (s *PasswordService) ResetPassword(ctx context.Context, rawToken, newPassword, clientIP string) error {
+	token, err := securetoken.Parse(rawToken)
+	if err != nil {
+		return ErrResetTokenInvalid
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	resetToken, err := s.resetDAO.FindByToken(ctx, token.Selector, hashOneTimeToken(token.Verifier))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrResetTokenInvalid
+		}
+		return apperrors.ServerError(err)
+	}
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return ErrResetTokenInvalid
+	}
+
+	user, err := s.userDAO.FindByID(ctx, resetToken.UserID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrResetTokenInvalid
+		}
+		return apperrors.ServerError(err)
+	}
+	// 先校验强度再消费令牌，密码不合格时用户可以用同一令牌重试
+	if err := s.policy.Validate(newPassword, user.UserName); err != nil {
+		return err
+	}
+
+	// 原子地标记为已使用，并发请求中只有一个能成功
+	if err := s.resetDAO.Consume(ctx, resetToken.ID); err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrResetTokenInvalid
+		}
+		return apperrors.ServerError(err)
+	}
+	if err := s.setPassword(ctx, user.ID, newPassword, primitive.NilObjectID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditDAO, &model.AuditLog{
+		Event:    model.AuditPasswordReset,
+		UserID:   &user.ID,
+		UserName: user.UserName,
+		IP:       clientIP,
+	})
+	return nil
+}
+
+// setPassword 保存新密码，作废未使用的重置令牌，并吊销 keepSessionID 之外的会话及其 access token
+func (s *PasswordService) setPassword(ctx context.Context, userID primitive.ObjectID, password string, keepSessionID primitive.ObjectID) error {
+	hashed, err := hashPassword(password)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if err := s.userDAO.UpdatePassword(ctx, userID, hashed); err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	if err := s.resetDAO.InvalidateByUserID(ctx, userID); err != nil {
+		return apperrors.ServerError(err)
+	}
+
+	if keepSessionID.IsZero() {
+		err = s.tokenDAO.RevokeAllByUserID(ctx, userID)
+	} else {
+		err = s.tokenDAO.RevokeOthersByUserID(ctx, userID, keepSessionID)
+	}
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if err := revokeUserAccess(ctx, s.tokenDAO, s.denylist, userID, keepSessionID); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
+
+// hashOneTimeToken 计算重置、验证等一次性令牌的哈希，与 refresh token 使用同一哈希密钥
+func hashOneTimeToken(verifier string) string {
+	return securetoken.Hash([]byte(config.AppConfig.RefreshTokenHashKey), verifier)
+}
+
+func hashPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(bytes), err
+}
+
+func checkPassword(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// 确保实现接口
+var _ PasswordServiceInterface = (*PasswordService)(nil)
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	"backend/internal/model"
+	"backend/pkg/sensitive"
+	"context"
+	"sync"
+	"time"
+)
+
+// SensitiveWordMatcher 缓存由 Mongo 词表构建的 DFA 匹配器，过期或被管理员修改后重新加载
+type SensitiveWordMatcher struct {
+	mu        sync.RWMutex
+	dao       *dao.SensitiveWordDAO
+	ttl       time.Duration
+	matcher   *sensitive.Matcher
+	weights   map[string]int
+	expiresAt time.Time
+}
+
+var (
+	sensitiveWordMatcher     *SensitiveWordMatcher
+	sensitiveWordMatcherOnce sync.Once
+)
+
+// GetSensitiveWordMatcher 获取全局敏感词匹配器（单例，保证词表修改能立即生效）
+func GetSensitiveWordMatcher() *SensitiveWordMatcher {
+	sensitiveWordMatcherOnce.Do(func() {
+		sensitiveWordMatcher = NewSensitiveWordMatcher(dao.NewSensitiveWordDAO(), config.AppConfig.SensitiveWordCacheTTL)
+	})
+	return sensitiveWordMatcher
+}
+
+// NewSensitiveWordMatcher 创建敏感词匹配器
+func NewSensitiveWordMatcher(wordDAO *dao.SensitiveWordDAO, ttl time.Duration) *SensitiveWordMatcher {
+	return &SensitiveWordMatcher{
+		dao: wordDAO,
+		ttl: ttl,
+	}
+}
+
+// Match 返回命中的敏感词及其权重之和
+func (m *SensitiveWordMatcher) Match(ctx context.Context, text string) ([]string, int, error) {
+	matcher, weights, err := m.current(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	words := matcher.Find(text)
+	score := 0
+	for _, w := range words {
+		score += weights[w]
+	}
+	return words, score, nil
+}
+
+// Invalidate 使缓存的词表失效，下次匹配时重新加载
+func (m *SensitiveWordMatcher) Invalidate() {
+	m.mu.Lock()
+	m.expiresAt = time.Time{}
+	m.mu.Unlock()
+}
+
+func (m *SensitiveWordMatcher) current(ctx context.Context) (*sensitive.Matcher, map[string]int, error) {
+	m.mu.RLock()
+	matcher, weights, expiresAt := m.matcher, m.weights, m.expiresAt
+	m.mu.RUnlock()
+	if matcher != nil && time.Now().Before(expiresAt) {
+		return matcher, weights, nil
+	}
+
+	list, err := m.dao.FindAll(ctx)
+	if err != nil {
+		// 重新加载失败时继续使用旧词表，避免数据库抖动导致过滤失效
+		if matcher != nil {
+			return matcher, weights, nil
+		}
+		return nil, nil, err
+	}
+
+	matcher, weights = buildSensitiveWords(list)
+
+	m.mu.Lock()
+	m.matcher, m.weights, m.expiresAt = matcher, weights, time.Now().Add(m.ttl)
+	m.mu.Unlock()
+	return matcher, weights, nil
+}
+
+// buildSensitiveWords 构建匹配器和按标准形式索引的权重；只有大小写或分隔符不同的词共用一个标准形式，取其中最大的权重
+func buildSensitiveWords(list []model.SensitiveWord) (*sensitive.Matcher, map[string]int) {
+	words := make([]string, 0, len(list))
+	weights := make(map[string]int, len(list))
+	for _, w := range list {
+		words = append(words, w.Word)
+		key := sensitive.Normalize(w.Word)
+		weights[key] = max(weights[key], w.Weight)
+	}
+	return sensitive.New(words), weights
+}
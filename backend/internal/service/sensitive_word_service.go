@@ -0,0 +1,119 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 未指定权重时，单个敏感词即可让留言进入审核队列
+const defaultSensitiveWordWeight = 50
+
+// SensitiveWordService 敏感词表管理
+type SensitiveWordService struct {
+	wordDAO *dao.SensitiveWordDAO
+	matcher *SensitiveWordMatcher
+}
+
+// NewSensitiveWordService 创建敏感词服务
+func NewSensitiveWordService() *SensitiveWordService {
+	return &SensitiveWordService{
+		wordDAO: dao.NewSensitiveWordDAO(),
+		matcher: GetSensitiveWordMatcher(),
+	}
+}
+
+// NewSensitiveWordServiceWithDAO 使用指定的 DAO 创建敏感词服务（用于测试）
+func NewSensitiveWordServiceWithDAO(wordDAO *dao.SensitiveWordDAO, matcher *SensitiveWordMatcher) *SensitiveWordService {
+	return &SensitiveWordService{
+		wordDAO: wordDAO,
+		matcher: matcher,
+	}
+}
+
+// List 获取全部敏感词
+func (s *SensitiveWordService) List(ctx context.Context) ([]model.SensitiveWord, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	words, err := s.wordDAO.FindAll(ctx)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if words == nil {
+		words = []model.SensitiveWord{}
+	}
+	return words, nil
+}
+
+// Create 添加敏感词，weight 为 0 时使用默认权重
+func (s *SensitiveWordService) Create(ctx context.Context, word string, weight int, userID primitive.ObjectID) (*model.SensitiveWord, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return nil, apperrors.InvalidParamsError("敏感词不能为空")
+	}
+	if weight < 0 {
+		return nil, apperrors.InvalidParamsError("权重必须大于 0")
+	}
+	if weight == 0 {
+		weight = defaultSensitiveWordWeight
+	}
+
+	exists, err := s.wordDAO.ExistsByWord(ctx, word)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	if exists {
+		return nil, apperrors.ConflictError("敏感词已存在")
+	}
+
+	created, err := s.wordDAO.Create(ctx, &model.SensitiveWord{Word: word, Weight: weight, CreatedBy: userID})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("敏感词已存在")
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	s.matcher.Invalidate()
+	return created, nil
+}
+
+// UpdateWeight 修改敏感词权重，权重必须大于 0
+func (s *SensitiveWordService) UpdateWeight(ctx context.Context, id primitive.ObjectID, weight int) (*model.SensitiveWord, error) {
+	if weight <= 0 {
+		return nil, apperrors.InvalidParamsError("权重必须大于 0")
+	}
+
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	word, err := s.wordDAO.UpdateWeight(ctx, id, weight)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "敏感词")
+	}
+	s.matcher.Invalidate()
+	return word, nil
+}
+
+// Delete 删除敏感词
+func (s *SensitiveWordService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.wordDAO.Delete(ctx, id); err != nil {
+		return apperrors.WrapMongoError(err, "敏感词")
+	}
+	s.matcher.Invalidate()
+	return nil
+}
+
+// 确保实现接口
+var _ SensitiveWordServiceInterface = (*SensitiveWordService)(nil)
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/slug"
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SeriesService 系列服务实现
+type SeriesService struct {
+	seriesDAO  *dao.SeriesDAO
+	articleDAO *dao.ArticleDAO
+}
+
+// NewSeriesService 创建系列服务
+func NewSeriesService() *SeriesService {
+	return &SeriesService{
+		seriesDAO:  dao.NewSeriesDAO(),
+		articleDAO: dao.NewArticleDAO(),
+	}
+}
+
+// NewSeriesServiceWithDAO 使用指定的 DAO 创建系列服务（用于测试）
+func NewSeriesServiceWithDAO(seriesDAO *dao.SeriesDAO, articleDAO *dao.ArticleDAO) *SeriesService {
+	return &SeriesService{
+		seriesDAO:  seriesDAO,
+		articleDAO: articleDAO,
+	}
+}
+
+// List 获取全部系列及其公开文章数
+func (s *SeriesService) List(ctx context.Context) ([]model.SeriesSummary, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	seriesList, err := s.seriesDAO.FindAll(ctx)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	counts, err := s.articleDAO.CountPublicGroupBy(ctx, "series_id")
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+
+	summaries := make([]model.SeriesSummary, 0, len(seriesList))
+	for _, series := range seriesList {
+		summaries = append(summaries, model.SeriesSummary{Series: series, Count: counts[series.ID]})
+	}
+	return summaries, nil
+}
+
+// GetBySlug 获取系列详情及按顺序排列的公开文章
+func (s *SeriesService) GetBySlug(ctx context.Context, seriesSlug string) (*model.SeriesDetail, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	series, err := s.seriesDAO.FindBySlug(ctx, seriesSlug)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "系列")
+	}
+	articles, err := s.articleDAO.FindSeriesArticles(ctx, series.ID)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return &model.SeriesDetail{Series: *series, Articles: articles}, nil
+}
+
+// Create 创建系列，未指定 slug 时根据标题生成
+func (s *SeriesService) Create(ctx context.Context, series *model.Series) (*model.Series, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	series.Title = strings.TrimSpace(series.Title)
+	series.Slug = strings.TrimSpace(series.Slug)
+	series.Description = strings.TrimSpace(series.Description)
+	if series.Title == "" {
+		return nil, apperrors.InvalidParamsError("系列标题不能为空")
+	}
+	series.ID = primitive.NewObjectID()
+
+	if err := s.resolveSlug(ctx, &series.Slug, series.Title, series.ID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.seriesDAO.Create(ctx, series)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("系列 slug 已被占用")
+		}
+		return nil, apperrors.ServerError(err)
+	}
+	return created, nil
+}
+
+// Update 修改系列
+func (s *SeriesService) Update(ctx context.Context, id primitive.ObjectID, update *model.SeriesUpdate) (*model.Series, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	for _, field := range []*string{update.Title, update.Slug, update.Description} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+	if update.Title != nil && *update.Title == "" {
+		return nil, apperrors.InvalidParamsError("系列标题不能为空")
+	}
+
+	current, err := s.seriesDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "系列")
+	}
+	if update.Slug != nil && *update.Slug != current.Slug {
+		// slug 留空时按修改后的标题重新生成
+		title := current.Title
+		if update.Title != nil {
+			title = *update.Title
+		}
+		if err := s.resolveSlug(ctx, update.Slug, title, id); err != nil {
+			return nil, err
+		}
+	}
+
+	series, err := s.seriesDAO.Update(ctx, id, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.ConflictError("系列 slug 已被占用")
+		}
+		return nil, apperrors.WrapMongoError(err, "系列")
+	}
+	return series, nil
+}
+
+// SetArticles 按给定顺序设置系列包含的文章，未列出的原有文章会被移出系列
+func (s *SeriesService) SetArticles(ctx context.Context, id primitive.ObjectID, articleIDs []primitive.ObjectID) (*model.SeriesDetail, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	series, err := s.seriesDAO.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "系列")
+	}
+
+	seen := make(map[primitive.ObjectID]struct{}, len(articleIDs))
+	for _, articleID := range articleIDs {
+		if _, ok := seen[articleID]; ok {
+			return nil, apperrors.InvalidParamsError("文章不能重复加入系列")
+		}
+		seen[articleID] = struct{}{}
+	}
+	if len(articleIDs) > 0 {
+		count, err := s.articleDAO.CountByIDs(ctx, articleIDs)
+		if err != nil {
+			return nil, apperrors.ServerError(err)
+		}
+		if count != int64(len(articleIDs)) {
+			return nil, apperrors.NotFoundError("部分文章")
+		}
+	}
+
+	if err := s.articleDAO.SetSeriesArticles(ctx, id, articleIDs); err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	articles, err := s.articleDAO.FindSeriesArticles(ctx, id)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return &model.SeriesDetail{Series: *series, Articles: articles}, nil
+}
+
+// Delete 删除系列，系列内的文章保留并移出系列
+func (s *SeriesService) Delete(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.seriesDAO.Delete(ctx, id); err != nil {
+		return apperrors.WrapMongoError(err, "系列")
+	}
+	if err := s.articleDAO.ClearSeries(ctx, id); err != nil {
+		return apperrors.ServerError(err)
+	}
+	return nil
+}
+
+// resolveSlug 校验手动指定的 slug，为空时根据标题生成
+func (s *SeriesService) resolveSlug(ctx context.Context, seriesSlug *string, title string, id primitive.ObjectID) error {
+	taken := func(ctx context.Context, candidate string) (bool, error) {
+		return s.seriesDAO.SlugTaken(ctx, candidate, id)
+	}
+
+	if *seriesSlug == "" {
+		generated, err := uniqueNameSlug(ctx, title, "series", id, taken)
+		if err != nil {
+			return err
+		}
+		*seriesSlug = generated
+		return nil
+	}
+
+	if !slug.IsValid(*seriesSlug) {
+		return apperrors.InvalidParamsError("slug 只能包含小写字母、数字和连字符")
+	}
+	exists, err := taken(ctx, *seriesSlug)
+	if err != nil {
+		return apperrors.ServerError(err)
+	}
+	if exists {
+		return apperrors.ConflictError("系列 slug 已被占用")
+	}
+	return nil
+}
+
+// 确保实现接口
+var _ SeriesServiceInterface = (*SeriesService)(nil)
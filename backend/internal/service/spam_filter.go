@@ -0,0 +1,161 @@
+package service
+
+import (
+	"backend/internal/config"
+	"backend/internal/dao"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 垃圾内容过滤链的处理结果
+const (
+	SpamActionAccept = "accept"
+	SpamActionHold   = "hold"
+	SpamActionReject = "reject"
+)
+
+// 每个超出限制的链接计入的分数
+const spamScorePerLink = 25
+
+var linkRegex = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// SpamInput 待检查的发言
+type SpamInput struct {
+	UserID  primitive.ObjectID
+	Content string
+	// Edit 为 true 表示修改已有发言，跳过重复和频率检查
+	Edit bool
+}
+
+// SpamFilter 垃圾内容过滤器；score 为 0 表示未命中，reason 说明命中原因
+type SpamFilter interface {
+	Check(ctx context.Context, input *SpamInput) (score int, reason string, err error)
+}
+
+// SpamVerdict 过滤链的判定结果
+type SpamVerdict struct {
+	Score   int
+	Reasons []string
+	Action  string
+}
+
+// SpamFilterChain 依次执行过滤器并累加分数，按阈值决定拒绝、进入审核或放行
+type SpamFilterChain struct {
+	filters     []SpamFilter
+	holdScore   int
+	rejectScore int
+}
+
+// NewSpamFilterChain 创建过滤链
+func NewSpamFilterChain(holdScore, rejectScore int, filters ...SpamFilter) *SpamFilterChain {
+	return &SpamFilterChain{
+		filters:     filters,
+		holdScore:   holdScore,
+		rejectScore: rejectScore,
+	}
+}
+
+// NewDefaultSpamFilterChain 按配置创建默认过滤链：敏感词、链接数、重复内容、发言频率
+func NewDefaultSpamFilterChain(messageDAO *dao.MessageDAO) *SpamFilterChain {
+	cfg := config.AppConfig
+	return NewSpamFilterChain(cfg.SpamHoldScore, cfg.SpamRejectScore,
+		&SensitiveWordFilter{matcher: GetSensitiveWordMatcher()},
+		&LinkFilter{MaxLinks: cfg.SpamMaxLinks},
+		&DuplicateFilter{messageDAO: messageDAO, Window: cfg.SpamDuplicateWindow, Score: cfg.SpamRejectScore},
+		&FrequencyFilter{messageDAO: messageDAO, Limit: cfg.SpamRateLimit, Window: cfg.SpamRateWindow, Score: cfg.SpamRejectScore},
+	)
+}
+
+// Check 执行过滤链，链为 nil 时直接放行
+func (c *SpamFilterChain) Check(ctx context.Context, input *SpamInput) (*SpamVerdict, error) {
+	verdict := &SpamVerdict{Action: SpamActionAccept}
+	if c == nil {
+		return verdict, nil
+	}
+
+	for _, f := range c.filters {
+		score, reason, err := f.Check(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if score > 0 {
+			verdict.Score += score
+			verdict.Reasons = append(verdict.Reasons, reason)
+		}
+	}
+
+	switch {
+	case c.rejectScore > 0 && verdict.Score >= c.rejectScore:
+		verdict.Action = SpamActionReject
+	case c.holdScore > 0 && verdict.Score >= c.holdScore:
+		verdict.Action = SpamActionHold
+	}
+	return verdict, nil
+}
+
+// SensitiveWordFilter 敏感词过滤，分数为命中词的权重之和
+type SensitiveWordFilter struct {
+	matcher *SensitiveWordMatcher
+}
+
+func (f *SensitiveWordFilter) Check(ctx context.Context, input *SpamInput) (int, string, error) {
+	words, score, err := f.matcher.Match(ctx, input.Content)
+	if err != nil || len(words) == 0 {
+		return 0, "", err
+	}
+	return score, "包含敏感词", nil
+}
+
+// LinkFilter 链接数量限制，超出部分每条计 spamScorePerLink 分
+type LinkFilter struct {
+	MaxLinks int
+}
+
+func (f *LinkFilter) Check(ctx context.Context, input *SpamInput) (int, string, error) {
+	n := len(linkRegex.FindAllStringIndex(input.Content, -1))
+	if n <= f.MaxLinks {
+		return 0, "", nil
+	}
+	return (n - f.MaxLinks) * spamScorePerLink, fmt.Sprintf("链接过多（%d 条）", n), nil
+}
+
+// DuplicateFilter 同一用户在 Window 内重复发布相同内容
+type DuplicateFilter struct {
+	messageDAO *dao.MessageDAO
+	Window     time.Duration
+	Score      int
+}
+
+func (f *DuplicateFilter) Check(ctx context.Context, input *SpamInput) (int, string, error) {
+	if input.Edit || f.Window <= 0 {
+		return 0, "", nil
+	}
+	exists, err := f.messageDAO.ExistsRecentContent(ctx, input.UserID, input.Content, time.Now().Add(-f.Window))
+	if err != nil || !exists {
+		return 0, "", err
+	}
+	return f.Score, "重复发布相同内容", nil
+}
+
+// FrequencyFilter 同一用户在 Window 内发言次数达到 Limit
+type FrequencyFilter struct {
+	messageDAO *dao.MessageDAO
+	Limit      int
+	Window     time.Duration
+	Score      int
+}
+
+func (f *FrequencyFilter) Check(ctx context.Context, input *SpamInput) (int, string, error) {
+	if input.Edit || f.Limit <= 0 {
+		return 0, "", nil
+	}
+	count, err := f.messageDAO.CountRecentByUser(ctx, input.UserID, time.Now().Add(-f.Window))
+	if err != nil || count < int64(f.Limit) {
+		return 0, "", err
+	}
+	return f.Score, "发言过于频繁", nil
+}
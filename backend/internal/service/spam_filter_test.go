@@ -0,0 +1,205 @@
+package service
+
+import (
+	"backend/internal/config"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fixedFilter 总是返回固定分数
+type fixedFilter struct {
+	score  int
+	reason string
+}
+
+func (f fixedFilter) Check(ctx context.Context, input *SpamInput) (int, string, error) {
+	return f.score, f.reason, nil
+}
+
+func TestSpamFilterChain_Thresholds(t *testing.T) {
+	tests := []struct {
+		name    string
+		scores  []int
+		want    string
+		reasons int
+	}{
+		{"未命中", []int{0, 0}, SpamActionAccept, 0},
+		{"低于审核阈值", []int{20, 29}, SpamActionAccept, 2},
+		{"恰好达到审核阈值", []int{25, 25}, SpamActionHold, 2},
+		{"累加达到拒绝阈值", []int{50, 0, 50}, SpamActionReject, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters := make([]SpamFilter, 0, len(tt.scores))
+			for _, score := range tt.scores {
+				filters = append(filters, fixedFilter{score: score, reason: "命中"})
+			}
+			chain := NewSpamFilterChain(50, 100, filters...)
+
+			verdict, err := chain.Check(context.Background(), &SpamInput{Content: "x"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if verdict.Action != tt.want {
+				t.Errorf("期望 %s, 实际 %s（分数 %d）", tt.want, verdict.Action, verdict.Score)
+			}
+			if len(verdict.Reasons) != tt.reasons {
+				t.Errorf("期望 %d 条原因, 实际 %v", tt.reasons, verdict.Reasons)
+			}
+		})
+	}
+}
+
+func TestSpamFilterChain_DisabledThresholds(t *testing.T) {
+	chain := NewSpamFilterChain(0, 0, fixedFilter{score: 1000})
+	verdict, err := chain.Check(context.Background(), &SpamInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verdict.Action != SpamActionAccept {
+		t.Errorf("阈值为 0 时不应拦截, 实际 %s", verdict.Action)
+	}
+
+	var nilChain *SpamFilterChain
+	if verdict, err := nilChain.Check(context.Background(), &SpamInput{}); err != nil || verdict.Action != SpamActionAccept {
+		t.Errorf("nil 过滤链应直接放行, 实际 %v, %v", verdict, err)
+	}
+}
+
+func TestLinkFilter(t *testing.T) {
+	f := &LinkFilter{MaxLinks: 1}
+	if score, _, _ := f.Check(context.Background(), &SpamInput{Content: "见 https://a.example"}); score != 0 {
+		t.Errorf("未超出限制不应计分, 实际 %d", score)
+	}
+	score, reason, _ := f.Check(context.Background(), &SpamInput{Content: "https://a.example www.b.example http://c.example"})
+	if score != 2*spamScorePerLink || !strings.Contains(reason, "3") {
+		t.Errorf("超出的每条链接计 %d 分, 实际 %d（%s）", spamScorePerLink, score, reason)
+	}
+}
+
+func newSpamTestMessageService(mode string, filters ...SpamFilter) *MessageService {
+	return NewMessageServiceWithDAO(nil, nil,
+		MessagePolicy{Mode: mode, EditWindow: time.Hour},
+		NewSpamFilterChain(50, 100, filters...))
+}
+
+func TestMessageService_SubmissionStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name    string
+		score   int
+		role    string
+		want    string
+		wantErr bool
+	}{
+		{"放行", 0, model.RoleUser, model.MessageStatusApproved, false},
+		{"命中审核阈值进入审核队列", 60, model.RoleUser, model.MessageStatusPending, false},
+		{"达到拒绝阈值直接拒绝", 150, model.RoleUser, "", true},
+		{"审核员不受过滤限制", 150, model.RoleModerator, model.MessageStatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newSpamTestMessageService(config.MessageModerationOff, fixedFilter{score: tt.score, reason: "包含敏感词"})
+
+			status, _, err := svc.submissionStatus(ctx, userID, tt.role, "内容")
+			if tt.wantErr {
+				var appErr *apperrors.AppError
+				if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInvalidParams || !strings.Contains(appErr.Message, "包含敏感词") {
+					t.Errorf("期望带原因的参数错误, 实际 %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tt.want {
+				t.Errorf("期望状态 %s, 实际 %s", tt.want, status)
+			}
+		})
+	}
+}
+
+func TestMessageService_EditStatus(t *testing.T) {
+	ctx := context.Background()
+	author := primitive.NewObjectID()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		score   int
+		status  string
+		want    string
+		wantErr bool
+	}{
+		{"已通过的内容修改后按规则重新判定", 0, model.MessageStatusApproved, model.MessageStatusPending, false},
+		{"修改后命中审核阈值", 60, model.MessageStatusApproved, model.MessageStatusPending, false},
+		{"待审核内容保持待审核", 0, model.MessageStatusPending, model.MessageStatusPending, false},
+		{"已拒绝的内容不能修改", 0, model.MessageStatusRejected, "", true},
+		{"已拒绝的内容命中审核阈值也不能修改", 60, model.MessageStatusRejected, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newSpamTestMessageService(config.MessageModerationAll, fixedFilter{score: tt.score})
+
+			status, err := svc.editStatus(ctx, author, now, tt.status, author, model.RoleUser, "新内容")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("期望返回错误, 实际状态 %s", status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tt.want {
+				t.Errorf("期望状态 %s, 实际 %s", tt.want, status)
+			}
+		})
+	}
+}
+
+func TestSensitiveWordService_RejectsNonPositiveWeight(t *testing.T) {
+	svc := &SensitiveWordService{}
+	for _, weight := range []int{0, -5} {
+		_, err := svc.UpdateWeight(context.Background(), primitive.NewObjectID(), weight)
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInvalidParams {
+			t.Errorf("权重 %d 期望参数错误, 实际 %v", weight, err)
+		}
+	}
+
+	_, err := svc.Create(context.Background(), "词", -1, primitive.NewObjectID())
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeInvalidParams {
+		t.Errorf("负权重期望参数错误, 实际 %v", err)
+	}
+}
+
+func TestBuildSensitiveWords_CaseVariants(t *testing.T) {
+	matcher, weights := buildSensitiveWords([]model.SensitiveWord{
+		{Word: "Casino", Weight: 80},
+		{Word: "casino", Weight: 20},
+		{Word: "赌博", Weight: 50},
+	})
+
+	words := matcher.Find("CASINO 与 赌博")
+	score := 0
+	for _, w := range words {
+		score += weights[w]
+	}
+	if len(words) != 2 || score != 130 {
+		t.Errorf("只有大小写不同的词应取较大权重, 实际 %v 共 %d 分", words, score)
+	}
+}
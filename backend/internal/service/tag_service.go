@@ -0,0 +1,182 @@
+package service
+
+import (
+	"backend/internal/dao"
+	apperrors "backend/internal/errors"
+	"backend/internal/model"
+	"backend/pkg/slug"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 单篇文章最多标签数
+const maxArticleTags = 10
+
+// tagCounter 统计标签的公开文章数，由 dao.ArticleDAO 实现
+type tagCounter interface {
+	CountPublicByTag(ctx context.Context, tag string) (int64, error)
+}
+
+// tagStore 标签存储，由 dao.TagDAO 实现
+type tagStore interface {
+	FindByName(ctx context.Context, name string) (*model.Tag, error)
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+	Create(ctx context.Context, tag *model.Tag) error
+	UpdateCount(ctx context.Context, name string, count int64) error
+}
+
+// TagService 标签服务实现
+type TagService struct {
+	tagDAO     *dao.TagDAO
+	articleDAO *dao.ArticleDAO
+}
+
+// NewTagService 创建标签服务
+func NewTagService() *TagService {
+	return &TagService{
+		tagDAO:     dao.NewTagDAO(),
+		articleDAO: dao.NewArticleDAO(),
+	}
+}
+
+// NewTagServiceWithDAO 使用指定的 DAO 创建标签服务（用于测试）
+func NewTagServiceWithDAO(tagDAO *dao.TagDAO, articleDAO *dao.ArticleDAO) *TagService {
+	return &TagService{
+		tagDAO:     tagDAO,
+		articleDAO: articleDAO,
+	}
+}
+
+// List 获取有公开文章的标签及文章数，文章数为 0 的标签保留描述但不对外展示
+func (s *TagService) List(ctx context.Context) ([]model.Tag, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	tags, err := s.tagDAO.FindInUse(ctx)
+	if err != nil {
+		return nil, apperrors.ServerError(err)
+	}
+	return tags, nil
+}
+
+// UpdateDescription 修改标签描述
+func (s *TagService) UpdateDescription(ctx context.Context, tagSlug, description string) (*model.Tag, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	tag, err := s.tagDAO.UpdateDescription(ctx, tagSlug, strings.TrimSpace(description))
+	if err != nil {
+		return nil, apperrors.WrapMongoError(err, "标签")
+	}
+	return tag, nil
+}
+
+// Rebuild 为旧文章补齐 tags 字段，并根据文章数据重建全部标签计数，返回标签数量
+func (s *TagService) Rebuild(ctx context.Context) (int, error) {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if _, err := s.articleDAO.BackfillTags(ctx); err != nil {
+		return 0, apperrors.ServerError(err)
+	}
+	names, err := s.articleDAO.DistinctTags(ctx)
+	if err != nil {
+		return 0, apperrors.ServerError(err)
+	}
+	if err := syncTags(ctx, s.articleDAO, s.tagDAO, names); err != nil {
+		return 0, err
+	}
+	return len(names), nil
+}
+
+// syncTags 重新计算指定标签的公开文章数，不存在的标签会被创建
+func syncTags(ctx context.Context, articleDAO tagCounter, tagDAO tagStore, names []string) error {
+	for _, name := range normalizeTags(names) {
+		count, err := articleDAO.CountPublicByTag(ctx, name)
+		if err != nil {
+			return apperrors.ServerError(err)
+		}
+
+		_, err = tagDAO.FindByName(ctx, name)
+		if err == nil {
+			if err := tagDAO.UpdateCount(ctx, name, count); err != nil {
+				return apperrors.ServerError(err)
+			}
+			continue
+		}
+		if !apperrors.IsNotFound(err) {
+			return apperrors.ServerError(err)
+		}
+
+		id := primitive.NewObjectID()
+		tagSlug, err := uniqueNameSlug(ctx, name, "tag", id, tagDAO.ExistsBySlug)
+		if err != nil {
+			return err
+		}
+		err = tagDAO.Create(ctx, &model.Tag{ID: id, Name: name, Slug: tagSlug, Count: count})
+		if mongo.IsDuplicateKeyError(err) {
+			// 并发创建同名标签时，以已存在的为准
+			err = tagDAO.UpdateCount(ctx, name, count)
+		}
+		if err != nil {
+			return apperrors.ServerError(err)
+		}
+	}
+	return nil
+}
+
+// uniqueNameSlug 根据名称生成未被 taken 占用的 slug，冲突时追加数字后缀，
+// 超过 maxSlugAttempts 次后使用 ID 后缀；名称无法转换时使用 prefix 加名称哈希兜底
+func uniqueNameSlug(ctx context.Context, name, prefix string, id primitive.ObjectID, taken func(context.Context, string) (bool, error)) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		base = fmt.Sprintf("%s-%08x", prefix, h.Sum32())
+	}
+
+	for i := 1; i <= maxSlugAttempts; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		exists, err := taken(ctx, candidate)
+		if err != nil {
+			return "", apperrors.ServerError(err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+	return base + "-" + id.Hex()[len(id.Hex())-8:], nil
+}
+
+// normalizeTags 去除空白、空值和重复标签，保持原有顺序
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		result = append(result, t)
+	}
+	return result
+}
+
+// 确保实现接口
+var (
+	_ TagServiceInterface = (*TagService)(nil)
+	_ tagCounter          = (*dao.ArticleDAO)(nil)
+	_ tagStore            = (*dao.TagDAO)(nil)
+)
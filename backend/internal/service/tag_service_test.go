@@ -0,0 +1,146 @@
+package service
+
+import (
+	"backend/internal/model"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeTagCounter 各标签的公开文章数
+type fakeTagCounter map[string]int64
+
+func (c fakeTagCounter) CountPublicByTag(ctx context.Context, tag string) (int64, error) {
+	return c[tag], nil
+}
+
+// fakeTagStore 内存标签存储
+type fakeTagStore struct {
+	tags map[string]*model.Tag
+	// createConflict 模拟并发创建同名标签
+	createConflict bool
+}
+
+func (s *fakeTagStore) FindByName(ctx context.Context, name string) (*model.Tag, error) {
+	if tag, ok := s.tags[name]; ok {
+		return tag, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *fakeTagStore) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	for _, tag := range s.tags {
+		if tag.Slug == slug {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeTagStore) Create(ctx context.Context, tag *model.Tag) error {
+	if s.createConflict {
+		s.createConflict = false
+		s.tags[tag.Name] = &model.Tag{Name: tag.Name, Slug: tag.Slug}
+		return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+	}
+	s.tags[tag.Name] = tag
+	return nil
+}
+
+func (s *fakeTagStore) UpdateCount(ctx context.Context, name string, count int64) error {
+	if tag, ok := s.tags[name]; ok {
+		tag.Count = count
+	}
+	return nil
+}
+
+func TestSyncTags(t *testing.T) {
+	store := &fakeTagStore{tags: map[string]*model.Tag{"Go": {Name: "Go", Slug: "go", Count: 5}}}
+	counts := fakeTagCounter{"Go": 4, "Rust": 1}
+
+	if err := syncTags(context.Background(), counts, store, []string{" Go ", "Rust", "", "Go", "Zig"}); err != nil {
+		t.Fatalf("syncTags 返回错误: %v", err)
+	}
+
+	if got := store.tags["Go"]; got.Count != 4 || got.Slug != "go" {
+		t.Errorf("已有标签应只更新计数: %+v", got)
+	}
+	if got := store.tags["Rust"]; got == nil || got.Slug != "rust" || got.Count != 1 || got.ID.IsZero() {
+		t.Errorf("新标签应生成 slug 和 ID: %+v", got)
+	}
+	// 文章数为 0 的标签同样保留，由 List 过滤
+	if got := store.tags["Zig"]; got == nil || got.Count != 0 {
+		t.Errorf("没有公开文章的标签计数应为 0: %+v", got)
+	}
+}
+
+func TestSyncTags_ConcurrentCreate(t *testing.T) {
+	store := &fakeTagStore{tags: make(map[string]*model.Tag), createConflict: true}
+
+	if err := syncTags(context.Background(), fakeTagCounter{"Go": 3}, store, []string{"Go"}); err != nil {
+		t.Fatalf("同名标签已被并发创建时不应返回错误: %v", err)
+	}
+	if got := store.tags["Go"]; got.Count != 3 {
+		t.Errorf("应更新已存在标签的计数, 实际 %d", got.Count)
+	}
+}
+
+func TestUniqueNameSlug(t *testing.T) {
+	id := primitive.NewObjectID()
+	idSuffix := id.Hex()[len(id.Hex())-8:]
+
+	tests := []struct {
+		name  string
+		input string
+		taken int // base、base-2 … 依次被占用的个数
+		want  string
+	}{
+		{"未占用", "Hello World", 0, "hello-world"},
+		{"追加数字", "Hello World", 2, "hello-world-3"},
+		{"最后一个数字候选", "Hello World", maxSlugAttempts - 1, fmt.Sprintf("hello-world-%d", maxSlugAttempts)},
+		{"全部占用时使用 ID 后缀", "Hello World", maxSlugAttempts, "hello-world-" + idSuffix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taken := map[string]bool{}
+			for i := 0; i < tt.taken; i++ {
+				if i == 0 {
+					taken["hello-world"] = true
+				} else {
+					taken[fmt.Sprintf("hello-world-%d", i+1)] = true
+				}
+			}
+			calls := 0
+			got, err := uniqueNameSlug(context.Background(), tt.input, "tag", id, func(ctx context.Context, s string) (bool, error) {
+				calls++
+				return taken[s], nil
+			})
+			if err != nil {
+				t.Fatalf("返回错误: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("期望 %q, 实际 %q", tt.want, got)
+			}
+			if calls > maxSlugAttempts {
+				t.Errorf("查询次数不应超过 %d, 实际 %d", maxSlugAttempts, calls)
+			}
+		})
+	}
+}
+
+func TestUniqueNameSlug_Unconvertible(t *testing.T) {
+	got, err := uniqueNameSlug(context.Background(), "!!!", "tag", primitive.NewObjectID(), func(ctx context.Context, s string) (bool, error) {
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("返回错误: %v", err)
+	}
+	if !strings.HasPrefix(got, "tag-") {
+		t.Errorf("无法转换的名称应使用前缀加哈希, 实际 %q", got)
+	}
+}
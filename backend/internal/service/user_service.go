@@ -11,20 +11,29 @@ import (
 
 // UserService 用户服务实现
 type UserService struct {
-	userDAO *dao.UserDAO
+	userDAO     *dao.UserDAO
+	tokenDAO    *dao.TokenDAO
+	statusCache *UserStatusCache
+	denylist    *AccessTokenDenylist
 }
 
 // NewUserService 创建用户服务
 func NewUserService() *UserService {
 	return &UserService{
-		userDAO: dao.NewUserDAO(),
+		userDAO:     dao.NewUserDAO(),
+		tokenDAO:    dao.NewTokenDAO(),
+		statusCache: GetUserStatusCache(),
+		denylist:    GetAccessTokenDenylist(),
 	}
 }
 
 // NewUserServiceWithDAO 使用指定的 DAO 创建用户服务（用于测试）
-func NewUserServiceWithDAO(userDAO *dao.UserDAO) *UserService {
+func NewUserServiceWithDAO(userDAO *dao.UserDAO, tokenDAO *dao.TokenDAO) *UserService {
 	return &UserService{
-		userDAO: userDAO,
+		userDAO:     userDAO,
+		tokenDAO:    tokenDAO,
+		statusCache: GetUserStatusCache(),
+		denylist:    GetAccessTokenDenylist(),
 	}
 }
 
@@ -51,5 +60,26 @@ func (s *UserService) UpdateAvatar(ctx context.Context, id primitive.ObjectID, a
 	return nil
 }
 
+// SetDisabled 禁用或启用用户；禁用时吊销该用户全部 refresh token 和仍然有效的 access token
+func (s *UserService) SetDisabled(ctx context.Context, id primitive.ObjectID, disabled bool) error {
+	ctx, cancel := dao.WithDefaultTimeout(ctx)
+	defer cancel()
+
+	if err := s.userDAO.UpdateDisabled(ctx, id, disabled); err != nil {
+		return apperrors.WrapMongoError(err, "用户")
+	}
+	s.statusCache.Invalidate(id)
+
+	if disabled {
+		if err := s.tokenDAO.RevokeAllByUserID(ctx, id); err != nil {
+			return apperrors.ServerError(err)
+		}
+		if err := revokeUserAccess(ctx, s.tokenDAO, s.denylist, id, primitive.NilObjectID); err != nil {
+			return apperrors.ServerError(err)
+		}
+	}
+	return nil
+}
+
 // 确保实现接口
 var _ UserServiceInterface = (*UserService)(nil)
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"backend/internal/config"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserStatusCache 用户禁用状态与角色缓存，避免每个受保护请求都查询数据库
+type UserStatusCache struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[primitive.ObjectID]userStatusEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// UserStatus 缓存的用户状态
+type UserStatus struct {
+	Disabled bool
+	Role     string
+}
+
+type userStatusEntry struct {
+	status    UserStatus
+	expiresAt time.Time
+}
+
+var (
+	userStatusCache     *UserStatusCache
+	userStatusCacheOnce sync.Once
+)
+
+// GetUserStatusCache 获取全局用户状态缓存（单例，保证禁用操作能使所有实例的缓存失效）
+func GetUserStatusCache() *UserStatusCache {
+	userStatusCacheOnce.Do(func() {
+		userStatusCache = NewUserStatusCache(config.AppConfig.UserStatusCacheTTL)
+	})
+	return userStatusCache
+}
+
+// NewUserStatusCache 创建用户状态缓存
+func NewUserStatusCache(ttl time.Duration) *UserStatusCache {
+	return &UserStatusCache{
+		ttl:     ttl,
+		entries: make(map[primitive.ObjectID]userStatusEntry),
+		now:     time.Now,
+	}
+}
+
+// Get 获取缓存的用户状态，ok 为 false 表示未命中或已过期
+func (c *UserStatusCache) Get(userID primitive.ObjectID) (status UserStatus, ok bool) {
+	c.mu.RLock()
+	entry, exists := c.entries[userID]
+	c.mu.RUnlock()
+
+	if !exists || c.now().After(entry.expiresAt) {
+		return UserStatus{}, false
+	}
+	return entry.status, true
+}
+
+// Set 写入用户状态
+func (c *UserStatusCache) Set(userID primitive.ObjectID, status UserStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.sweep(now)
+	c.entries[userID] = userStatusEntry{
+		status:    status,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Invalidate 删除指定用户的缓存
+func (c *UserStatusCache) Invalidate(userID primitive.ObjectID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, userID)
+}
+
+// sweep 清理过期记录，避免不再访问的用户一直占用内存；间隔不小于 ttl，摊销遍历开销
+func (c *UserStatusCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < max(c.ttl, time.Minute) {
+		return
+	}
+	c.lastSweep = now
+	for id, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"backend/internal/model"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUserStatusCache_Expiry(t *testing.T) {
+	now := time.Now()
+	c := NewUserStatusCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	id := primitive.NewObjectID()
+	c.Set(id, UserStatus{Disabled: true, Role: model.RoleUser})
+	if status, ok := c.Get(id); !ok || !status.Disabled || status.Role != model.RoleUser {
+		t.Fatalf("期望命中禁用状态, 实际 %+v ok=%v", status, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get(id); ok {
+		t.Error("过期后不应命中")
+	}
+
+	c.Invalidate(id)
+	if _, ok := c.Get(id); ok {
+		t.Error("失效后不应命中")
+	}
+}
+
+func TestUserStatusCache_SweepsExpiredEntries(t *testing.T) {
+	now := time.Now()
+	c := NewUserStatusCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	for range 100 {
+		c.Set(primitive.NewObjectID(), UserStatus{})
+	}
+
+	// 超过清理间隔后，下一次写入清理全部过期记录
+	now = now.Add(2 * time.Minute)
+	fresh := primitive.NewObjectID()
+	c.Set(fresh, UserStatus{})
+
+	if len(c.entries) != 1 {
+		t.Errorf("过期记录应被清理, 剩余 %d 条", len(c.entries))
+	}
+	if _, ok := c.Get(fresh); !ok {
+		t.Error("新写入的记录应保留")
+	}
+}
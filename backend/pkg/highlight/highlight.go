@@ -0,0 +1,101 @@
+package highlight
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+	ellipsis  = "…"
+)
+
+var (
+	tagRegex        = regexp.MustCompile(`<[^>]*>`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// Matcher 关键词匹配器：大小写不敏感，关键词中的正则元字符会被转义
+type Matcher struct {
+	re *regexp.Regexp
+}
+
+// NewMatcher 根据关键词创建匹配器，没有有效关键词时返回 nil
+func NewMatcher(terms []string) *Matcher {
+	quoted := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.TrimSpace(t); t != "" {
+			quoted = append(quoted, regexp.QuoteMeta(t))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	// 长词优先匹配，避免短词截断长词的高亮
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return &Matcher{re: regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))}
+}
+
+// Mark 转义 HTML 并用 <mark> 包裹匹配的关键词
+func (m *Matcher) Mark(text string) string {
+	if m == nil {
+		return html.EscapeString(text)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range m.re.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(markOpen)
+		b.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		b.WriteString(markClose)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// Snippet 从 HTML 正文中截取包含首个关键词的片段（最多 maxRunes 个字符）并高亮
+func (m *Matcher) Snippet(content string, maxRunes int) string {
+	plain := StripHTML(content)
+	runes := []rune(plain)
+
+	start := 0
+	if m != nil {
+		if loc := m.re.FindStringIndex(plain); loc != nil {
+			// 关键词前保留约四分之一的上下文
+			start = utf8.RuneCountInString(plain[:loc[0]]) - maxRunes/4
+			if start < 0 {
+				start = 0
+			}
+		}
+	}
+	end := start + maxRunes
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - maxRunes; start < 0 {
+			start = 0
+		}
+	}
+
+	snippet := m.Mark(string(runes[start:end]))
+	if start > 0 {
+		snippet = ellipsis + snippet
+	}
+	if end < len(runes) {
+		snippet += ellipsis
+	}
+	return snippet
+}
+
+// StripHTML 去除 HTML 标签、反转义实体并折叠空白
+func StripHTML(s string) string {
+	s = tagRegex.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,52 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatcher_Mark(t *testing.T) {
+	m := NewMatcher([]string{"go", "gin"})
+	got := m.Mark("Go + Gin <后端>")
+	want := "<mark>Go</mark> + <mark>Gin</mark> &lt;后端&gt;"
+	if got != want {
+		t.Errorf("Mark() = %q, 期望 %q", got, want)
+	}
+}
+
+func TestMatcher_RegexMetacharacters(t *testing.T) {
+	m := NewMatcher([]string{"c++", "(a|b)"})
+	got := m.Mark("学习 c++ 与 (a|b) 以及 ab")
+	if !strings.Contains(got, "<mark>c++</mark>") || !strings.Contains(got, "<mark>(a|b)</mark>") {
+		t.Errorf("元字符应按字面匹配: %q", got)
+	}
+	if strings.Contains(got, "<mark>ab</mark>") {
+		t.Errorf("不应把关键词当作正则解释: %q", got)
+	}
+}
+
+func TestMatcher_Snippet(t *testing.T) {
+	content := "<p>" + strings.Repeat("前文", 50) + "<b>MongoDB</b> 全文索引" + strings.Repeat("后文", 50) + "</p>"
+	m := NewMatcher([]string{"mongodb"})
+	got := m.Snippet(content, 40)
+
+	if !strings.Contains(got, "<mark>MongoDB</mark>") {
+		t.Errorf("片段应包含高亮关键词: %q", got)
+	}
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+		t.Errorf("截断的片段应带省略号: %q", got)
+	}
+	if strings.Contains(got, "<b>") {
+		t.Errorf("片段不应包含原文 HTML 标签: %q", got)
+	}
+}
+
+func TestNilMatcher(t *testing.T) {
+	var m *Matcher = NewMatcher([]string{" ", ""})
+	if m != nil {
+		t.Fatal("没有有效关键词时应返回 nil")
+	}
+	if got := m.Snippet("<p>a &amp; b</p>", 10); got != "a &amp; b" {
+		t.Errorf("Snippet() = %q", got)
+	}
+}
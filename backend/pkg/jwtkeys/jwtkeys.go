@@ -0,0 +1,294 @@
+// Package jwtkeys 管理 JWT 签名密钥环：按 kid 选择验证密钥、按生效时间轮换签名密钥，并导出 JWKS。
+//
+// 每个密钥有生效时间 ActivateAt，当前签名密钥为已生效密钥中最新的一个。
+// 被新密钥取代后，旧密钥在 overlap 时间内仍可用于验证，保证已签发的令牌平滑过期；
+// 尚未生效的密钥同样可以验证并出现在 JWKS 中，便于下游提前缓存。
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 支持的签名算法
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// HS256 密钥的最小长度（字节）
+const minHMACKeySize = 32
+
+var (
+	ErrNoSigningKey = errors.New("jwtkeys: no active signing key")
+	ErrUnknownKey   = errors.New("jwtkeys: unknown or retired key id")
+)
+
+// KeySpec 密钥配置，File 与 Env 二选一：File 为 PEM 文件（HS256 为密钥原文）路径，Env 为保存密钥内容的环境变量名
+type KeySpec struct {
+	ID         string     `json:"kid"`
+	Algorithm  string     `json:"alg"`
+	File       string     `json:"file,omitempty"`
+	Env        string     `json:"env,omitempty"`
+	ActivateAt time.Time  `json:"activate_at,omitempty"`
+	ExpireAt   *time.Time `json:"expire_at,omitempty"`
+}
+
+// Key 已加载的密钥。只有公钥的非对称密钥只能用于验证
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	SignKey    interface{}
+	VerifyKey  interface{}
+	ActivateAt time.Time
+	// ExpireAt 停止验证的时间，零值表示由轮换自动计算
+	ExpireAt time.Time
+}
+
+// Keyring 密钥环，加载后只读，可并发使用
+type Keyring struct {
+	keys    []*Key
+	overlap time.Duration
+	now     func() time.Time
+}
+
+// New 创建密钥环，overlap 为旧密钥被取代后继续用于验证的时长（应不小于 access token 有效期）
+func New(keys []*Key, overlap time.Duration) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("jwtkeys: keyring is empty")
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if k.ID == "" {
+			return nil, errors.New("jwtkeys: key id is required")
+		}
+		if seen[k.ID] {
+			return nil, fmt.Errorf("jwtkeys: duplicate key id %q", k.ID)
+		}
+		seen[k.ID] = true
+	}
+
+	sorted := append([]*Key(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActivateAt.Before(sorted[j].ActivateAt)
+	})
+	return &Keyring{keys: sorted, overlap: overlap, now: time.Now}, nil
+}
+
+// Load 按配置加载密钥
+func Load(specs []KeySpec, overlap time.Duration) (*Keyring, error) {
+	keys := make([]*Key, 0, len(specs))
+	for _, spec := range specs {
+		key, err := LoadKey(spec)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return New(keys, overlap)
+}
+
+// LoadKey 读取并解析单个密钥
+func LoadKey(spec KeySpec) (*Key, error) {
+	var material []byte
+	switch {
+	case spec.File != "":
+		data, err := os.ReadFile(spec.File)
+		if err != nil {
+			return nil, fmt.Errorf("jwtkeys: read key %q: %w", spec.ID, err)
+		}
+		material = data
+	case spec.Env != "":
+		material = []byte(os.Getenv(spec.Env))
+		if len(material) == 0 {
+			return nil, fmt.Errorf("jwtkeys: env %s for key %q is empty", spec.Env, spec.ID)
+		}
+	default:
+		return nil, fmt.Errorf("jwtkeys: key %q needs file or env", spec.ID)
+	}
+
+	key, err := ParseKey(spec.ID, spec.Algorithm, material)
+	if err != nil {
+		return nil, err
+	}
+	key.ActivateAt = spec.ActivateAt
+	if spec.ExpireAt != nil {
+		key.ExpireAt = *spec.ExpireAt
+	}
+	return key, nil
+}
+
+// ParseKey 按算法解析密钥内容：HS256 为密钥原文，RS256/EdDSA 为 PEM 私钥或公钥
+func ParseKey(id, alg string, material []byte) (*Key, error) {
+	key := &Key{ID: id}
+	var err error
+
+	switch alg {
+	case AlgHS256:
+		secret := []byte(strings.TrimSpace(string(material)))
+		if len(secret) < minHMACKeySize {
+			return nil, fmt.Errorf("jwtkeys: HS256 key %q must be at least %d bytes", id, minHMACKeySize)
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.SignKey, key.VerifyKey = secret, secret
+	case AlgRS256:
+		key.Method = jwt.SigningMethodRS256
+		var priv *rsa.PrivateKey
+		if priv, err = jwt.ParseRSAPrivateKeyFromPEM(material); err == nil {
+			key.SignKey, key.VerifyKey = priv, &priv.PublicKey
+		} else if key.VerifyKey, err = jwt.ParseRSAPublicKeyFromPEM(material); err != nil {
+			return nil, fmt.Errorf("jwtkeys: parse RS256 key %q: %w", id, err)
+		}
+	case AlgEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		var priv crypto.PrivateKey
+		if priv, err = jwt.ParseEdPrivateKeyFromPEM(material); err == nil {
+			key.SignKey, key.VerifyKey = priv, priv.(ed25519.PrivateKey).Public()
+		} else if key.VerifyKey, err = jwt.ParseEdPublicKeyFromPEM(material); err != nil {
+			return nil, fmt.Errorf("jwtkeys: parse EdDSA key %q: %w", id, err)
+		}
+	default:
+		return nil, fmt.Errorf("jwtkeys: unsupported algorithm %q for key %q", alg, id)
+	}
+	return key, nil
+}
+
+// SigningKey 返回当前用于签名的密钥
+func (r *Keyring) SigningKey() (*Key, error) {
+	now := r.now()
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		k := r.keys[i]
+		if k.SignKey != nil && !k.ActivateAt.After(now) && r.verifiable(i, now) {
+			return k, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// Sign 使用当前签名密钥签发令牌，并在头部写入 kid
+func (r *Keyring) Sign(claims jwt.Claims) (string, error) {
+	key, err := r.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.SignKey)
+}
+
+// Keyfunc 按 kid 选择验证密钥，算法必须与密钥一致（防止算法混淆攻击）
+func (r *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := r.VerificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("jwtkeys: unexpected algorithm %s for key %q", token.Method.Alg(), kid)
+	}
+	return key.VerifyKey, nil
+}
+
+// VerificationKey 返回 kid 对应且仍可用于验证的密钥
+func (r *Keyring) VerificationKey(kid string) (*Key, error) {
+	now := r.now()
+	for i, k := range r.keys {
+		if k.ID == kid && r.verifiable(i, now) {
+			return k, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// Algorithms 返回密钥环中使用的算法，用于限制解析时接受的算法
+func (r *Keyring) Algorithms() []string {
+	seen := make(map[string]bool)
+	var algs []string
+	for _, k := range r.keys {
+		if alg := k.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// verifiable 判断第 i 个密钥在 now 时是否仍可验证：显式过期时间优先，
+// 否则在下一个密钥生效 overlap 之后退役
+func (r *Keyring) verifiable(i int, now time.Time) bool {
+	k := r.keys[i]
+	if !k.ExpireAt.IsZero() {
+		return now.Before(k.ExpireAt)
+	}
+	for _, next := range r.keys[i+1:] {
+		if next.SignKey == nil || !next.ActivateAt.After(k.ActivateAt) || next.ActivateAt.After(now) {
+			continue
+		}
+		if !next.ExpireAt.IsZero() && !now.Before(next.ExpireAt) {
+			continue
+		}
+		return now.Before(next.ActivateAt.Add(r.overlap))
+	}
+	return true
+}
+
+// JWK JSON Web Key（RFC 7517）
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS 导出仍可验证的非对称公钥，HS256 密钥不会被公开
+func (r *Keyring) JWKS() JWKS {
+	now := r.now()
+	set := JWKS{Keys: []JWK{}}
+	for i, k := range r.keys {
+		if !r.verifiable(i, now) {
+			continue
+		}
+		switch pub := k.VerifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: k.ID,
+				Use: "sig",
+				Alg: AlgRS256,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: k.ID,
+				Use: "sig",
+				Alg: AlgEdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return set
+}
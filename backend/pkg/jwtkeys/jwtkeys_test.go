@@ -0,0 +1,176 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func edKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成 Ed25519 密钥失败: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func rsaPublicKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成 RSA 密钥失败: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func mustParseKey(t *testing.T, id, alg string, material []byte, activateAt time.Time) *Key {
+	t.Helper()
+	key, err := ParseKey(id, alg, material)
+	if err != nil {
+		t.Fatalf("解析密钥 %s 失败: %v", id, err)
+	}
+	key.ActivateAt = activateAt
+	return key
+}
+
+func verify(r *Keyring, token string) error {
+	_, err := jwt.Parse(token, r.Keyfunc, jwt.WithValidMethods(r.Algorithms()))
+	return err
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	r, err := New([]*Key{mustParseKey(t, "ed1", AlgEdDSA, edKeyPEM(t), time.Time{})}, time.Hour)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+
+	token, err := r.Sign(jwt.MapClaims{"sub": "u1"})
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	parsed, err := jwt.Parse(token, r.Keyfunc)
+	if err != nil {
+		t.Fatalf("验证失败: %v", err)
+	}
+	if parsed.Header["kid"] != "ed1" {
+		t.Errorf("期望 kid=ed1, 实际 %v", parsed.Header["kid"])
+	}
+}
+
+func TestKeyring_RotationOverlap(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(30 * 24 * time.Hour)
+	oldKey := mustParseKey(t, "old", AlgHS256, []byte(strings.Repeat("a", 32)), t0)
+	newKey := mustParseKey(t, "new", AlgEdDSA, edKeyPEM(t), t1)
+
+	r, err := New([]*Key{newKey, oldKey}, time.Hour)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+
+	// 新密钥生效前使用旧密钥签名
+	r.now = func() time.Time { return t1.Add(-time.Minute) }
+	oldToken, err := r.Sign(jwt.MapClaims{"sub": "u1"})
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if k, _ := r.SigningKey(); k.ID != "old" {
+		t.Fatalf("生效前期望使用 old, 实际 %s", k.ID)
+	}
+
+	// 重叠期内切换到新密钥，旧令牌仍然有效
+	r.now = func() time.Time { return t1.Add(30 * time.Minute) }
+	if k, _ := r.SigningKey(); k.ID != "new" {
+		t.Errorf("生效后期望使用 new, 实际 %s", k.ID)
+	}
+	if err := verify(r, oldToken); err != nil {
+		t.Errorf("重叠期内旧令牌应有效: %v", err)
+	}
+
+	// 重叠期结束后旧密钥退役
+	r.now = func() time.Time { return t1.Add(2 * time.Hour) }
+	if err := verify(r, oldToken); err == nil {
+		t.Error("重叠期结束后旧令牌应失效")
+	}
+}
+
+func TestKeyring_RejectsAlgorithmMismatch(t *testing.T) {
+	secret := []byte(strings.Repeat("s", 32))
+	r, err := New([]*Key{
+		mustParseKey(t, "hs", AlgHS256, secret, time.Time{}),
+		mustParseKey(t, "rsa", AlgRS256, rsaPublicKeyPEM(t), time.Time{}),
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+
+	// 用 HS256 伪造声称使用 RSA 密钥的令牌
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"})
+	forged.Header["kid"] = "rsa"
+	token, _ := forged.SignedString(secret)
+
+	if err := verify(r, token); err == nil {
+		t.Error("kid 与算法不匹配的令牌应被拒绝")
+	}
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	r, err := New([]*Key{
+		mustParseKey(t, "hs", AlgHS256, []byte(strings.Repeat("s", 32)), time.Time{}),
+		mustParseKey(t, "rsa", AlgRS256, rsaPublicKeyPEM(t), time.Time{}),
+		mustParseKey(t, "ed", AlgEdDSA, edKeyPEM(t), time.Time{}),
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("创建密钥环失败: %v", err)
+	}
+
+	set := r.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("期望导出 2 个公钥, 实际 %d", len(set.Keys))
+	}
+	for _, k := range set.Keys {
+		switch k.Kid {
+		case "rsa":
+			if k.Kty != "RSA" || k.N == "" || k.E != "AQAB" {
+				t.Errorf("RSA JWK 字段错误: %+v", k)
+			}
+		case "ed":
+			if k.Kty != "OKP" || k.Crv != "Ed25519" || k.X == "" {
+				t.Errorf("Ed25519 JWK 字段错误: %+v", k)
+			}
+		default:
+			t.Errorf("不应导出密钥 %s", k.Kid)
+		}
+	}
+}
+
+func TestParseKey_Errors(t *testing.T) {
+	if _, err := ParseKey("short", AlgHS256, []byte("too-short")); err == nil {
+		t.Error("过短的 HS256 密钥应报错")
+	}
+	if _, err := ParseKey("bad", "ES256", []byte("x")); err == nil {
+		t.Error("不支持的算法应报错")
+	}
+	if _, err := New([]*Key{
+		mustParseKey(t, "dup", AlgEdDSA, edKeyPEM(t), time.Time{}),
+		mustParseKey(t, "dup", AlgEdDSA, edKeyPEM(t), time.Time{}),
+	}, time.Hour); err == nil {
+		t.Error("重复的 kid 应报错")
+	}
+}
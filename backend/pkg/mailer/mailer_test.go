@@ -0,0 +1,207 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageBytes(t *testing.T) {
+	msg := &Message{To: "张三 <zhangsan@example.com>", Subject: "验证邮箱", Body: "你好，世界"}
+
+	data, err := msg.Bytes("Blog <noreply@example.com>", time.Now())
+	if err != nil {
+		t.Fatalf("生成邮件失败: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("解析邮件失败: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "验证邮箱" {
+		t.Errorf("主题期望 %q, 实际 %q (%v)", "验证邮箱", subject, err)
+	}
+	if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("Message-ID 应使用发件人域名: %s", parsed.Header.Get("Message-ID"))
+	}
+
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(readAll(t, parsed), "\r\n", ""))
+	if err != nil || string(body) != "你好，世界" {
+		t.Errorf("正文期望 %q, 实际 %q (%v)", "你好，世界", body, err)
+	}
+}
+
+func TestMessageBytes_InvalidHeader(t *testing.T) {
+	cases := []*Message{
+		{To: "not-an-address", Subject: "hi"},
+		{To: "a@example.com", Subject: "hi\r\nBcc: victim@example.com"},
+	}
+	for _, msg := range cases {
+		if _, err := msg.Bytes("noreply@example.com", time.Now()); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("%+v 期望 ErrInvalidHeader, 实际 %v", msg, err)
+		}
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m := NewFileMailer(dir, "noreply@example.com")
+
+	if err := m.Send(context.Background(), &Message{To: "a@example.com", Subject: "hi", Body: "body"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("期望生成 1 个文件, 实际 %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "To: <a@example.com>") {
+		t.Errorf("文件内容缺少收件人: %s", data)
+	}
+}
+
+func TestLogMailer(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewLogMailer(&buf)
+
+	if err := m.Send(context.Background(), &Message{To: "a@example.com", Subject: "主题", Body: "正文"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+	for _, want := range []string{"To: a@example.com", "Subject: 主题", "正文"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("输出缺少 %q: %s", want, buf.String())
+		}
+	}
+}
+
+func TestTemplateRender(t *testing.T) {
+	tpl := MustParseTemplate("verify", "欢迎 {{.Name}}", "点击 {{.Link}} 完成验证")
+
+	msg, err := tpl.Render("a@example.com", map[string]string{"Name": "张三", "Link": "https://example.com/v?token=x"})
+	if err != nil {
+		t.Fatalf("渲染失败: %v", err)
+	}
+	if msg.Subject != "欢迎 张三" || msg.Body != "点击 https://example.com/v?token=x 完成验证" {
+		t.Errorf("渲染结果不正确: %+v", msg)
+	}
+
+	if _, err := tpl.Render("a@example.com", map[string]string{"Name": "张三"}); err == nil {
+		t.Error("缺少字段时应返回错误")
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go serveSMTP(ln, received)
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host:     host,
+		Port:     portNum,
+		From:     "noreply@example.com",
+		Security: SecurityNone,
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("创建发送器失败: %v", err)
+	}
+
+	if err := m.Send(context.Background(), &Message{To: "a@example.com", Subject: "hi", Body: "body"}); err != nil {
+		t.Fatalf("发送失败: %v", err)
+	}
+
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "MAIL FROM:<noreply@example.com>") || !strings.Contains(data, "RCPT TO:<a@example.com>") {
+			t.Errorf("SMTP 会话不正确: %s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("服务器未收到邮件")
+	}
+}
+
+func TestNewSMTPMailer_InvalidConfig(t *testing.T) {
+	cases := []SMTPConfig{
+		{From: "noreply@example.com"},
+		{Host: "smtp.example.com", From: "invalid"},
+		{Host: "smtp.example.com", From: "noreply@example.com", Security: "ssl"},
+	}
+	for _, cfg := range cases {
+		if _, err := NewSMTPMailer(cfg); err == nil {
+			t.Errorf("%+v 应返回错误", cfg)
+		}
+	}
+}
+
+// serveSMTP 最小化的 SMTP 服务器，只处理一次会话并记录客户端命令
+func serveSMTP(ln net.Listener, received chan<- string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var log strings.Builder
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		log.WriteString(line)
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 go ahead")
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+			}
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			received <- log.String()
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func readAll(t *testing.T, msg *mail.Message) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(msg.Body); err != nil {
+		t.Fatalf("读取正文失败: %v", err)
+	}
+	return buf.String()
+}
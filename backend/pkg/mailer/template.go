@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template 邮件模板，主题与正文均使用 text/template 语法
+type Template struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// ParseTemplate 解析模板，缺失的字段按错误处理
+func ParseTemplate(name, subject, body string) (*Template, error) {
+	s, err := template.New(name + ".subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return nil, err
+	}
+	b, err := template.New(name + ".body").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{subject: s, body: b}, nil
+}
+
+// MustParseTemplate 与 ParseTemplate 相同，解析失败时 panic，用于内置模板
+func MustParseTemplate(name, subject, body string) *Template {
+	t, err := ParseTemplate(name, subject, body)
+	if err != nil {
+		panic(fmt.Sprintf("mailer: parse template %s: %v", name, err))
+	}
+	return t
+}
+
+// Render 渲染出发给 to 的邮件
+func (t *Template) Render(to string, data any) (*Message, error) {
+	var subject, body strings.Builder
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
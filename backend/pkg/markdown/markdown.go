@@ -0,0 +1,161 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"backend/pkg/slug"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// Heading 目录中的一个标题
+type Heading struct {
+	Level int    `bson:"level" json:"level"`
+	Text  string `bson:"text" json:"text"`
+	ID    string `bson:"id" json:"id"`
+}
+
+// Document 文章渲染结果
+type Document struct {
+	HTML string
+	TOC  []Heading
+}
+
+var (
+	// 文章：GFM 语法，标题自动生成锚点，原始 HTML 不输出
+	articleMarkdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+
+	// 留言：只支持行内格式、列表、引用和代码，换行即换行
+	messageMarkdown = goldmark.New(
+		goldmark.WithExtensions(extension.Linkify, extension.Strikethrough),
+		goldmark.WithRendererOptions(html.WithHardWraps()),
+	)
+
+	articlePolicy = newArticlePolicy()
+	messagePolicy = newMessagePolicy()
+)
+
+// Render 将文章 Markdown 渲染为 HTML 并提取目录。
+// 代码块带 language-xxx 类名供前端高亮，标题带 id 作为锚点
+func Render(src string) (*Document, error) {
+	source := []byte(src)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := articleMarkdown.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+
+	var buf bytes.Buffer
+	if err := articleMarkdown.Renderer().Render(&buf, source, doc); err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		HTML: articlePolicy.Sanitize(buf.String()),
+		TOC:  extractTOC(doc, source),
+	}, nil
+}
+
+// RenderMessage 将留言、回复等用户提交内容渲染为 HTML，并按严格白名单过滤
+func RenderMessage(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := messageMarkdown.Convert([]byte(src), &buf); err != nil {
+		return "", err
+	}
+	return messagePolicy.Sanitize(buf.String()), nil
+}
+
+func newArticlePolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[a-z0-9-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	// GFM 任务列表
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+func newMessagePolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "strong", "em", "del", "code", "pre", "blockquote", "ul", "ol", "li")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// extractTOC 按文档顺序收集标题
+func extractTOC(doc ast.Node, source []byte) []Heading {
+	toc := []Heading{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		heading := Heading{Level: h.Level, Text: strings.TrimSpace(plainText(h, source))}
+		if id, ok := h.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				heading.ID = string(b)
+			}
+		}
+		toc = append(toc, heading)
+		return ast.WalkSkipChildren, nil
+	})
+	return toc
+}
+
+// plainText 提取节点下的纯文本
+func plainText(n ast.Node, source []byte) string {
+	var sb strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		default:
+			sb.WriteString(plainText(c, source))
+		}
+	}
+	return sb.String()
+}
+
+// headingIDs 用 slug 生成标题锚点（汉字转拼音），重复时追加 -N
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{used: make(map[string]bool)}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := slug.Make(string(value))
+	if base == "" {
+		base = "section"
+	}
+	id := base
+	for i := 1; s.used[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	s.used[id] = true
+	return []byte(id)
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.used[string(value)] = true
+}
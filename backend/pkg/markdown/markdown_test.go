@@ -0,0 +1,61 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_HeadingAnchorsAndTOC(t *testing.T) {
+	doc, err := Render("# 快速开始\n\n## Install\n\n## Install\n\n正文")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := []Heading{
+		{Level: 1, Text: "快速开始", ID: "kuai-su-kai-shi"},
+		{Level: 2, Text: "Install", ID: "install"},
+		{Level: 2, Text: "Install", ID: "install-1"},
+	}
+	if len(doc.TOC) != len(want) {
+		t.Fatalf("TOC 长度 = %d, 期望 %d: %+v", len(doc.TOC), len(want), doc.TOC)
+	}
+	for i, h := range want {
+		if doc.TOC[i] != h {
+			t.Errorf("TOC[%d] = %+v, 期望 %+v", i, doc.TOC[i], h)
+		}
+	}
+	if !strings.Contains(doc.HTML, `<h2 id="install-1">`) {
+		t.Errorf("标题缺少锚点: %s", doc.HTML)
+	}
+}
+
+func TestRender_CodeClassAndRawHTML(t *testing.T) {
+	doc, err := Render("```go\nfmt.Println(1)\n```\n\n<script>alert(1)</script>\n\n[x](javascript:alert(1))")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(doc.HTML, `<code class="language-go">`) {
+		t.Errorf("代码块缺少语言类名: %s", doc.HTML)
+	}
+	if strings.Contains(doc.HTML, "<script") || strings.Contains(doc.HTML, "javascript:") {
+		t.Errorf("危险内容未被过滤: %s", doc.HTML)
+	}
+}
+
+func TestRenderMessage_StrictAllowlist(t *testing.T) {
+	got, err := RenderMessage("**你好**\n第二行 https://example.com\n\n<img src=x onerror=alert(1)>\n\n# 标题")
+	if err != nil {
+		t.Fatalf("RenderMessage() error = %v", err)
+	}
+
+	for _, s := range []string{"<strong>你好</strong>", "<br", `rel="nofollow noopener"`} {
+		if !strings.Contains(got, s) {
+			t.Errorf("结果缺少 %q: %s", s, got)
+		}
+	}
+	for _, s := range []string{"<img", "onerror", "<h1"} {
+		if strings.Contains(got, s) {
+			t.Errorf("结果不应包含 %q: %s", s, got)
+		}
+	}
+}
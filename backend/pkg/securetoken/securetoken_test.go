@@ -0,0 +1,60 @@
+package securetoken
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAndParse(t *testing.T) {
+	tok, err := Generate()
+	if err != nil {
+		t.Fatalf("生成令牌失败: %v", err)
+	}
+	if len(tok.Selector) != SelectorLength {
+		t.Errorf("selector 长度期望 %d, 实际 %d", SelectorLength, len(tok.Selector))
+	}
+
+	parsed, err := Parse(tok.String())
+	if err != nil {
+		t.Fatalf("解析令牌失败: %v", err)
+	}
+	if parsed != tok {
+		t.Errorf("解析结果不一致: %+v != %+v", parsed, tok)
+	}
+}
+
+func TestParse_Legacy(t *testing.T) {
+	legacy := strings.Repeat("ab", 32)
+
+	tok, err := Parse(legacy)
+	if err != nil {
+		t.Fatalf("解析旧格式令牌失败: %v", err)
+	}
+	if tok.Selector != legacy[:SelectorLength] || tok.Verifier != legacy[SelectorLength:] {
+		t.Errorf("旧格式拆分错误: %+v", tok)
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	cases := []string{"", "abc", "zzzzzzzzzzzzzzzz.verifier", "0123456789abcdef.", "short.verifier"}
+	for _, raw := range cases {
+		if _, err := Parse(raw); err != ErrMalformed {
+			t.Errorf("Parse(%q) 期望 ErrMalformed, 实际 %v", raw, err)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	h1 := Hash([]byte("key1"), "verifier")
+	h2 := Hash([]byte("key2"), "verifier")
+
+	if h1 == h2 {
+		t.Error("不同密钥的哈希应不同")
+	}
+	if !Equal(h1, Hash([]byte("key1"), "verifier")) {
+		t.Error("相同输入的哈希应相同")
+	}
+	if strings.Contains(h1, "verifier") {
+		t.Error("哈希不应包含原文")
+	}
+}
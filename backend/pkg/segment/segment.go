@@ -0,0 +1,135 @@
+package segment
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer 分词器接口，索引与查询必须使用同一个实现
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+//go:embed dict.txt
+var builtinDict string
+
+// DictTokenizer 基于词典的分词器：
+//   - 英文与数字按非字母数字字符切分并转为小写，忽略单个字符；
+//   - 连续汉字使用正向最大匹配切分，词典未收录的部分按二元组（bigram）切分，保证召回。
+type DictTokenizer struct {
+	words      map[string]struct{}
+	maxWordLen int
+}
+
+// NewDictTokenizer 使用给定词表创建分词器
+func NewDictTokenizer(words []string) *DictTokenizer {
+	t := &DictTokenizer{words: make(map[string]struct{}, len(words))}
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		t.words[w] = struct{}{}
+		if n := utf8.RuneCountInString(w); n > t.maxWordLen {
+			t.maxWordLen = n
+		}
+	}
+	return t
+}
+
+var defaultTokenizer = NewDictTokenizer(strings.Split(builtinDict, "\n"))
+
+// Default 返回使用内置词典的分词器
+func Default() *DictTokenizer {
+	return defaultTokenizer
+}
+
+// Tokenize 切分文本，结果按出现顺序去重
+func (t *DictTokenizer) Tokenize(text string) []string {
+	seen := make(map[string]struct{})
+	var tokens []string
+	emit := func(tok string) {
+		if _, ok := seen[tok]; ok {
+			return
+		}
+		seen[tok] = struct{}{}
+		tokens = append(tokens, tok)
+	}
+
+	var word []rune
+	var han []rune
+	flushWord := func() {
+		if len(word) > 1 {
+			emit(strings.ToLower(string(word)))
+		}
+		word = word[:0]
+	}
+	flushHan := func() {
+		for _, tok := range t.segmentHan(han) {
+			emit(tok)
+		}
+		han = han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+
+	return tokens
+}
+
+// segmentHan 对连续汉字做正向最大匹配，未登录片段切为二元组
+func (t *DictTokenizer) segmentHan(runes []rune) []string {
+	var tokens []string
+	var unknown []rune
+
+	flushUnknown := func() {
+		switch len(unknown) {
+		case 0:
+		case 1:
+			tokens = append(tokens, string(unknown))
+		default:
+			for i := 0; i+1 < len(unknown); i++ {
+				tokens = append(tokens, string(unknown[i:i+2]))
+			}
+		}
+		unknown = unknown[:0]
+	}
+
+	for i := 0; i < len(runes); {
+		matched := 0
+		for n := min(t.maxWordLen, len(runes)-i); n >= 2; n-- {
+			if _, ok := t.words[string(runes[i:i+n])]; ok {
+				matched = n
+				break
+			}
+		}
+
+		if matched == 0 {
+			unknown = append(unknown, runes[i])
+			i++
+			continue
+		}
+
+		flushUnknown()
+		tokens = append(tokens, string(runes[i:i+matched]))
+		i += matched
+	}
+	flushUnknown()
+
+	return tokens
+}
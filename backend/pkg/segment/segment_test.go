@@ -0,0 +1,41 @@
+package segment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDictTokenizer_Tokenize(t *testing.T) {
+	tok := NewDictTokenizer([]string{"后端", "开发", "数据库", "数据", "全文搜索"})
+
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"Go 后端开发", []string{"go", "后端", "开发"}},
+		{"数据库索引", []string{"数据库", "索引"}},
+		{"MongoDB全文搜索", []string{"mongodb", "全文搜索"}},
+		{"a 的 b", []string{"的"}},
+		{"开发，开发！", []string{"开发"}},
+	}
+
+	for _, tc := range cases {
+		if got := tok.Tokenize(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Tokenize(%q) = %v, 期望 %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestDefault_QueryMatchesIndex(t *testing.T) {
+	tok := Default()
+	index := make(map[string]bool)
+	for _, term := range tok.Tokenize("本文介绍如何使用 Gin 框架实现中间件和路由分组") {
+		index[term] = true
+	}
+
+	for _, term := range tok.Tokenize("gin 中间件") {
+		if !index[term] {
+			t.Errorf("查询词 %q 未出现在索引中", term)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sensitive
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Matcher 基于 DFA（字典树）的敏感词匹配器：大小写不敏感，
+// 词中间夹杂的空白和标点（如“敏 感-词”）同样能匹配。构建后只读，可并发使用。
+// Find 返回词的标准形式（见 Normalize）
+type Matcher struct {
+	root *node
+}
+
+type node struct {
+	children map[rune]*node
+	// word 非空表示从根到此处构成一个完整的词
+	word string
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// New 根据词表构建匹配器，空词和只含分隔符的词会被忽略
+func New(words []string) *Matcher {
+	m := &Matcher{root: newNode()}
+	for _, w := range words {
+		m.add(w)
+	}
+	return m
+}
+
+func (m *Matcher) add(word string) {
+	word = Normalize(word)
+	if word == "" {
+		return
+	}
+	cur := m.root
+	for _, r := range word {
+		next, ok := cur.children[r]
+		if !ok {
+			next = newNode()
+			cur.children[r] = next
+		}
+		cur = next
+	}
+	cur.word = word
+}
+
+// Normalize 返回词的标准形式：去掉分隔符并转为小写。标准形式相同的词（如 “Casino” 与 “casino”）
+// 在匹配器中是同一个词，调用方按标准形式关联词的其他属性
+func Normalize(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if !isSeparator(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// Find 返回文本中出现的敏感词（按首次出现顺序去重），每个位置取最长匹配
+func (m *Matcher) Find(text string) []string {
+	if m == nil || len(m.root.children) == 0 {
+		return nil
+	}
+
+	runes := []rune(text)
+	seen := make(map[string]bool)
+	var found []string
+
+	for i := 0; i < len(runes); i++ {
+		if isSeparator(runes[i]) {
+			continue
+		}
+		cur := m.root
+		matched, end := "", -1
+		for j := i; j < len(runes); j++ {
+			r := runes[j]
+			if isSeparator(r) {
+				continue
+			}
+			next, ok := cur.children[unicode.ToLower(r)]
+			if !ok {
+				break
+			}
+			cur = next
+			if cur.word != "" {
+				matched, end = cur.word, j
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		if !seen[matched] {
+			seen[matched] = true
+			found = append(found, matched)
+		}
+		i = end
+	}
+	return found
+}
+
+// isSeparator 匹配时跳过的字符：空白、标点和符号
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
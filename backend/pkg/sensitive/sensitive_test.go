@@ -0,0 +1,50 @@
+package sensitive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcher_Find(t *testing.T) {
+	m := New([]string{"赌博", "赌博网站", "Casino", "  "})
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"最长匹配", "欢迎访问赌博网站", []string{"赌博网站"}},
+		{"夹杂分隔符", "这里有 赌-博 信息", []string{"赌博"}},
+		{"大小写不敏感，返回标准形式", "online CASINO here", []string{"casino"}},
+		{"去重", "赌博赌博", []string{"赌博"}},
+		{"无匹配", "正常的留言", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Find(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %v, 期望 %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	var m *Matcher
+	if got := m.Find("任何内容"); got != nil {
+		t.Errorf("nil 匹配器应返回 nil, 实际 %v", got)
+	}
+	if got := New(nil).Find("任何内容"); got != nil {
+		t.Errorf("空词表应返回 nil, 实际 %v", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, word := range []string{"Casino", "CASINO", " casino ", "Ca-si no"} {
+		if got := Normalize(word); got != "casino" {
+			t.Errorf("Normalize(%q) = %q, 期望 casino", word, got)
+		}
+	}
+	if got := New([]string{"Casino", "casino"}).Find("casino"); !reflect.DeepEqual(got, []string{"casino"}) {
+		t.Errorf("只有大小写不同的词应视为同一个词, 实际 %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// MaxLength slug 最大长度（字节），超出时在单词边界截断
+const MaxLength = 80
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Make 根据标题生成 URL 友好的 slug：英文数字转小写，汉字转拼音，其余字符作为分隔符。
+// 无法生成任何内容时返回空字符串，由调用方决定兜底方案
+func Make(title string) string {
+	var words []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range title {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			current.WriteRune(unicode.ToLower(r))
+		case unicode.Is(unicode.Han, r):
+			flush()
+			if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 && py[0] != "" {
+				words = append(words, py[0])
+			}
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return truncate(words)
+}
+
+// IsValid 判断字符串是否为合法 slug：小写字母、数字和单个连字符组成
+func IsValid(s string) bool {
+	if s == "" || len(s) > MaxLength || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9':
+		case ch == '-' && s[i-1] != '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func truncate(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		extra := len(w)
+		if b.Len() > 0 {
+			extra++
+		}
+		if b.Len()+extra > MaxLength {
+			if b.Len() == 0 {
+				b.WriteString(w[:MaxLength])
+			}
+			break
+		}
+		if b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
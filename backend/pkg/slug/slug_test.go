@@ -0,0 +1,51 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	cases := []struct {
+		title string
+		want  string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"Go + Gin 后端开发", "go-gin-hou-duan-kai-fa"},
+		{"React18新特性", "react18-xin-te-xing"},
+		{"  --- ", ""},
+		{"😀😀", ""},
+	}
+
+	for _, tc := range cases {
+		if got := Make(tc.title); got != tc.want {
+			t.Errorf("Make(%q) = %q, 期望 %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestMake_Truncate(t *testing.T) {
+	got := Make(strings.Repeat("word ", 40))
+	if len(got) > MaxLength {
+		t.Errorf("slug 长度 %d 超过上限 %d", len(got), MaxLength)
+	}
+	if strings.HasSuffix(got, "-") {
+		t.Errorf("slug 不应以连字符结尾: %q", got)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	valid := []string{"hello", "hello-world", "go-1-24"}
+	invalid := []string{"", "-hello", "hello-", "hello--world", "Hello", "你好", "a_b"}
+
+	for _, s := range valid {
+		if !IsValid(s) {
+			t.Errorf("IsValid(%q) 应为 true", s)
+		}
+	}
+	for _, s := range invalid {
+		if IsValid(s) {
+			t.Errorf("IsValid(%q) 应为 false", s)
+		}
+	}
+}
@@ -13,10 +13,23 @@ db.refresh_tokens.createIndex(
   { expireAfterSeconds: 0, name: "idx_expires_at_ttl" }
 );
 
-// token 查询索引
+// selector 查询索引（令牌只保存 selector 与哈希）
+db.refresh_tokens.createIndex(
+  { "selector": 1 },
+  { unique: true, sparse: true, name: "idx_selector_unique" }
+);
+
+// 删除旧版 token 唯一索引：新令牌不再有 token 字段，非稀疏唯一索引会在第二次插入时冲突
+["idx_token_unique", "idx_token_revoked"].forEach(function(name) {
+  if (db.refresh_tokens.getIndexes().some(function(idx) { return idx.name === name; })) {
+    db.refresh_tokens.dropIndex(name);
+  }
+});
+
+// 旧版明文 token 索引（仅用于迁移，迁移完成后可删除）
 db.refresh_tokens.createIndex(
   { "token": 1 },
-  { unique: true, name: "idx_token_unique" }
+  { sparse: true, name: "idx_token_legacy" }
 );
 
 // user_id 索引（用于查询用户的所有 token）
@@ -25,12 +38,13 @@ db.refresh_tokens.createIndex(
   { name: "idx_user_id" }
 );
 
-// 复合索引：token + revoked（常用查询条件）
+// family_id 索引（用于吊销同一次登录轮换出的全部 token）
 db.refresh_tokens.createIndex(
-  { "token": 1, "revoked": 1 },
-  { name: "idx_token_revoked" }
+  { "family_id": 1 },
+  { name: "idx_family_id" }
 );
 
+
 // messages 集合索引
 print("==> 创建 messages 索引");
 
@@ -46,6 +60,84 @@ db.messages.createIndex(
   { name: "idx_created_at_desc" }
 );
 
+// 审核状态索引（公开列表只显示已通过的留言，审核队列按状态查询）
+db.messages.createIndex(
+  { "status": 1, "created_at": -1 },
+  { name: "idx_status_created_at" }
+);
+
+db.messages.createIndex(
+  { "replies.status": 1 },
+  { name: "idx_replies_status" }
+);
+
+// 垃圾内容检测：按用户查询近期发言
+db.messages.createIndex(
+  { "user_id": 1, "created_at": -1 },
+  { name: "idx_user_id_created_at" }
+);
+
+db.messages.createIndex(
+  { "replies.user_id": 1, "replies.created_at": -1 },
+  { name: "idx_replies_user_id_created_at" }
+);
+
+// sensitive_words 集合索引
+print("==> 创建 sensitive_words 索引");
+
+db.sensitive_words.createIndex(
+  { "word": 1 },
+  { unique: true, name: "idx_word_unique" }
+);
+
+// revoked_access_tokens 集合索引
+print("==> 创建 revoked_access_tokens 索引");
+
+// access token 过期后黑名单记录自动删除
+db.revoked_access_tokens.createIndex(
+  { "expires_at": 1 },
+  { expireAfterSeconds: 0, name: "idx_expires_at_ttl" }
+);
+
+db.revoked_access_tokens.createIndex(
+  { "user_id": 1 },
+  { name: "idx_user_id" }
+);
+
+// password_reset_tokens 集合索引
+print("==> 创建 password_reset_tokens 索引");
+
+db.password_reset_tokens.createIndex(
+  { "selector": 1 },
+  { unique: true, name: "idx_selector_unique" }
+);
+
+// 作废用户未使用的令牌
+db.password_reset_tokens.createIndex(
+  { "user_id": 1 },
+  { name: "idx_user_id" }
+);
+
+// 过期令牌自动删除
+db.password_reset_tokens.createIndex(
+  { "expires_at": 1 },
+  { expireAfterSeconds: 0, name: "idx_expires_at_ttl" }
+);
+
+// audit_logs 集合索引
+print("==> 创建 audit_logs 索引");
+
+// 按事件类型查询最近的审计记录
+db.audit_logs.createIndex(
+  { "event": 1, "created_at": -1 },
+  { name: "idx_event_created_at" }
+);
+
+db.audit_logs.createIndex(
+  { "user_id": 1, "created_at": -1 },
+  { name: "idx_user_id_created_at" }
+);
+
 // articles 集合索引
 print("==> 创建 articles 索引");
 
@@ -55,16 +147,113 @@ db.articles.createIndex(
   { name: "idx_tag_pageviews" }
 );
 
+// tags + page_views 复合索引（用于多标签筛选）
+db.articles.createIndex(
+  { "tags": 1, "page_views": -1 },
+  { name: "idx_tags_pageviews" }
+);
+
+// category_id 索引（用于分类筛选）
+db.articles.createIndex(
+  { "category_id": 1 },
+  { name: "idx_category_id" }
+);
+
+// series_id + series_order 复合索引（用于系列内排序）
+db.articles.createIndex(
+  { "series_id": 1, "series_order": 1 },
+  { name: "idx_series_order" }
+);
+
+// slug 唯一索引（旧数据未生成 slug 前允许缺失）
+db.articles.createIndex(
+  { "slug": 1 },
+  { unique: true, partialFilterExpression: { slug: { $type: "string" } }, name: "idx_slug_unique" }
+);
+
+// 历史 slug 索引（用于重定向）
+db.articles.createIndex(
+  { "old_slugs": 1 },
+  { name: "idx_old_slugs" }
+);
+
+// status + publish_at 复合索引（用于公开文章过滤和定时发布任务）
+db.articles.createIndex(
+  { "status": 1, "publish_at": 1 },
+  { name: "idx_status_publish_at" }
+);
+
 // page_views 索引（用于热门文章查询）
 db.articles.createIndex(
   { "page_views": -1 },
   { name: "idx_pageviews_desc" }
 );
 
-// 标题文本索引（用于搜索）
-db.articles.createIndex(
-  { "title": "text", "tag": "text" },
-  { name: "idx_title_tag_text", default_language: "none" }
+// 搜索使用 article_terms 分词索引，删除旧版本创建的文本索引
+db.articles.getIndexes()
+  .filter(function(idx) { return idx.key && idx.key._fts === "text"; })
+  .forEach(function(idx) { db.articles.dropIndex(idx.name); });
+
+// tags 集合索引（标签名与 slug 均唯一）
+print("==> 创建 tags 索引");
+
+db.tags.createIndex(
+  { "name": 1 },
+  { unique: true, name: "idx_name_unique" }
+);
+
+db.tags.createIndex(
+  { "slug": 1 },
+  { unique: true, name: "idx_slug_unique" }
+);
+
+// categories 集合索引
+print("==> 创建 categories 索引");
+
+db.categories.createIndex(
+  { "slug": 1 },
+  { unique: true, name: "idx_slug_unique" }
+);
+
+db.categories.createIndex(
+  { "parent_id": 1, "sort": 1 },
+  { name: "idx_parent_sort" }
+);
+
+// series 集合索引
+print("==> 创建 series 索引");
+
+db.series.createIndex(
+  { "slug": 1 },
+  { unique: true, name: "idx_slug_unique" }
+);
+
+// comments 集合索引
+print("==> 创建 comments 索引");
+
+// article_id + created_at 复合索引（用于分页获取顶层评论）
+db.comments.createIndex(
+  { "article_id": 1, "created_at": -1 },
+  { name: "idx_article_created" }
+);
+
+// root_id 索引（用于获取楼层回复）
+db.comments.createIndex(
+  { "root_id": 1, "created_at": 1 },
+  { name: "idx_root_created" }
+);
+
+// article_terms 集合索引（文章分词索引，_id 即文章 ID）
+print("==> 创建 article_terms 索引");
+
+db.article_terms.createIndex(
+  { "title_terms": 1 },
+  { name: "idx_title_terms" }
+);
+
+db.article_terms.createIndex(
+  { "content_terms": 1 },
+  { name: "idx_content_terms" }
 );
 
 // users 集合索引
@@ -76,6 +265,45 @@ db.users.createIndex(
   { unique: true, name: "idx_username_unique" }
 );
 
+// 已验证的邮箱唯一（未验证的邮箱允许重复，避免被抢占）
+db.users.createIndex(
+  { "email": 1 },
+  { unique: true, partialFilterExpression: { email_verified: true }, name: "idx_email_verified_unique" }
+);
+
+// email_verifications 集合索引
+print("==> 创建 email_verifications 索引");
+
+db.email_verifications.createIndex(
+  { "selector": 1 },
+  { unique: true, name: "idx_selector_unique" }
+);
+
+db.email_verifications.createIndex(
+  { "user_id": 1 },
+  { name: "idx_user_id" }
+);
+
+db.email_verifications.createIndex(
+  { "expires_at": 1 },
+  { expireAfterSeconds: 0, name: "idx_expires_at_ttl" }
+);
+
+// mail_outbox 集合索引
+print("==> 创建 mail_outbox 索引");
+
+// 后台任务按到期时间领取待发送邮件
+db.mail_outbox.createIndex(
+  { "status": 1, "next_attempt_at": 1 },
+  { name: "idx_status_next_attempt_at" }
+);
+
+// 已发送的邮件保留 30 天
+db.mail_outbox.createIndex(
+  { "sent_at": 1 },
+  { expireAfterSeconds: 2592000, name: "idx_sent_at_ttl" }
+);
+
 // visitors 集合索引
 print("==> 创建 visitors 索引");
 
@@ -96,7 +324,7 @@ print("");
 print("索引列表:");
 print("==========");
 
-["refresh_tokens", "messages", "articles", "users", "visitors"].forEach(function(coll) {
+["refresh_tokens", "messages", "articles", "tags", "categories", "series", "comments", "article_terms", "sensitive_words", "revoked_access_tokens", "password_reset_tokens", "audit_logs", "users", "email_verifications", "mail_outbox", "visitors"].forEach(function(coll) {
   print("\n" + coll + ":");
   db[coll].getIndexes().forEach(function(idx) {
     print("  - " + idx.name + ": " + JSON.stringify(idx.key));
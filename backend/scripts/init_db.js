@@ -4,25 +4,59 @@ use('blog_go');
 // 清理旧数据（如果存在）
 db.users.drop();
 db.articles.drop();
+// 旧版统计集合，统计信息现已实时聚合
 db.article_infos.drop();
 db.messages.drop();
 db.visitors.drop();
 db.refresh_tokens.drop();
+db.article_terms.drop();
+db.tags.drop();
+db.categories.drop();
+db.series.drop();
+db.comments.drop();
+db.sensitive_words.drop();
+db.audit_logs.drop();
+db.revoked_access_tokens.drop();
+db.password_reset_tokens.drop();
+db.email_verifications.drop();
+db.mail_outbox.drop();
 
 print('--- 创建集合和索引 ---');
 
 // 创建用户集合和索引
 db.createCollection('users');
 db.users.createIndex({ user_name: 1 }, { unique: true });
+db.users.createIndex({ email: 1 }, { unique: true, partialFilterExpression: { email_verified: true } });
 
 // 创建文章集合和索引
 db.createCollection('articles');
 db.articles.createIndex({ tag: 1 });
+db.articles.createIndex({ tags: 1 });
+
+// 创建标签集合和索引（文章数由服务启动时同步）
+db.createCollection('tags');
+db.tags.createIndex({ name: 1 }, { unique: true });
+db.tags.createIndex({ slug: 1 }, { unique: true });
 db.articles.createIndex({ page_views: -1 });
-db.articles.createIndex({ title: 'text', tag: 'text' });
 
-// 创建文章信息集合
-db.createCollection('article_infos');
+// 创建分类和系列集合
+db.createCollection('categories');
+db.categories.createIndex({ slug: 1 }, { unique: true });
+db.categories.createIndex({ parent_id: 1, sort: 1 });
+db.createCollection('series');
+db.series.createIndex({ slug: 1 }, { unique: true });
+db.articles.createIndex({ category_id: 1 });
+db.articles.createIndex({ series_id: 1, series_order: 1 });
+
+// 创建文章分词索引集合（由服务启动时自动补建）
+db.createCollection('article_terms');
+db.article_terms.createIndex({ title_terms: 1 });
+db.article_terms.createIndex({ content_terms: 1 });
+
+// 创建文章评论集合
+db.createCollection('comments');
+db.comments.createIndex({ article_id: 1, created_at: -1 });
+db.comments.createIndex({ root_id: 1, created_at: 1 });
 
 // 创建留言集合
 db.createCollection('messages');
@@ -35,10 +69,31 @@ db.visitors.createIndex({ user_id: 1 });
 
 // 创建刷新令牌集合
 db.createCollection('refresh_tokens');
-db.refresh_tokens.createIndex({ token: 1 }, { unique: true });
+db.refresh_tokens.createIndex({ selector: 1 }, { unique: true, sparse: true });
 db.refresh_tokens.createIndex({ user_id: 1 });
 db.refresh_tokens.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
 
+// 创建 access token 黑名单集合
+db.createCollection('revoked_access_tokens');
+db.revoked_access_tokens.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
+
+// 创建密码重置令牌集合
+db.createCollection('password_reset_tokens');
+db.password_reset_tokens.createIndex({ selector: 1 }, { unique: true });
+db.password_reset_tokens.createIndex({ user_id: 1 });
+db.password_reset_tokens.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
+
+// 创建邮箱验证令牌集合
+db.createCollection('email_verifications');
+db.email_verifications.createIndex({ selector: 1 }, { unique: true });
+db.email_verifications.createIndex({ user_id: 1 });
+db.email_verifications.createIndex({ expires_at: 1 }, { expireAfterSeconds: 0 });
+
+// 创建发件箱集合
+db.createCollection('mail_outbox');
+db.mail_outbox.createIndex({ status: 1, next_attempt_at: 1 });
+db.mail_outbox.createIndex({ sent_at: 1 }, { expireAfterSeconds: 2592000 });
+
 print('--- 插入测试数据 ---');
 
 // 插入管理员用户 (密码: 123456)
@@ -61,22 +116,18 @@ db.users.insertOne({
   is_admin: false
 });
 
-// 插入文章标签配置
-db.article_infos.insertOne({
-  tags: ['HTML&Css', 'JavaScript', 'Node', 'Vue&React', 'Go', 'Other'],
-  total_count: 100
-});
-
 // 插入示例文章
 const tags = ['HTML&Css', 'JavaScript', 'Node', 'Vue&React', 'Go'];
 const types = ['原创', '转载'];
 
 for (let i = 1; i <= 10; i++) {
+  const tag = tags[Math.floor(Math.random() * tags.length)];
   db.articles.insertOne({
     article_type: types[Math.floor(Math.random() * 2)],
     title: `示例文章 ${i} - Go + Gin 后端开发`,
     content: `<h2>文章内容</h2><p>这是第 ${i} 篇示例文章的内容。</p><p>本项目使用 Go + Gin 框架重构，数据库使用 MongoDB，认证方式采用 JWT Token。</p><h3>主要特性</h3><ul><li>RESTful API 设计</li><li>JWT Token 认证</li><li>bcrypt 密码加密</li><li>MongoDB 数据存储</li></ul>`,
-    tag: tags[Math.floor(Math.random() * tags.length)],
+    tag: tag,
+    tags: [tag],
     updated_at: new Date(),
     created_at: new Date(),
     cover_image: 'http://localhost:3000/img/default_cover.jpg',
@@ -88,6 +139,5 @@ for (let i = 1; i <= 10; i++) {
 print('--- 验证数据 ---');
 print('用户数量: ' + db.users.countDocuments());
 print('文章数量: ' + db.articles.countDocuments());
-print('文章配置: ' + db.article_infos.countDocuments());
 
 print('--- 数据库初始化完成 ---');
@@ -77,15 +77,7 @@ for (let i = 1; i <= 10; i++) {
 db.articles.insertMany(articles);
 print("已创建 " + articles.length + " 篇文章");
 
-// 3. 创建文章统计信息
-db.article_infos.insertOne({
-  _id: ObjectId(),
-  tags: tags,
-  total_count: articles.length
-});
-print("已创建文章统计信息");
-
-// 4. 创建访客记录
+// 3. 创建访客记录
 const visitors = [
   {
     _id: ObjectId(),
@@ -107,7 +99,7 @@ const visitors = [
 db.visitors.insertMany(visitors);
 print("已创建 " + visitors.length + " 条访客记录");
 
-// 5. 创建留言数据
+// 4. 创建留言数据
 const messages = [
   {
     _id: ObjectId(),
@@ -159,7 +151,6 @@ print("已创建 " + messages.length + " 条留言");
 db.users.createIndex({ user_name: 1 }, { unique: true });
 db.articles.createIndex({ tag: 1 });
 db.articles.createIndex({ page_views: -1 });
-db.articles.createIndex({ title: "text", tag: "text" });
 db.visitors.createIndex({ user_id: 1 });
 db.visitors.createIndex({ visited_at: -1 });
 db.messages.createIndex({ created_at: -1 });